@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey/authenticate"
+	"kiln-exercice/pkg/api"
+	grpcapi "kiln-exercice/pkg/grpc/api"
+	httpapi "kiln-exercice/pkg/http/api"
+	xtzv1 "kiln-exercice/proto/xtz/v1"
+)
+
+// grpcScopes are the scopes of the gRPC methods.
+var grpcScopes = map[string]string{
+	xtzv1.DelegationService_ListDelegations_FullMethodName:   model.ScopeRead,
+	xtzv1.DelegationService_GetDelegations_FullMethodName:    model.ScopeRead,
+	xtzv1.DelegationService_ExportDelegations_FullMethodName: model.ScopeExport,
+}
+
+// grpcHeavyMethods are the gRPC methods within the heavy rate limit, like their HTTP routes.
+var grpcHeavyMethods = []string{
+	xtzv1.DelegationService_ListDelegations_FullMethodName,
+	xtzv1.DelegationService_ExportDelegations_FullMethodName,
+}
+
+// authenticator authenticates the API keys of the clients.
+func authenticator(uc *authenticate.UseCase) api.Authenticator {
+	return func(ctx context.Context, key, scope string) (api.Client, error) {
+		out, err := uc.Authenticate(ctx, authenticate.Input{Secret: key, Scope: scope})
+		if err != nil {
+			return api.Client{}, err
+		}
+
+		return api.Client{ID: out.ID, Name: out.Name, Admin: out.Admin}, nil
+	}
+}
+
+// anonymousScopes are the scopes granted to the clients without an API key.
+func anonymousScopes(anonymousRead bool) []string {
+	if anonymousRead {
+		return []string{model.ScopeRead}
+	}
+
+	return nil
+}
+
+// authentication authenticates the clients of the HTTP routes requiring a scope.
+func authentication(auth api.Authenticator, anonymous []string) func(scope string) middleware {
+	return func(scope string) middleware {
+		return func(h http.Handler) http.Handler {
+			return httpapi.Authenticated(auth, scope, slices.Contains(anonymous, scope), h)
+		}
+	}
+}
+
+// grpcAuthentication authenticates the clients of the gRPC methods.
+func grpcAuthentication(auth api.Authenticator, anonymous []string) grpcapi.Authenticator {
+	return grpcapi.Authenticator{Auth: auth, Scopes: grpcScopes, Anonymous: anonymous}
+}
+
+// grpcRateLimit limits the calls of each gRPC method like rateLimit does the HTTP routes, with limiters of its own.
+// A zero rate disables a limit.
+func grpcRateLimit(standard, heavy httpapi.Limit) grpcapi.RateLimiter {
+	limiters := make(map[string]grpcapi.Limiter)
+
+	for method := range grpcScopes {
+		limit := standard
+		if slices.Contains(grpcHeavyMethods, method) {
+			limit = heavy
+		}
+
+		if limit.Rate > 0 {
+			limiters[method] = httpapi.NewRateLimiter(limit, nil, nil, time.Now)
+		}
+	}
+
+	return grpcapi.RateLimiter{Limiters: limiters}
+}
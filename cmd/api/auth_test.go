@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"kiln-exercice/pkg/api"
+	grpcapi "kiln-exercice/pkg/grpc/api"
+	httpapi "kiln-exercice/pkg/http/api"
+	xtzv1 "kiln-exercice/proto/xtz/v1"
+)
+
+func TestGRPCScopes(t *testing.T) {
+	t.Parallel()
+
+	desc := xtzv1.DelegationService_ServiceDesc
+	methods := make([]string, 0, len(desc.Methods)+len(desc.Streams))
+
+	for _, m := range desc.Methods {
+		methods = append(methods, "/"+desc.ServiceName+"/"+m.MethodName)
+	}
+
+	for _, s := range desc.Streams {
+		methods = append(methods, "/"+desc.ServiceName+"/"+s.StreamName)
+	}
+
+	for _, method := range methods {
+		assert.Contains(t, grpcScopes, method, "method without scope")
+	}
+
+	// A method missing from the scopes is denied, even to anonymous clients granted reading.
+	scopes := maps.Clone(grpcScopes)
+	delete(scopes, xtzv1.DelegationService_ListDelegations_FullMethodName)
+
+	auth := grpcapi.Authenticator{
+		Auth: func(context.Context, string, string) (api.Client, error) {
+			return api.Client{}, errors.New("unexpected authentication")
+		},
+		Scopes:    scopes,
+		Anonymous: anonymousScopes(true),
+	}
+
+	_, err := auth.UnaryServerInterceptor(
+		context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: xtzv1.DelegationService_ListDelegations_FullMethodName},
+		func(context.Context, any) (any, error) { return nil, nil },
+	)
+
+	var apiErr *api.Error
+	require.True(t, errors.As(err, &apiErr), "error = %v", err)
+	assert.Equal(t, api.PermissionDenied, apiErr.Code)
+}
+
+func TestGRPCRateLimit(t *testing.T) {
+	t.Parallel()
+
+	// With a single token per client, the heavy methods are limited at once, unlike the standard ones.
+	l := grpcRateLimit(httpapi.Limit{Rate: 0.001, Burst: 2}, httpapi.Limit{Rate: 0.001, Burst: 1})
+	assert.Len(t, l.Limiters, len(grpcScopes))
+
+	for method, wantSecond := range map[string]bool{
+		xtzv1.DelegationService_ListDelegations_FullMethodName:   false,
+		xtzv1.DelegationService_ExportDelegations_FullMethodName: false,
+		xtzv1.DelegationService_GetDelegations_FullMethodName:    true,
+	} {
+		require.True(t, l.Limiters[method].Take("ip:192.0.2.1", 1), method)
+		assert.Equal(t, wantSecond, l.Limiters[method].Take("ip:192.0.2.1", 1), method)
+	}
+
+	assert.Empty(t, grpcRateLimit(httpapi.Limit{}, httpapi.Limit{}).Limiters, "zero rates disable the limits")
+}
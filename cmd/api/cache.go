@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/cache"
+)
+
+// aggregateCacheNamespace prefixes the keys of the aggregate cache, which may share its Redis with other data.
+const aggregateCacheNamespace = "kiln:aggregates"
+
+type CacheParameters struct {
+	Backend    string `env:"CACHE_BACKEND"` // "memory" or "redis", the cache is disabled when empty.
+	RedisURL   string `env:"REDIS_URL" env-default:"redis://localhost:6379/0"`
+	TTLSeconds int    `env:"CACHE_TTL_SECONDS" env-default:"600"`
+}
+
+// newAggregateCache returns the cache of the aggregate queries, nil when it is disabled.
+// The Redis backend is shared by every replica of the API.
+func newAggregateCache(params CacheParameters) (*cache.Cache, error) {
+	var backend cache.Backend
+
+	switch params.Backend {
+	case "":
+		return nil, nil
+	case "memory":
+		backend = cache.NewMemory(time.Now)
+	case "redis":
+		opts, err := redis.ParseURL(params.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis url: %w", err)
+		}
+
+		backend = cache.NewRedis(redis.NewClient(opts))
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q, must be memory or redis", params.Backend)
+	}
+
+	return cache.New(backend, aggregateCacheNamespace, time.Duration(params.TTLSeconds)*time.Second), nil
+}
+
+// invalidateOnInsert invalidates the cache whenever the poller commits delegations, until the notifier is closed.
+func invalidateOnInsert(c *cache.Cache, notifier list.Notifier) {
+	notifications, unsubscribe := notifier.Subscribe()
+	defer unsubscribe()
+
+	for range notifications {
+		if err := c.Invalidate(context.Background()); err != nil {
+			log.Error().Err(err).Msg("error invalidating the aggregate cache")
+		}
+	}
+}
@@ -14,22 +14,45 @@ import (
 	env "github.com/ilyakaznacheev/cleanenv"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
-	httphandler "kiln-exercice/internal/handler/delegation"
+	"kiln-exercice/internal/graph"
+	grpcdelegation "kiln-exercice/internal/grpc/delegation"
 	pgrepo "kiln-exercice/internal/pg"
-	"kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/pkg/breaker"
+	grpcapi "kiln-exercice/pkg/grpc/api"
+	"kiln-exercice/pkg/http/api"
 	"kiln-exercice/pkg/pg"
+	"kiln-exercice/pkg/tzkt"
+	xtzv1 "kiln-exercice/proto/xtz/v1"
 )
 
 var (
 	serverPort               = flag.String("port", "8080", "server port")
+	grpcPort                 = flag.String("grpc-port", "9090", "gRPC server port")
 	gracefulShutdownTimeout  = flag.Duration("graceful-shutdown-timeout", 15*time.Second, "graceful shutdown timeout")
 	gracelessShutdownTimeout = flag.Duration("graceless-shutdown-timeout", 15*time.Second, "graceless shutdown timeout")
 	readTimeout              = flag.Duration("read-timeout", 15*time.Second, "read timeout")
+	streamHeartbeat          = flag.Duration("stream-heartbeat", 15*time.Second, "heartbeat period of idle event streams")
+	cacheSize                = flag.Int("cache-size", 1024, "number of responses kept in the response cache, 0 to disable it")
+	rateLimitRate            = flag.Float64("rate-limit", 10, "requests per second allowed per client and route or gRPC method, 0 to disable the limit")
+	rateLimitBurst           = flag.Int("rate-limit-burst", 20, "requests a client may burst per route or gRPC method")
+	heavyRateLimitRate       = flag.Float64("heavy-rate-limit", 1, "requests per second allowed per client on the delegation list, export, statistics and GraphQL routes and gRPC methods, 0 to disable the limit")
+	heavyRateLimitBurst      = flag.Int("heavy-rate-limit-burst", 10, "requests a client may burst on the delegation list, export, statistics and GraphQL routes and gRPC methods")
+	anonymousRead            = flag.Bool("anonymous-read", true, "serve the read routes to clients without an API key")
+	maxPollingLag            = flag.Duration("max-polling-lag", 10*time.Minute, "lag of the last poll behind the chain head beyond which the API is not ready, 0 to disable the check")
+	maxDelegationLag         = flag.Duration("max-delegation-lag", 0, "lag of the latest delegation behind the chain head beyond which the API is not ready, 0 to disable the check")
+	v1Deprecation            = flag.String("v1-deprecation", "2026-10-19", "date the v1 API is deprecated, as YYYY-MM-DD")
+	v1Sunset                 = flag.String("v1-sunset", "2027-04-19", "date the v1 API is removed, as YYYY-MM-DD")
 )
 
 type Parameters struct {
-	DB pg.Parameters
+	DB    pg.Parameters
+	Cache CacheParameters
+
+	TzktURL string `env:"TZKT_URL" env-default:"https://api.tzkt.io"` // Gives the chain head the data is compared to.
 }
 
 func main() {
@@ -51,20 +74,99 @@ func main() {
 	}
 	defer db.Close()
 
-	delegationRepo := pgrepo.NewDelegationRepository(db, 0) // no insert in the api
+	delegationListener, err := pg.NewListener(params.DB, pgrepo.DelegationChannel)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error listening to delegation insertions")
+	}
+
+	aggregateCache, err := newAggregateCache(params.Cache)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating the aggregate cache")
+	}
+
+	if aggregateCache != nil {
+		go invalidateOnInsert(aggregateCache, delegationListener)
+	}
+
+	// The breaker spares the requests of the X-Data-Lag header waiting for an unreachable TzKT.
+	tzktSDK, err := tzkt.NewSDK(params.TzktURL, tzkt.WithCircuitBreaker(breaker.New(3, time.Minute, time.Now)))
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating tzkt sdk")
+	}
+
+	uc := newUseCases(
+		db, delegationListener, *streamHeartbeat, aggregateCache, tzktSDK,
+		freshness.Thresholds{MaxPollingLag: *maxPollingLag, MaxDelegationLag: *maxDelegationLag},
+	)
+
+	deprecation, err := parseDeprecation(*v1Deprecation, *v1Sunset)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error parsing the v1 deprecation dates")
+	}
+
+	var (
+		auth      = authenticator(uc.apiKeyAuth)
+		anonymous = anonymousScopes(*anonymousRead)
+		grpcAuth  = grpcAuthentication(auth, anonymous)
+		standard  = api.Limit{Rate: *rateLimitRate, Burst: *rateLimitBurst}
+		heavy     = api.Limit{Rate: *heavyRateLimitRate, Burst: *heavyRateLimitBurst}
+		grpcLimit = grpcRateLimit(standard, heavy)
+	)
+
+	a := access{
+		authenticate: authentication(auth, anonymous),
+		limits: rateLimits{
+			standard: rateLimit(standard, api.PageCost),
+			heavy:    rateLimit(heavy, api.PageCost),
+			graphql:  rateLimit(heavy, graph.QueryCost),
+		},
+	}
+
+	// The routes reading stored data tell how fresh it is, and are cached.
+	stored := chain(dataLag(uc.healthFreshness), responseCache(*cacheSize, pgrepo.NewDelegationRepository(db, 0)))
 
-	// For the sake of simplicity, we define the routes here.
-	r := http.NewServeMux()
-	r.Handle("GET /xtz/delegations", httphandler.NewDelegationHandler(list.NewUseCase(delegationRepo)))
+	r, err := newRouter(uc, deprecation, stored, a, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error parsing the OpenAPI document")
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcapi.RecoveryUnaryServerInterceptor, grpcapi.UnaryServerInterceptor,
+			grpcAuth.UnaryServerInterceptor, grpcLimit.UnaryServerInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			grpcapi.RecoveryStreamServerInterceptor, grpcapi.StreamServerInterceptor,
+			grpcAuth.StreamServerInterceptor, grpcLimit.StreamServerInterceptor,
+		),
+	)
+	xtzv1.RegisterDelegationServiceServer(
+		grpcServer, grpcdelegation.NewServer(uc.delegationList, uc.delegationGet, uc.delegationExport),
+	)
+	reflection.Register(grpcServer)
+
+	// Event streams never end by themselves, closing the listener ends them when the server shuts down.
+	onShutdown := func() {
+		if err := delegationListener.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing delegation listener")
+		}
+	}
 
-	if err = listenAndServe(ctx, r); err != nil {
+	if err = listenAndServe(ctx, r, grpcServer, onShutdown); err != nil {
 		log.Fatal().Err(err).Msg("server error")
 	}
 
 	log.Info().Msg("Server stopped")
 }
 
-func listenAndServe(ctx context.Context, mux *http.ServeMux) error {
+// listenAndServe serves handler and grpcServer until an interrupt signal, then shuts the servers down,
+// calling onShutdown first.
+func listenAndServe(ctx context.Context, handler http.Handler, grpcServer *grpc.Server, onShutdown func()) error {
+	grpcListener, err := net.Listen("tcp", ":"+*grpcPort)
+	if err != nil {
+		return err
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	ctx, cancel := signal.NotifyContext(
@@ -75,13 +177,14 @@ func listenAndServe(ctx context.Context, mux *http.ServeMux) error {
 	reqCtx, reqCancel := context.WithCancel(context.Background())
 
 	server := http.Server{
-		Handler:     mux,
+		Handler:     handler,
 		Addr:        ":" + *serverPort,
 		ReadTimeout: *readTimeout,
 		BaseContext: func(net.Listener) context.Context {
 			return reqCtx // Sets the parent context for each incoming request allowing to cancel all of them.
 		},
 	}
+	server.RegisterOnShutdown(onShutdown)
 
 	g.Go(
 		func() error {
@@ -92,7 +195,13 @@ func listenAndServe(ctx context.Context, mux *http.ServeMux) error {
 		},
 	)
 
-	log.Info().Msgf("Server started on port %s", *serverPort)
+	g.Go(
+		func() error {
+			return grpcServer.Serve(grpcListener) // Returns nil once stopped.
+		},
+	)
+
+	log.Info().Msgf("Server started on port %s, gRPC on port %s", *serverPort, *grpcPort)
 
 	<-ctx.Done()
 
@@ -114,5 +223,23 @@ func listenAndServe(ctx context.Context, mux *http.ServeMux) error {
 		},
 	)
 
+	g.Go(
+		func() error {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-time.After(*gracefulShutdownTimeout):
+				grpcServer.Stop() // Cancels the remaining calls, like reqCancel for HTTP requests.
+			}
+
+			return nil
+		},
+	)
+
 	return errors.Join(g.Wait())
 }
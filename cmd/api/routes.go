@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+
+	"kiln-exercice/internal/graph"
+	apikeyhandler "kiln-exercice/internal/handler/apikey"
+	bakerhandler "kiln-exercice/internal/handler/baker"
+	httphandler "kiln-exercice/internal/handler/delegation"
+	delegatorhandler "kiln-exercice/internal/handler/delegator"
+	healthhandler "kiln-exercice/internal/handler/health"
+	webhookhandler "kiln-exercice/internal/handler/webhook"
+	"kiln-exercice/internal/model"
+	pgrepo "kiln-exercice/internal/pg"
+	"kiln-exercice/internal/usecase/apikey/authenticate"
+	"kiln-exercice/internal/usecase/apikey/usage"
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	"kiln-exercice/internal/usecase/delegation/get"
+	"kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/internal/usecase/delegation/stats"
+	"kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/internal/usecase/health/ready"
+	webhookcreate "kiln-exercice/internal/usecase/webhook/create"
+	webhookdelete "kiln-exercice/internal/usecase/webhook/delete"
+	webhookdeliveries "kiln-exercice/internal/usecase/webhook/deliveries"
+	"kiln-exercice/pkg/cache"
+	"kiln-exercice/pkg/http/api"
+)
+
+// openAPI documents every route of the HTTP API. Its query parameters are validated before reaching the handlers.
+//
+//go:embed openapi.json
+var openAPI []byte
+
+// useCases are shared by the HTTP, GraphQL and gRPC APIs.
+type useCases struct {
+	delegationList    *list.UseCase
+	delegationGet     *get.UseCase
+	delegationExport  *list.ExportUseCase
+	delegationStream  *list.StreamUseCase
+	delegationStats   *stats.UseCase
+	bakerList         *bakerlist.UseCase
+	bakerGet          *bakerget.UseCase
+	delegatorProfile  *profile.UseCase
+	webhookCreate     *webhookcreate.UseCase
+	webhookDelete     *webhookdelete.UseCase
+	webhookDeliveries *webhookdeliveries.UseCase
+	apiKeyAuth        *authenticate.UseCase
+	apiKeyUsage       *usage.UseCase
+	healthFreshness   *freshness.UseCase
+	healthReady       *ready.UseCase
+}
+
+// freshnessTTL is how long the freshness of the data is reused, by the readiness checks and the X-Data-Lag header.
+const freshnessTTL = 5 * time.Second
+
+// newUseCases returns the use cases, whose aggregate queries are cached unless aggregateCache is nil.
+// The freshness of the data is measured against the chain head given by xtzSDK, and is stale beyond thresholds.
+func newUseCases(
+	db *sqlx.DB,
+	notifier list.Notifier,
+	streamHeartbeat time.Duration,
+	aggregateCache *cache.Cache,
+	xtzSDK freshness.XTZSDK,
+	thresholds freshness.Thresholds,
+) useCases {
+	delegationRepo := pgrepo.NewDelegationRepository(db, 0, pgrepo.WithCache(aggregateCache)) // no insert in the api
+	bakerRepo := pgrepo.NewBakerRepository(db, pgrepo.WithCache(aggregateCache))
+	webhookRepo := pgrepo.NewWebhookRepository(db)
+	apiKeyRepo := pgrepo.NewAPIKeyRepository(db)
+	healthFreshness := freshness.NewUseCase(
+		pgrepo.NewPollingRepository(db), delegationRepo, xtzSDK, thresholds, freshnessTTL, time.Now,
+	)
+
+	return useCases{
+		delegationList:    list.NewUseCase(delegationRepo),
+		delegationGet:     get.NewUseCase(delegationRepo),
+		delegationExport:  list.NewExportUseCase(delegationRepo),
+		delegationStream:  list.NewStreamUseCase(delegationRepo, notifier, streamHeartbeat),
+		delegationStats:   stats.NewUseCase(delegationRepo),
+		bakerList:         bakerlist.NewUseCase(bakerRepo),
+		bakerGet:          bakerget.NewUseCase(bakerRepo),
+		delegatorProfile:  profile.NewUseCase(delegationRepo),
+		webhookCreate:     webhookcreate.NewUseCase(webhookRepo),
+		webhookDelete:     webhookdelete.NewUseCase(webhookRepo),
+		webhookDeliveries: webhookdeliveries.NewUseCase(webhookRepo),
+		apiKeyAuth:        authenticate.NewUseCase(apiKeyRepo, time.Now),
+		apiKeyUsage:       usage.NewUseCase(apiKeyRepo, time.Now),
+		healthFreshness:   healthFreshness,
+		healthReady:       ready.NewUseCase(pgrepo.NewSchemaRepository(db), healthFreshness, pgrepo.SchemaVersion),
+	}
+}
+
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+// middleware wraps a handler.
+type middleware func(http.Handler) http.Handler
+
+// passThrough serves the routes as they are: uncached, unlimited or unauthenticated.
+func passThrough(h http.Handler) http.Handler {
+	return h
+}
+
+// responseCache caches the responses of the routes until the poller commits new data.
+func responseCache(size int, delegations *pgrepo.DelegationRepository) middleware {
+	c := api.NewResponseCache(size)
+
+	// Delegations are only ever inserted, by a single poller, so the last id changes exactly when new data lands,
+	// unlike the time of the last poll, which changes on every poll.
+	watermark := func(ctx context.Context) (string, error) {
+		id, err := delegations.GetLastDelegationID(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Itoa(id), nil
+	}
+
+	return func(h http.Handler) http.Handler {
+		return api.Cached(c, watermark, h)
+	}
+}
+
+// chain applies middlewares in order, the first one being the outermost.
+func chain(middlewares ...middleware) middleware {
+	return func(h http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			h = middlewares[i](h)
+		}
+
+		return h
+	}
+}
+
+// dataLag tells the clients of the routes how far the data lags behind the chain, in the X-Data-Lag header.
+func dataLag(f *freshness.UseCase) middleware {
+	lag := func(ctx context.Context) (time.Duration, bool) {
+		out, err := f.GetFreshness(ctx)
+		if err != nil {
+			return 0, false
+		}
+
+		return out.PollingLag()
+	}
+
+	return func(h http.Handler) http.Handler {
+		return api.WithDataLag(lag, h)
+	}
+}
+
+// rateLimits are the per-client rate limits of the routes.
+type rateLimits struct {
+	standard middleware
+	heavy    middleware // Of the routes reading many delegations at once.
+	graphql  middleware // Of the GraphQL route, within the heavy limit, by the rows a query reads.
+}
+
+// unlimited serves the routes without rate limit.
+var unlimited = rateLimits{standard: passThrough, heavy: passThrough, graphql: passThrough}
+
+// rateLimit gives each client of a route a bucket of the limit, taking as many tokens as a request costs. A zero
+// rate disables it. Clients are identified by their API key, or their IP address when anonymous.
+func rateLimit(limit api.Limit, cost api.RequestCost) middleware {
+	if limit.Rate <= 0 {
+		return passThrough
+	}
+
+	return func(h http.Handler) http.Handler {
+		return api.RateLimited(api.NewRateLimiter(limit, api.APIKeyOrIP, cost, time.Now), h)
+	}
+}
+
+// access authenticates the clients of the routes for a scope, then rate limits them.
+type access struct {
+	authenticate func(scope string) middleware
+	limits       rateLimits
+}
+
+// open serves the routes to every client, without limit.
+var open = access{authenticate: func(string) middleware { return passThrough }, limits: unlimited}
+
+// standard serves a route to the clients granted the scope, within the standard rate limit.
+func (a access) standard(scope string, h http.Handler) http.Handler {
+	return a.authenticate(scope)(a.limits.standard(h))
+}
+
+// heavy serves a route to the clients granted the scope, within the heavy rate limit.
+func (a access) heavy(scope string, h http.Handler) http.Handler {
+	return a.authenticate(scope)(a.limits.heavy(h))
+}
+
+// graphql serves the GraphQL route to the clients granted the scope, within the GraphQL rate limit.
+func (a access) graphql(scope string, h http.Handler) http.Handler {
+	return a.authenticate(scope)(a.limits.graphql(h))
+}
+
+// routes lists the routes of the HTTP API, which must match the operations of the OpenAPI document.
+// For the sake of simplicity, we define them here. Only the routes reading stored data, without streaming, are cached.
+// The routes but this document's and the health checks require a scope, and are rate limited, each with limiters of
+// its own.
+func routes(uc useCases, cache middleware, a access) []route {
+	const (
+		read     = model.ScopeRead
+		export   = model.ScopeExport
+		webhooks = model.ScopeWebhooks
+		admin    = model.ScopeAdmin
+	)
+
+	return []route{
+		{"GET /openapi.json", http.HandlerFunc(serveOpenAPI)},
+		{"GET /healthz", http.HandlerFunc(serveHealth)},
+		{"GET /readyz", healthhandler.NewReadinessHandler(uc.healthReady)},
+		{"GET /xtz/freshness", a.standard(read, healthhandler.NewFreshnessHandler(uc.healthFreshness))},
+		{"GET /xtz/delegations", a.heavy(read, cache(httphandler.NewDelegationHandler(uc.delegationList)))},
+		{"GET /xtz/delegations/export", a.heavy(export, httphandler.NewDelegationExportHandler(uc.delegationExport))},
+		{"GET /xtz/delegations/stream", a.standard(read, httphandler.NewDelegationStreamHandler(uc.delegationStream))},
+		{"GET /xtz/delegations/stats", a.heavy(read, cache(httphandler.NewDelegationStatsHandler(uc.delegationStats)))},
+		{"GET /xtz/delegations/{hash}", a.standard(read, cache(httphandler.NewDelegationGetHandler(uc.delegationGet)))},
+		{"GET /v2/xtz/delegations", a.heavy(read, cache(httphandler.NewDelegationHandlerV2(uc.delegationList)))},
+		{"GET /v2/xtz/delegations/export", a.heavy(export, httphandler.NewDelegationExportHandlerV2(uc.delegationExport))},
+		{"GET /v2/xtz/delegations/stream", a.standard(read, httphandler.NewDelegationStreamHandlerV2(uc.delegationStream))},
+		{"GET /v2/xtz/delegations/{hash}", a.standard(read, cache(httphandler.NewDelegationGetHandlerV2(uc.delegationGet)))},
+		{"GET /xtz/bakers", a.standard(read, cache(bakerhandler.NewBakerListHandler(uc.bakerList)))},
+		{"GET /xtz/bakers/{address}", a.standard(read, cache(bakerhandler.NewBakerGetHandler(uc.bakerGet)))},
+		{"GET /xtz/delegators/{address}", a.standard(read, cache(delegatorhandler.NewDelegatorProfileHandler(uc.delegatorProfile)))},
+		{"POST /xtz/graphql", a.graphql(read, graph.NewHandler(uc.delegationList, uc.delegatorProfile, uc.bakerGet, uc.bakerList))},
+		{"POST /xtz/webhooks", a.standard(webhooks, webhookhandler.NewWebhookCreateHandler(uc.webhookCreate))},
+		{"DELETE /xtz/webhooks/{id}", a.standard(webhooks, webhookhandler.NewWebhookDeleteHandler(uc.webhookDelete))},
+		{"GET /xtz/webhooks/{id}/deliveries", a.standard(webhooks, webhookhandler.NewWebhookDeliveriesHandler(uc.webhookDeliveries))},
+		{"GET /xtz/keys/{id}/usage", a.standard(admin, apikeyhandler.NewAPIKeyUsageHandler(uc.apiKeyUsage))},
+	}
+}
+
+// apiVersion is the latest version of the HTTP API. The unversioned routes are its frozen v1.
+const apiVersion = 2
+
+// newRouter serves the routes, validating their query parameters against the OpenAPI document. The v1 routes having
+// a v2 successor are served by it when the Accept header requests version 2, and are deprecated otherwise.
+// Every request is identified, logged with logger, and recovered from panics.
+func newRouter(
+	uc useCases, deprecation api.Deprecation, cache middleware, a access, logger zerolog.Logger,
+) (http.Handler, error) {
+	spec, err := api.ParseOpenAPI(openAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	for _, route := range routes(uc, cache, a) {
+		mux.Handle(route.pattern, route.handler)
+	}
+
+	versioned := api.Versioned(mux, apiVersion, deprecation, api.ValidateQuery(spec, mux))
+
+	return api.Logged(logger, api.Recovered(versioned)), nil
+}
+
+// parseDeprecation parses the deprecation and sunset dates of the v1 API, as YYYY-MM-DD.
+func parseDeprecation(since, sunset string) (api.Deprecation, error) {
+	var (
+		d   api.Deprecation
+		err error
+	)
+
+	if d.Since, err = time.Parse(time.DateOnly, since); err != nil {
+		return api.Deprecation{}, fmt.Errorf("invalid deprecation date: %w", err)
+	}
+
+	if d.Sunset, err = time.Parse(time.DateOnly, sunset); err != nil {
+		return api.Deprecation{}, fmt.Errorf("invalid sunset date: %w", err)
+	}
+
+	if d.Sunset.Before(d.Since) {
+		return api.Deprecation{}, fmt.Errorf("sunset %s before deprecation %s", sunset, since)
+	}
+
+	return d, nil
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPI)
+}
+
+// serveHealth tells that the process serves requests, whatever the state of its dependencies, checked by /readyz.
+func serveHealth(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	_ = api.JSONResponse(w, http.StatusOK, map[string]string{"status": "ok"})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/pkg/http/api"
+	"kiln-exercice/pkg/tzkt"
+)
+
+// openAPIDocument holds the parts of the OpenAPI document checked against the routes.
+type openAPIDocument struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			Name    string `json:"name"`
+			In      string `json:"in"`
+			Example any    `json:"example"`
+		} `json:"parameters"`
+	} `json:"paths"`
+}
+
+// stubNotifier never notifies.
+type stubNotifier struct{}
+
+func (stubNotifier) Subscribe() (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
+
+// stubHead never reaches the chain.
+type stubHead struct{}
+
+func (stubHead) GetHead(context.Context) (tzkt.Head, error) {
+	return tzkt.Head{}, errors.New("unreachable")
+}
+
+// newTestUseCases returns use cases whose database is unreachable, so that every request passing the validation
+// of the handlers and use cases fails with a 500.
+func newTestUseCases(t *testing.T) useCases {
+	t.Helper()
+
+	db, err := sql.Open("postgres", "host=/nonexistent sslmode=disable connect_timeout=1")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return newUseCases(sqlx.NewDb(db, "postgres"), stubNotifier{}, time.Hour, nil, stubHead{}, freshness.Thresholds{})
+}
+
+func parseOpenAPIDocument(t *testing.T) openAPIDocument {
+	t.Helper()
+
+	// Numbers are kept as written, to be used as query parameters.
+	dec := json.NewDecoder(bytes.NewReader(openAPI))
+	dec.UseNumber()
+
+	var doc openAPIDocument
+	require.NoError(t, dec.Decode(&doc))
+
+	return doc
+}
+
+// TestRoutes_OpenAPIOperations fails when a route is added without documenting it, or the other way around.
+func TestRoutes_OpenAPIOperations(t *testing.T) {
+	t.Parallel()
+
+	var operations []string
+	for path, item := range parseOpenAPIDocument(t).Paths {
+		for method := range item {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	var patterns []string
+	for _, r := range routes(useCases{}, passThrough, open) {
+		patterns = append(patterns, r.pattern)
+	}
+
+	assert.ElementsMatch(t, operations, patterns)
+}
+
+// TestRoutes_OpenAPIQueryParameters fails when the handlers and the OpenAPI document disagree on the query parameters
+// of a route: the handlers must accept the examples of the document, and reject invalid values of every documented
+// parameter, which shows that they read it.
+func TestRoutes_OpenAPIQueryParameters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		doc = parseOpenAPIDocument(t)
+		uc  = newTestUseCases(t)
+		mux = http.NewServeMux()
+	)
+
+	for _, r := range routes(uc, passThrough, open) {
+		mux.Handle(r.pattern, r.handler)
+	}
+
+	router, err := newRouter(uc, api.Deprecation{}, passThrough, open, zerolog.Nop())
+	require.NoError(t, err)
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			var (
+				target = path
+				query  = url.Values{}
+			)
+
+			for _, p := range op.Parameters {
+				switch p.In {
+				case "path":
+					target = strings.ReplaceAll(target, "{"+p.Name+"}", fmt.Sprint(p.Example))
+				case "query":
+					if p.Example == nil {
+						continue
+					}
+
+					if values, ok := p.Example.([]any); ok {
+						for _, v := range values {
+							query.Add(p.Name, fmt.Sprint(v))
+						}
+					} else {
+						query.Set(p.Name, fmt.Sprint(p.Example))
+					}
+				}
+			}
+
+			method = strings.ToUpper(method)
+
+			if method != http.MethodGet {
+				continue // Only GET routes have query parameters.
+			}
+
+			t.Run(
+				method+" "+path, func(t *testing.T) {
+					t.Parallel()
+
+					rec := httptest.NewRecorder()
+					router.ServeHTTP(rec, httptest.NewRequest(method, target+"?"+query.Encode(), nil))
+					assert.NotEqual(t, http.StatusBadRequest, rec.Code, "examples rejected: %s", rec.Body.String())
+
+					for _, p := range op.Parameters {
+						if p.In != "query" {
+							continue
+						}
+
+						invalid := url.Values{p.Name: {"invalid"}}
+
+						rec := httptest.NewRecorder()
+						mux.ServeHTTP(rec, httptest.NewRequest(method, target+"?"+invalid.Encode(), nil))
+						assert.Equal(
+							t, http.StatusBadRequest, rec.Code, "invalid %s accepted by the handler: %s", p.Name, rec.Body.String(),
+						)
+
+						rec = httptest.NewRecorder()
+						router.ServeHTTP(rec, httptest.NewRequest(method, target+"?"+invalid.Encode(), nil))
+						assert.Equal(t, http.StatusBadRequest, rec.Code, "invalid %s accepted by the OpenAPI document", p.Name)
+						assert.Contains(t, rec.Body.String(), p.Name)
+					}
+				},
+			)
+		}
+	}
+}
+
+func TestRoutes_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	limit := rateLimit(api.Limit{Rate: 0.001, Burst: 1}, api.PageCost)
+
+	a := access{authenticate: open.authenticate, limits: rateLimits{standard: limit, heavy: limit, graphql: limit}}
+
+	router, err := newRouter(newTestUseCases(t), api.Deprecation{}, passThrough, a, zerolog.Nop())
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		target   string
+		wantCode int
+	}{
+		{"/xtz/delegations/stats?interval=month", http.StatusInternalServerError},
+		{"/xtz/delegations/stats?interval=month", http.StatusTooManyRequests},
+		{"/xtz/bakers", http.StatusInternalServerError}, // Each route has its own buckets.
+		{"/openapi.json", http.StatusOK},
+		{"/openapi.json", http.StatusOK},
+		{"/healthz", http.StatusOK},
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable}, // The database is unreachable.
+		{"/readyz", http.StatusServiceUnavailable},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		assert.Equal(t, tt.wantCode, rec.Code, "request %d", i)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	env "github.com/ilyakaznacheev/cleanenv"
+	"github.com/rs/zerolog/log"
+
+	"kiln-exercice/internal/model"
+	pgrepo "kiln-exercice/internal/pg"
+	"kiln-exercice/internal/usecase/apikey/create"
+	"kiln-exercice/internal/usecase/apikey/list"
+	"kiln-exercice/internal/usecase/apikey/revoke"
+	"kiln-exercice/internal/usecase/apikey/rotate"
+	"kiln-exercice/internal/usecase/apikey/usage"
+	"kiln-exercice/pkg/pg"
+)
+
+const usageText = `Usage: apikey <command> [flags]
+
+Commands:
+  create -name NAME -scopes SCOPES [-quota N]  create an API key and print its secret
+  rotate -id ID                                replace the secret of an API key and print it
+  revoke -id ID                                invalidate an API key for good
+  list                                         list every API key
+  usage -id ID [-from TIME] [-to TIME]         print the daily requests of an API key
+`
+
+type Parameters struct {
+	DB pg.Parameters
+}
+
+// command runs a subcommand with its arguments.
+type command func(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error
+
+func main() {
+	commands := map[string]command{
+		"create": createKey,
+		"rotate": rotateKey,
+		"revoke": revokeKey,
+		"list":   listKeys,
+		"usage":  keyUsage,
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usageText)
+		os.Exit(2)
+	}
+
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usageText)
+		os.Exit(2)
+	}
+
+	params := Parameters{}
+
+	err := env.ReadEnv(&params)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error parsing environment variables")
+	}
+
+	db, err := pg.New(params.DB)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error connecting to database")
+	}
+	defer db.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err = run(ctx, pgrepo.NewAPIKeyRepository(db), os.Args[2:]); err != nil {
+		log.Fatal().Err(err).Str("command", os.Args[1]).Msg("command failed")
+	}
+}
+
+func createKey(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	name := fs.String("name", "", "name of the client the key is for")
+	scopes := fs.String("scopes", model.ScopeRead, "comma separated scopes: "+strings.Join(model.Scopes, ", "))
+	quota := fs.Int("quota", 0, "requests per UTC day, 0 for no quota")
+	_ = fs.Parse(args)
+
+	out, err := create.NewUseCase(repo).CreateAPIKey(
+		ctx, create.Input{Name: *name, Scopes: strings.Split(*scopes, ","), DailyQuota: *quota},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("id: %d\nname: %s\nscopes: %s\nsecret: %s\n", out.ID, out.Name, strings.Join(out.Scopes, ","), out.Secret)
+	fmt.Fprintln(os.Stderr, "The secret is not stored and cannot be shown again.")
+
+	return nil
+}
+
+func rotateKey(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+	id := fs.Int("id", 0, "id of the API key")
+	_ = fs.Parse(args)
+
+	out, err := rotate.NewUseCase(repo).RotateAPIKey(ctx, rotate.Input{ID: *id})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("id: %d\nname: %s\nsecret: %s\n", out.ID, out.Name, out.Secret)
+	fmt.Fprintln(os.Stderr, "The previous secret is no longer valid, and the new one cannot be shown again.")
+
+	return nil
+}
+
+func revokeKey(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error {
+	fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := fs.Int("id", 0, "id of the API key")
+	_ = fs.Parse(args)
+
+	return revoke.NewUseCase(repo).RevokeAPIKey(ctx, revoke.Input{ID: *id})
+}
+
+func listKeys(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	out, err := list.NewUseCase(repo).ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tQUOTA\tCREATED\tREVOKED")
+
+	for _, k := range out.Keys {
+		revoked := "-"
+		if k.RevokedAt != nil {
+			revoked = k.RevokedAt.UTC().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(
+			w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			k.ID, k.Name, k.Prefix, strings.Join(k.Scopes, ","), k.DailyQuota, k.CreatedAt.UTC().Format(time.RFC3339), revoked,
+		)
+	}
+
+	return w.Flush()
+}
+
+func keyUsage(ctx context.Context, repo *pgrepo.APIKeyRepository, args []string) error {
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	id := fs.Int("id", 0, "id of the API key")
+	from := fs.String("from", "", "first UTC day, as an RFC 3339 time, 29 days before -to by default")
+	to := fs.String("to", "", "last UTC day, as an RFC 3339 time, today by default")
+	_ = fs.Parse(args)
+
+	input := usage.Input{ID: *id}
+
+	var err error
+
+	if input.From, err = parseTime(*from); err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+
+	if input.To, err = parseTime(*to); err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	out, err := usage.NewUseCase(repo, time.Now).GetUsage(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(out)
+}
+
+// parseTime parses an RFC 3339 time, an empty string being the zero time.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	env "github.com/ilyakaznacheev/cleanenv"
+	"github.com/rs/zerolog/log"
+
+	pgrepo "kiln-exercice/internal/pg"
+	"kiln-exercice/internal/usecase/webhook/dispatch"
+	"kiln-exercice/pkg/pg"
+	"kiln-exercice/pkg/scheduler"
+	"kiln-exercice/pkg/webhook"
+)
+
+type Parameters struct {
+	DB pg.Parameters
+
+	DispatchIntervalSeconds   int `env:"WEBHOOK_DISPATCH_INTERVAL_SECONDS" env-default:"5"`
+	BatchSize                 int `env:"WEBHOOK_BATCH_SIZE" env-default:"100"`
+	Concurrency               int `env:"WEBHOOK_CONCURRENCY" env-default:"10"`
+	TimeoutSeconds            int `env:"WEBHOOK_TIMEOUT_SECONDS" env-default:"10"`
+	MaxAttempts               int `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"10"`
+	RetryInitialDelaySeconds  int `env:"WEBHOOK_RETRY_INITIAL_DELAY_SECONDS" env-default:"30"`
+	RetryMaxDelaySeconds      int `env:"WEBHOOK_RETRY_MAX_DELAY_SECONDS" env-default:"21600"`
+	DispatchMaxBackoffSeconds int `env:"WEBHOOK_DISPATCH_MAX_BACKOFF_SECONDS" env-default:"300"`
+}
+
+func main() {
+	params := Parameters{}
+
+	err := env.ReadEnv(&params)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error parsing environment variables")
+	}
+
+	db, err := pg.New(params.DB)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error connecting to database")
+	}
+	defer db.Close()
+
+	timeout := time.Duration(params.TimeoutSeconds) * time.Second
+
+	dispatchUseCase := dispatch.NewUseCase(
+		pgrepo.NewWebhookRepository(db),
+		webhook.NewSender(timeout, time.Now),
+		dispatch.Config{
+			BatchSize:   params.BatchSize,
+			Concurrency: params.Concurrency,
+			MaxAttempts: params.MaxAttempts,
+			Backoff: scheduler.Backoff{
+				Initial: time.Duration(params.RetryInitialDelaySeconds) * time.Second,
+				Max:     time.Duration(params.RetryMaxDelaySeconds) * time.Second,
+			},
+			// A batch is sent in waves of Concurrency deliveries, each wave lasting up to the timeout.
+			Lease: time.Duration(params.BatchSize/max(params.Concurrency, 1)+1) * timeout,
+		},
+		time.Now,
+	)
+
+	ctx, done := signal.NotifyContext(
+		context.Background(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL,
+	)
+	defer done()
+
+	interval := time.Duration(params.DispatchIntervalSeconds) * time.Second
+
+	s := scheduler.New(
+		scheduler.Every(interval),
+		func(ctx context.Context) error {
+			err := dispatchUseCase.DispatchDeliveries(ctx)
+			if err == nil {
+				return nil
+			}
+
+			// A failed dispatch is retried on the next activation, unless the database itself is gone.
+			if pingErr := db.PingContext(ctx); pingErr != nil {
+				return scheduler.Fatal(errors.Join(err, pingErr))
+			}
+
+			return err
+		},
+		scheduler.Backoff{
+			Initial: interval,
+			Max:     time.Duration(params.DispatchMaxBackoffSeconds) * time.Second,
+		},
+	)
+
+	log.Info().Msg("webhook dispatcher started")
+
+	if err = s.Run(ctx); err != nil {
+		log.Fatal().Err(err).Msg("error dispatching webhooks")
+	}
+
+	log.Info().Msg("webhook dispatcher stopped")
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	env "github.com/ilyakaznacheev/cleanenv"
+	"github.com/rs/zerolog/log"
+
+	pgrepo "kiln-exercice/internal/pg"
+	"kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+	httpapi "kiln-exercice/pkg/http/api"
+	"kiln-exercice/pkg/pg"
+)
+
+var (
+	output     = flag.String("out", "-", "output file, - for stdout")
+	format     = flag.String("format", "csv", "output format: csv or ndjson")
+	year       = flag.Int("year", 0, "only export delegations of this year")
+	from       = flag.String("from", "", "only export delegations from this RFC 3339 time, inclusive")
+	to         = flag.String("to", "", "only export delegations up to this RFC 3339 time, exclusive")
+	minLevel   = flag.Int("min-level", 0, "only export delegations from this level, inclusive")
+	maxLevel   = flag.Int("max-level", 0, "only export delegations up to this level, inclusive")
+	delegators = flag.String("delegators", "", "only export delegations of these comma separated delegators")
+	sortField  = flag.String("sort", list.SortFields[0], "sort field: "+strings.Join(list.SortFields, ", "))
+	sortOrder  = flag.String("order", api.OrderAsc, "sort order: asc or desc")
+	fetchSize  = flag.Int("fetch-size", pg.DefaultCursorFetchSize, "number of rows fetched from the database at once")
+)
+
+type Parameters struct {
+	DB pg.Parameters
+}
+
+func main() {
+	params := Parameters{}
+
+	flag.Parse()
+
+	err := env.ReadEnv(&params)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error parsing environment variables")
+	}
+
+	input, err := inputFromFlags()
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid flags")
+	}
+
+	encoders := map[string]httpapi.StreamEncoder{"csv": httpapi.CSVEncoder{}, "ndjson": httpapi.NDJSONEncoder{}}
+
+	encoder, ok := encoders[*format]
+	if !ok {
+		log.Fatal().Msgf("unknown format %q, expected csv or ndjson", *format)
+	}
+
+	db, err := pg.New(params.DB)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error connecting to database")
+	}
+	defer db.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	start := time.Now()
+
+	count, err := export(ctx, list.NewExportUseCase(pgrepo.NewDelegationRepository(db, *fetchSize)), encoder, input)
+	if err != nil {
+		log.Fatal().Err(err).Int("records", count).Msg("export failed")
+	}
+
+	log.Info().Int("records", count).Dur("duration", time.Since(start)).Msg("Export done")
+}
+
+// export writes every delegation matching the input to the output file, returning the number of records written.
+func export(ctx context.Context, uc *list.ExportUseCase, encoder httpapi.StreamEncoder, input list.Input) (int, error) {
+	var out io.WriteCloser = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return 0, fmt.Errorf("create output file: %w", err)
+		}
+
+		out = f
+	}
+	defer out.Close()
+
+	buf := bufio.NewWriter(out)
+
+	records, err := encoder.NewRecordWriter(buf, reflect.TypeFor[list.DelegationData]())
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+
+	err = uc.ExportDelegations(
+		ctx, input, func(d list.DelegationData) error {
+			count++
+			return records.Write(d)
+		},
+	)
+	if err != nil {
+		return count, err
+	}
+
+	if err = records.Flush(); err != nil {
+		return count, err
+	}
+
+	return count, buf.Flush()
+}
+
+func inputFromFlags() (list.Input, error) {
+	input := list.Input{
+		Year:     *year,
+		MinLevel: *minLevel,
+		MaxLevel: *maxLevel,
+		Sort:     api.Sort{Field: *sortField, Order: *sortOrder},
+	}
+
+	if !slices.Contains(list.SortFields, input.Sort.Field) {
+		return list.Input{}, fmt.Errorf("sort must be one of %s", strings.Join(list.SortFields, ", "))
+	}
+
+	if input.Sort.Order != api.OrderAsc && input.Sort.Order != api.OrderDesc {
+		return list.Input{}, fmt.Errorf("order must be one of %s, %s", api.OrderAsc, api.OrderDesc)
+	}
+
+	var err error
+
+	if *from != "" {
+		if input.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			return list.Input{}, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	if *to != "" {
+		if input.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return list.Input{}, fmt.Errorf("to: %w", err)
+		}
+	}
+
+	for _, delegator := range strings.Split(*delegators, ",") {
+		if delegator = strings.TrimSpace(delegator); delegator != "" {
+			input.Delegators = append(input.Delegators, delegator)
+		}
+	}
+
+	return input, nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,17 +15,27 @@ import (
 
 	pgrepo "kiln-exercice/internal/pg"
 	delegationpoll "kiln-exercice/internal/usecase/delegation/poll"
+	"kiln-exercice/pkg/breaker"
 	"kiln-exercice/pkg/pg"
+	"kiln-exercice/pkg/scheduler"
 	"kiln-exercice/pkg/tzkt"
 )
 
 type Parameters struct {
 	DB pg.Parameters
 
-	TzktURL                string    `env:"TZKT_URL" env-default:"https://api.tzkt.io"`
-	PollingIntervalSeconds int       `env:"POLLING_INTERVAL_SECONDS" env-default:"10"`
-	DefaultPollingFrom     time.Time `env:"DEFAULT_POLLING_FROM" env-layout:"2006-01-02" env-default:"2018-01-01"`
-	PollingBatchSize       int       `env:"POLLING_BATCH_SIZE" env-default:"10000"`
+	TzktURL                  string    `env:"TZKT_URL" env-default:"https://api.tzkt.io"`
+	PollingIntervalSeconds   int       `env:"POLLING_INTERVAL_SECONDS" env-default:"10"`
+	PollingCron              string    `env:"POLLING_CRON"` // Takes precedence over the interval when set.
+	PollingJitterSeconds     int       `env:"POLLING_JITTER_SECONDS" env-default:"0"`
+	PollingMaxBackoffSeconds int       `env:"POLLING_MAX_BACKOFF_SECONDS" env-default:"300"`
+	DefaultPollingFrom       time.Time `env:"DEFAULT_POLLING_FROM" env-layout:"2006-01-02" env-default:"2018-01-01"`
+	PollingBatchSize         int       `env:"POLLING_BATCH_SIZE" env-default:"10000"`
+	BreakerFailureThreshold  int       `env:"BREAKER_FAILURE_THRESHOLD" env-default:"5"`
+	BreakerOpenSeconds       int       `env:"BREAKER_OPEN_SECONDS" env-default:"60"`
+	StatusPort               string    `env:"STATUS_PORT" env-default:"8081"`
+	PollingMaxRunSeconds     int       `env:"POLLING_MAX_RUN_SECONDS" env-default:"1800"` // A longer poll is wedged.
+	PollingMaxLagSeconds     int       `env:"POLLING_MAX_LAG_SECONDS" env-default:"600"`  // 0 disables the check.
 }
 
 func main() {
@@ -42,7 +54,16 @@ func main() {
 		log.Fatal().Err(err).Msg("error connecting to database")
 	}
 
-	tzktSDK, err := tzkt.NewSDK(params.TzktURL)
+	tzktSDK, err := tzkt.NewSDK(
+		params.TzktURL,
+		tzkt.WithCircuitBreaker(
+			breaker.New(
+				params.BreakerFailureThreshold,
+				time.Duration(params.BreakerOpenSeconds)*time.Second,
+				time.Now,
+			),
+		),
+	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error creating tzkt sdk")
 	}
@@ -58,35 +79,84 @@ func main() {
 		time.Now,
 	)
 
+	schedule, err := newSchedule(params)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating polling schedule")
+	}
+
 	ctx, done := signal.NotifyContext(
 		context.Background(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL,
 	)
 	defer done()
 
+	s := scheduler.New(
+		schedule,
+		func(ctx context.Context) error {
+			err := delegationUseCase.PollDelegations(ctx)
+			if err == nil {
+				return nil
+			}
+
+			// A failed poll is retried on the next activation, unless the database itself is gone.
+			if pingErr := db.PingContext(ctx); pingErr != nil {
+				return scheduler.Fatal(errors.Join(err, pingErr))
+			}
+
+			return err
+		},
+		scheduler.Backoff{
+			Initial: time.Duration(params.PollingIntervalSeconds) * time.Second,
+			Max:     time.Duration(params.PollingMaxBackoffSeconds) * time.Second,
+		},
+	)
+
+	checks := statusChecks{
+		scheduler: s,
+		ping:      db.PingContext,
+		maxRun:    time.Duration(params.PollingMaxRunSeconds) * time.Second,
+		maxLag:    time.Duration(params.PollingMaxLagSeconds) * time.Second,
+		timeNow:   time.Now,
+	}
+
+	// The status server stops with the scheduler, and the other way around.
 	g, ctx := errgroup.WithContext(ctx)
 
-	log.Info().Msg("delegations polling started")
+	g.Go(
+		func() error {
+			return serveStatus(ctx, ":"+params.StatusPort, checks.handler())
+		},
+	)
 
 	g.Go(
 		func() error {
-			ticker := time.NewTicker(time.Duration(params.PollingIntervalSeconds) * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return nil
-				case <-ticker.C:
-					if err = delegationUseCase.PollDelegations(ctx); err != nil {
-						return err
-					}
-				}
+			if err := s.Run(ctx); err != nil {
+				return fmt.Errorf("poll delegations: %w", err)
 			}
+
+			return nil
 		},
 	)
 
+	log.Info().Msgf("delegations polling started, status on port %s", params.StatusPort)
+
 	if err = g.Wait(); err != nil {
 		log.Fatal().Err(err).Msg("error polling delegations")
 	}
 
 	log.Info().Msg("delegations polling stopped")
 }
+
+func newSchedule(params Parameters) (scheduler.Schedule, error) {
+	jitter := time.Duration(params.PollingJitterSeconds) * time.Second
+
+	if params.PollingCron != "" {
+		schedule, err := scheduler.Cron(params.PollingCron)
+		if err != nil {
+			return nil, err
+		}
+
+		return scheduler.WithJitter(schedule, jitter), nil
+	}
+
+	return scheduler.WithJitter(scheduler.Every(time.Duration(params.PollingIntervalSeconds)*time.Second), jitter), nil
+}
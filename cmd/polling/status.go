@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"kiln-exercice/pkg/http/api"
+	"kiln-exercice/pkg/scheduler"
+)
+
+const (
+	// statusReadHeaderTimeout bounds the wait for the headers of a status request.
+	statusReadHeaderTimeout = 5 * time.Second
+	// statusShutdownTimeout bounds the wait for the status requests in progress when the poller stops.
+	statusShutdownTimeout = 5 * time.Second
+)
+
+// statusOutput is the body of the status routes.
+type statusOutput struct {
+	OK        bool             `json:"ok"`
+	Problems  []string         `json:"problems,omitempty"`
+	Scheduler scheduler.Status `json:"scheduler"`
+}
+
+// statusChecks decide whether the poller is alive and ready from the status of its scheduler.
+type statusChecks struct {
+	scheduler *scheduler.Scheduler
+	ping      func(ctx context.Context) error
+	maxRun    time.Duration // Beyond which a poll, running or overdue, is wedged.
+	maxLag    time.Duration // Beyond which the last successful poll is too old, 0 to disable the check.
+	timeNow   func() time.Time
+}
+
+// liveness returns why the poller is wedged: a poll running, or overdue, for more than maxRun.
+// Failing polls are retried with a backoff, so they do not make the poller wedged.
+func liveness(st scheduler.Status, now time.Time, maxRun time.Duration) []string {
+	switch {
+	case st.Running && now.Sub(st.LastStartedAt) > maxRun:
+		return []string{fmt.Sprintf("poll running for %s, at most %s", now.Sub(st.LastStartedAt).Round(time.Second), maxRun)}
+	case !st.Running && !st.NextRunAt.IsZero() && now.Sub(st.NextRunAt) > maxRun:
+		return []string{fmt.Sprintf("poll overdue by %s, at most %s", now.Sub(st.NextRunAt).Round(time.Second), maxRun)}
+	default:
+		return nil
+	}
+}
+
+// readiness returns why the poller is not ready: it is wedged, or no poll succeeded within maxLag.
+func readiness(st scheduler.Status, now time.Time, maxRun, maxLag time.Duration) []string {
+	problems := liveness(st, now, maxRun)
+
+	if maxLag > 0 {
+		switch lag := now.Sub(st.LastSucceededAt); {
+		case st.LastSucceededAt.IsZero():
+			problems = append(problems, "no successful poll yet")
+		case lag > maxLag:
+			problems = append(problems, fmt.Sprintf("last successful poll %s ago, at most %s", lag.Round(time.Second), maxLag))
+		}
+	}
+
+	return problems
+}
+
+// handler serves /healthz, failing when the poller is wedged, and /readyz, failing as well when the database is
+// unreachable or the data is stale.
+func (c statusChecks) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle(
+		"GET /healthz", api.Handle(
+			func(w http.ResponseWriter, _ *http.Request) error {
+				st := c.scheduler.Status()
+				return writeStatus(w, st, liveness(st, c.timeNow(), c.maxRun))
+			},
+		),
+	)
+
+	mux.Handle(
+		"GET /readyz", api.Handle(
+			func(w http.ResponseWriter, r *http.Request) error {
+				st := c.scheduler.Status()
+				problems := readiness(st, c.timeNow(), c.maxRun, c.maxLag)
+
+				if err := c.ping(r.Context()); err != nil {
+					log.Ctx(r.Context()).Warn().Err(err).Msg("Database unreachable")
+					problems = append(problems, "database unreachable")
+				}
+
+				return writeStatus(w, st, problems)
+			},
+		),
+	)
+
+	return mux
+}
+
+// writeStatus answers a 200 without problem, and a 503 listing them otherwise.
+func writeStatus(w http.ResponseWriter, st scheduler.Status, problems []string) error {
+	status := http.StatusOK
+	if len(problems) > 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	return api.JSONResponse(w, status, statusOutput{OK: len(problems) == 0, Problems: problems, Scheduler: st})
+}
+
+// serveStatus serves the status routes on addr until the context is done.
+func serveStatus(ctx context.Context, addr string, handler http.Handler) error {
+	server := http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: statusReadHeaderTimeout,
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), statusShutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
+}
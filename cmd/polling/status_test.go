@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"kiln-exercice/pkg/http/apitest"
+	"kiln-exercice/pkg/scheduler"
+)
+
+func TestReadiness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		status        scheduler.Status
+		wantLiveness  []string
+		wantReadiness []string
+	}{
+		{
+			name:   "waiting for the next poll",
+			status: scheduler.Status{LastSucceededAt: now.Add(-time.Minute), NextRunAt: now.Add(time.Second)},
+		},
+		{
+			name: "failing polls",
+			status: scheduler.Status{
+				LastSucceededAt: now.Add(-time.Hour), LastError: "tzkt unavailable", ConsecutiveFailures: 6,
+				NextRunAt: now.Add(time.Minute),
+			},
+			wantReadiness: []string{"last successful poll 1h0m0s ago, at most 10m0s"},
+		},
+		{
+			name:          "first poll running",
+			status:        scheduler.Status{Running: true, LastStartedAt: now.Add(-5 * time.Minute)},
+			wantReadiness: []string{"no successful poll yet"},
+		},
+		{
+			name:          "wedged poll",
+			status:        scheduler.Status{Running: true, LastStartedAt: now.Add(-31 * time.Minute), LastSucceededAt: now.Add(-32 * time.Minute)},
+			wantLiveness:  []string{"poll running for 31m0s, at most 30m0s"},
+			wantReadiness: []string{"poll running for 31m0s, at most 30m0s", "last successful poll 32m0s ago, at most 10m0s"},
+		},
+		{
+			name:          "overdue poll",
+			status:        scheduler.Status{LastSucceededAt: now.Add(-5 * time.Minute), NextRunAt: now.Add(-time.Hour)},
+			wantLiveness:  []string{"poll overdue by 1h0m0s, at most 30m0s"},
+			wantReadiness: []string{"poll overdue by 1h0m0s, at most 30m0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				assert.Equal(t, tt.wantLiveness, liveness(tt.status, now, 30*time.Minute))
+				assert.Equal(t, tt.wantReadiness, readiness(tt.status, now, 30*time.Minute, 10*time.Minute))
+			},
+		)
+	}
+}
+
+func TestStatusChecks_handler(t *testing.T) {
+	t.Parallel()
+
+	// The scheduler has not run yet.
+	checks := statusChecks{
+		scheduler: scheduler.New(scheduler.Every(time.Minute), func(context.Context) error { return nil }, scheduler.Backoff{}),
+		ping:      func(context.Context) error { return errors.New("connection refused") },
+		maxRun:    30 * time.Minute,
+		maxLag:    10 * time.Minute,
+		timeNow:   time.Now,
+	}
+
+	const idle = `"scheduler": {
+		"running": false, "last_started_at": "0001-01-01T00:00:00Z", "last_succeeded_at": "0001-01-01T00:00:00Z",
+		"consecutive_failures": 0, "next_run_at": "0001-01-01T00:00:00Z"}`
+
+	apitest.TestHandler(
+		t, httptest.NewRequest("GET", "/healthz", nil), http.StatusOK, `{"data": {"ok": true, `+idle+`}}`, checks.handler(),
+	)
+	apitest.TestHandler(
+		t, httptest.NewRequest("GET", "/readyz", nil), http.StatusServiceUnavailable,
+		`{"data": {"ok": false, "problems": ["no successful poll yet", "database unreachable"], `+idle+`}}`,
+		checks.handler(),
+	)
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+)
+
+type BakerConnectionResolver struct {
+	root *Resolver
+	out  bakerlist.Output
+}
+
+func (r *BakerConnectionResolver) Nodes() []*BakerResolver {
+	nodes := make([]*BakerResolver, len(r.out.Bakers))
+	for i, b := range r.out.Bakers {
+		nodes[i] = &BakerResolver{
+			root: r.root,
+			summary: bakerget.Summary{
+				Address:         b.Address,
+				DelegatorCount:  b.DelegatorCount,
+				DelegatedAmount: b.DelegatedAmount,
+			},
+		}
+	}
+
+	return nodes
+}
+
+func (r *BakerConnectionResolver) PageInfo() PageInfoResolver {
+	return PageInfoResolver{endCursor: r.out.NextCursor}
+}
+
+func (r *BakerConnectionResolver) TotalCount() int32 {
+	return int32(r.out.TotalCount)
+}
+
+type BakerResolver struct {
+	root    *Resolver
+	summary bakerget.Summary
+}
+
+func (r *BakerResolver) Address() string {
+	return r.summary.Address
+}
+
+func (r *BakerResolver) DelegatorCount() int32 {
+	return int32(r.summary.DelegatorCount)
+}
+
+func (r *BakerResolver) DelegatedAmount() string {
+	return r.summary.DelegatedAmount.String()
+}
+
+type FlowsArgs struct {
+	From *graphql.Time
+	To   *graphql.Time
+}
+
+// Flows is not batched: flows are only worth requesting for a handful of bakers.
+func (r *BakerResolver) Flows(ctx context.Context, args FlowsArgs) (*BakerFlowsResolver, error) {
+	input := bakerget.Input{Address: r.summary.Address}
+
+	if args.From != nil {
+		input.From = args.From.Time
+	}
+
+	if args.To != nil {
+		input.To = args.To.Time
+	}
+
+	out, err := r.root.bakerGetUseCase.GetBaker(ctx, input)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &BakerFlowsResolver{out: out}, nil
+}
+
+type BakerFlowsResolver struct {
+	out bakerget.Output
+}
+
+func (r *BakerFlowsResolver) Inflows() FlowResolver {
+	return FlowResolver{data: r.out.Inflows}
+}
+
+func (r *BakerFlowsResolver) Outflows() FlowResolver {
+	return FlowResolver{data: r.out.Outflows}
+}
+
+func (r *BakerFlowsResolver) NetChange() FlowResolver {
+	return FlowResolver{data: r.out.NetChange}
+}
+
+type FlowResolver struct {
+	data bakerget.FlowData
+}
+
+func (r FlowResolver) Count() int32 {
+	return int32(r.data.Count)
+}
+
+func (r FlowResolver) Amount() string {
+	return r.data.Amount.String()
+}
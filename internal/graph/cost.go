@@ -0,0 +1,618 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/api"
+	httpapi "kiln-exercice/pkg/http/api"
+)
+
+// maxCost bounds the cost of a query, in rows read: as many as the largest page of the delegation list.
+const maxCost = api.MaxPageSize
+
+// errUnexpectedEnd is returned for queries ending in the middle of a definition.
+var errUnexpectedEnd = errors.New("unexpected end of query")
+
+// QueryCost takes a token per page of the default size read by a GraphQL request, as PageCost does for the other
+// routes. Invalid requests take a single token, being rejected before reading anything. The body is left unread.
+func QueryCost(r *http.Request) int {
+	body, err := io.ReadAll(io.LimitReader(r.Body, httpapi.MaxBodySize+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+	if err != nil {
+		return 1
+	}
+
+	var req request
+	if err = json.Unmarshal(body, &req); err != nil {
+		return 1
+	}
+
+	rows, err := queryCost(req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		return 1
+	}
+
+	return api.PageCost(rows)
+}
+
+// queryCost estimates the number of rows read by the operation of a query, before it is validated: every field
+// loading rows costs as many rows as it may load, multiplied by the nodes of the lists it is nested in, aliases and
+// fragments counting as many times as they are selected. Lists are assumed full, and totalCount costs a row.
+func queryCost(query, operationName string, variables map[string]any) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, err
+	}
+
+	c := coster{fragments: doc.fragments, variables: variables, visiting: make(map[string]bool)}
+
+	var cost float64
+
+	for _, op := range doc.operations {
+		if operationName == "" || op.name == operationName {
+			cost = max(cost, c.selections(op.selections, 1, 0))
+		}
+	}
+
+	return int(min(cost, math.MaxInt32)), nil
+}
+
+// coster sums the cost of the fields of a document, in floats so that nested lists cannot overflow.
+type coster struct {
+	fragments map[string][]selection
+	variables map[string]any
+	visiting  map[string]bool // Fragments being expanded, which cannot spread themselves.
+}
+
+// selections returns the cost of selections made mult times, within a connection of size nodes.
+func (c coster) selections(sels []selection, mult, size float64) float64 {
+	var cost float64
+
+	for _, s := range sels {
+		switch {
+		case s.spread != "":
+			if c.visiting[s.spread] {
+				continue
+			}
+
+			c.visiting[s.spread] = true
+			cost += c.selections(c.fragments[s.spread], mult, size)
+			delete(c.visiting, s.spread)
+		case s.field == "":
+			cost += c.selections(s.selections, mult, size)
+		default:
+			cost += c.field(s, mult, size)
+		}
+	}
+
+	return cost
+}
+
+// field returns the cost of a field selected mult times, within a connection of size nodes.
+func (c coster) field(f selection, mult, size float64) float64 {
+	switch f.field {
+	case "delegations", "bakers":
+		first := float64(c.first(f.arguments["first"]))
+		return mult*first + c.selections(f.selections, mult, first)
+	case "nodes":
+		return c.selections(f.selections, mult*size, 0)
+	case "totalCount":
+		return mult
+	case "history":
+		return mult*profile.HistorySize + c.selections(f.selections, mult*profile.HistorySize, 0)
+	case "delegator", "baker", "currentBaker", "flows":
+		return mult + c.selections(f.selections, mult, 0)
+	default:
+		return c.selections(f.selections, mult, size)
+	}
+}
+
+// first returns the size of a connection, the largest one when it is not a literal or a variable within bounds.
+func (c coster) first(v value) int {
+	if v.variable != "" {
+		if n, ok := c.variables[v.variable].(float64); ok && n >= 0 && n <= maxPageSize {
+			return int(n)
+		}
+
+		return maxPageSize
+	}
+
+	if n, err := strconv.Atoi(v.literal); err == nil && n >= 0 && n <= maxPageSize {
+		return n
+	}
+
+	return maxPageSize
+}
+
+// document is the part of a GraphQL document costing rows.
+type document struct {
+	operations []operation
+	fragments  map[string][]selection
+}
+
+type operation struct {
+	name       string
+	selections []selection
+}
+
+// selection is a field, a fragment spread or an inline fragment.
+type selection struct {
+	field      string // Empty for fragments.
+	arguments  map[string]value
+	spread     string // Name of the spread fragment.
+	selections []selection
+}
+
+// value is an argument value, of which only the literals and variables are kept.
+type value struct {
+	literal  string
+	variable string
+}
+
+// parseDocument parses the definitions of a GraphQL document, leaving their validation to the schema.
+func parseDocument(query string) (document, error) {
+	p := parser{lex: lexer{src: query}}
+	doc := document{fragments: make(map[string][]selection)}
+
+	if err := p.next(); err != nil {
+		return document{}, err
+	}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.tok.is(tokenPunct, "{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return document{}, err
+			}
+
+			doc.operations = append(doc.operations, operation{selections: sels})
+		case p.tok.is(tokenName, "query"), p.tok.is(tokenName, "mutation"), p.tok.is(tokenName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return document{}, err
+			}
+
+			doc.operations = append(doc.operations, op)
+		case p.tok.is(tokenName, "fragment"):
+			name, sels, err := p.fragment()
+			if err != nil {
+				return document{}, err
+			}
+
+			doc.fragments[name] = sels
+		default:
+			return document{}, p.unexpected()
+		}
+	}
+
+	return doc, nil
+}
+
+// parser parses GraphQL documents a token ahead.
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex.next()
+	p.tok = tok
+
+	return err
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errUnexpectedEnd
+	}
+
+	return fmt.Errorf("unexpected %q", p.tok.text)
+}
+
+// expect consumes a punctuator.
+func (p *parser) expect(punct string) error {
+	if !p.tok.is(tokenPunct, punct) {
+		return p.unexpected()
+	}
+
+	return p.next()
+}
+
+// name consumes a name.
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.tok.text
+
+	return name, p.next()
+}
+
+// operation parses an operation definition, its variable definitions aside.
+func (p *parser) operation() (operation, error) {
+	var op operation
+
+	if err := p.next(); err != nil { // The operation type.
+		return operation{}, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.text
+
+		if err := p.next(); err != nil {
+			return operation{}, err
+		}
+	}
+
+	if p.tok.is(tokenPunct, "(") {
+		if err := p.skipBalanced("(", ")"); err != nil {
+			return operation{}, err
+		}
+	}
+
+	if err := p.directives(); err != nil {
+		return operation{}, err
+	}
+
+	sels, err := p.selectionSet()
+	op.selections = sels
+
+	return op, err
+}
+
+// fragment parses a fragment definition.
+func (p *parser) fragment() (string, []selection, error) {
+	if err := p.next(); err != nil { // fragment
+		return "", nil, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err = p.name(); err != nil { // on
+		return "", nil, err
+	}
+
+	if _, err = p.name(); err != nil { // The type condition.
+		return "", nil, err
+	}
+
+	if err = p.directives(); err != nil {
+		return "", nil, err
+	}
+
+	sels, err := p.selectionSet()
+
+	return name, sels, err
+}
+
+// selectionSet parses a selection set, braces included.
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var sels []selection
+
+	for !p.tok.is(tokenPunct, "}") {
+		s, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+
+		sels = append(sels, s)
+	}
+
+	return sels, p.next()
+}
+
+// selection parses a field, a fragment spread or an inline fragment.
+func (p *parser) selection() (selection, error) {
+	if p.tok.is(tokenPunct, "...") {
+		return p.fragmentSelection()
+	}
+
+	var (
+		s   selection
+		err error
+	)
+
+	if s.field, err = p.name(); err != nil {
+		return selection{}, err
+	}
+
+	if p.tok.is(tokenPunct, ":") { // The field was an alias.
+		if err = p.next(); err != nil {
+			return selection{}, err
+		}
+
+		if s.field, err = p.name(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if p.tok.is(tokenPunct, "(") {
+		if s.arguments, err = p.arguments(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if err = p.directives(); err != nil {
+		return selection{}, err
+	}
+
+	if p.tok.is(tokenPunct, "{") {
+		s.selections, err = p.selectionSet()
+	}
+
+	return s, err
+}
+
+// fragmentSelection parses a fragment spread or an inline fragment, from its ellipsis.
+func (p *parser) fragmentSelection() (selection, error) {
+	if err := p.next(); err != nil {
+		return selection{}, err
+	}
+
+	var s selection
+
+	switch {
+	case p.tok.is(tokenName, "on"):
+		if err := p.next(); err != nil {
+			return selection{}, err
+		}
+
+		if _, err := p.name(); err != nil { // The type condition.
+			return selection{}, err
+		}
+	case p.tok.kind == tokenName:
+		s.spread = p.tok.text
+
+		if err := p.next(); err != nil {
+			return selection{}, err
+		}
+
+		return s, p.directives()
+	}
+
+	if err := p.directives(); err != nil {
+		return selection{}, err
+	}
+
+	sels, err := p.selectionSet()
+	s.selections = sels
+
+	return s, err
+}
+
+// arguments parses arguments, parentheses included.
+func (p *parser) arguments() (map[string]value, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]value)
+
+	for !p.tok.is(tokenPunct, ")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		if args[name], err = p.value(); err != nil {
+			return nil, err
+		}
+	}
+
+	return args, p.next()
+}
+
+// value parses a value, keeping literals and variables only.
+func (p *parser) value() (value, error) {
+	switch {
+	case p.tok.is(tokenPunct, "$"):
+		if err := p.next(); err != nil {
+			return value{}, err
+		}
+
+		name, err := p.name()
+
+		return value{variable: name}, err
+	case p.tok.is(tokenPunct, "["):
+		return value{}, p.skipBalanced("[", "]")
+	case p.tok.is(tokenPunct, "{"):
+		return value{}, p.skipBalanced("{", "}")
+	case p.tok.kind == tokenName, p.tok.kind == tokenNumber, p.tok.kind == tokenString:
+		literal := p.tok.text
+
+		return value{literal: literal}, p.next()
+	default:
+		return value{}, p.unexpected()
+	}
+}
+
+// directives skips directives, along with their arguments.
+func (p *parser) directives() error {
+	for p.tok.is(tokenPunct, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if _, err := p.name(); err != nil {
+			return err
+		}
+
+		if p.tok.is(tokenPunct, "(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// skipBalanced skips tokens from an opening punctuator to its closing one.
+func (p *parser) skipBalanced(open, closing string) error {
+	depth := 0
+
+	for {
+		switch {
+		case p.tok.kind == tokenEOF:
+			return errUnexpectedEnd
+		case p.tok.is(tokenPunct, open):
+			depth++
+		case p.tok.is(tokenPunct, closing):
+			depth--
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenNumber
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+// lexer splits a GraphQL document into tokens, skipping whitespaces, commas and comments.
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunct, text: "..."}, nil
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, text: string(c)}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+
+		return token{kind: tokenName, text: l.src[start:l.pos]}, nil
+	case c == '-' || isDigit(c):
+		l.pos++
+
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || strings.IndexByte(".eE+-", l.src[l.pos]) >= 0) {
+			l.pos++
+		}
+
+		return token{kind: tokenNumber, text: l.src[start:l.pos]}, nil
+	case c == '"':
+		return l.string()
+	default:
+		return token{}, fmt.Errorf("unexpected character %q", c)
+	}
+}
+
+// string lexes a string or a block string, whose value is never needed.
+func (l *lexer) string() (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		for i := l.pos + 3; i+3 <= len(l.src); i++ {
+			switch {
+			case strings.HasPrefix(l.src[i:], `\"""`):
+				i += 3
+			case strings.HasPrefix(l.src[i:], `"""`):
+				l.pos = i + 3
+				return token{kind: tokenString}, nil
+			}
+		}
+
+		return token{}, errUnexpectedEnd
+	}
+
+	for i := l.pos + 1; i < len(l.src); i++ {
+		switch l.src[i] {
+		case '\\':
+			i++
+		case '\n':
+			return token{}, errors.New("unterminated string")
+		case '"':
+			l.pos = i + 1
+			return token{kind: tokenString}, nil
+		}
+	}
+
+	return token{}, errUnexpectedEnd
+}
+
+// skipIgnored skips whitespaces, commas, comments and byte order marks.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
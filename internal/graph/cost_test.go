@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		want          int
+		wantErr       bool
+	}{
+		{
+			name:  "default page",
+			query: `{ delegations { nodes { hash } } }`,
+			want:  100,
+		},
+		{
+			name:  "small page with count",
+			query: `{ delegations(first: 10, sort: {field: AMOUNT}) { totalCount nodes { hash amount } } }`,
+			want:  11,
+		},
+		{
+			name:  "aliases",
+			query: `{ a: delegations(first: 100) { nodes { hash } } b: delegations(first: 100) { nodes { hash } } }`,
+			want:  200,
+		},
+		{
+			name:  "nested delegators",
+			query: `{ delegations(first: 10) { nodes { delegator { currentBaker { address } } } } }`,
+			want:  30,
+		},
+		{
+			name:  "nested history",
+			query: `{ delegations(first: 10) { nodes { delegator { history { baker { address } } } } } }`,
+			want:  10 + 10 + 10*100 + 10*100,
+		},
+		{
+			name:  "fragments",
+			query: `query { bakers(first: 5) { ...page } } fragment page on BakerConnection { nodes { ... on Baker { flows { inflows { count } } } } }`,
+			want:  10,
+		},
+		{
+			name:  "recursive fragment",
+			query: `{ delegator(address: "tz1") { ...f } } fragment f on Delegator { currentBaker { address } ...f }`,
+			want:  2,
+		},
+		{
+			name:      "variable",
+			query:     `query Q($first: Int) { delegations(first: $first) @include(if: true) { nodes { hash } } }`,
+			variables: map[string]any{"first": float64(20)},
+			want:      20,
+		},
+		{
+			name:  "unknown variable",
+			query: `query Q($first: Int = 1) { delegations(first: $first) { nodes { hash } } }`,
+			want:  100,
+		},
+		{
+			name:          "named operation",
+			query:         `query A { delegations(first: 1) { totalCount } } query B { delegations(first: 2) { totalCount } }`,
+			operationName: "A",
+			want:          2,
+		},
+		{
+			name:  "costliest operation",
+			query: `query A { delegations(first: 1) { totalCount } } query B { delegations(first: 2) { totalCount } }`,
+			want:  3,
+		},
+		{
+			name:  "strings and comments",
+			query: "# comment\n{ delegator(address: \"\\\"}\") { history { hash } } b: baker(address: \"\"\"}\"\"\") { address } }",
+			want:  102,
+		},
+		{
+			name:    "unterminated",
+			query:   `{ delegations { nodes { hash }`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := queryCost(tt.query, tt.operationName, tt.variables)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestQueryCost_Request(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "pages read",
+			body: `{"query": "{ a: delegations { nodes { hash } } b: delegations(first: 1) { nodes { hash } } }"}`,
+			want: 2,
+		},
+		{
+			name: "invalid body",
+			body: `{"query": `,
+			want: 1,
+		},
+		{
+			name: "invalid query",
+			body: `{"query": "{"}`,
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				r := httptest.NewRequest(http.MethodPost, "/xtz/graphql", strings.NewReader(tt.body))
+				assert.Equal(t, tt.want, QueryCost(r))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.body, string(body), "body left unread")
+			},
+		)
+	}
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+)
+
+type DelegationConnectionResolver struct {
+	root  *Resolver
+	input delegationlist.Input
+	out   delegationlist.Output
+
+	// The count is shared by totalCount and totalCountEstimated, which may be resolved concurrently.
+	countOnce sync.Once
+	count     int64
+	estimated bool
+	countErr  error
+}
+
+func (r *DelegationConnectionResolver) Nodes() []*DelegationResolver {
+	nodes := make([]*DelegationResolver, len(r.out.Delegations))
+	for i, d := range r.out.Delegations {
+		nodes[i] = &DelegationResolver{root: r.root, data: d}
+	}
+
+	return nodes
+}
+
+func (r *DelegationConnectionResolver) PageInfo() PageInfoResolver {
+	return PageInfoResolver{endCursor: r.out.NextCursor}
+}
+
+func (r *DelegationConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	if err := r.countDelegations(ctx); err != nil {
+		return 0, err
+	}
+
+	return int32(r.count), nil
+}
+
+func (r *DelegationConnectionResolver) TotalCountEstimated(ctx context.Context) (bool, error) {
+	if err := r.countDelegations(ctx); err != nil {
+		return false, err
+	}
+
+	return r.estimated, nil
+}
+
+// countDelegations counts the delegations matching the filters of the connection, once.
+func (r *DelegationConnectionResolver) countDelegations(ctx context.Context) error {
+	r.countOnce.Do(
+		func() {
+			r.count, r.estimated, r.countErr = r.root.delegationListUseCase.CountDelegations(ctx, r.input)
+			if r.countErr != nil {
+				r.countErr = resolverError(ctx, r.countErr)
+			}
+		},
+	)
+
+	return r.countErr
+}
+
+type DelegationResolver struct {
+	root *Resolver
+	data delegationlist.DelegationData
+}
+
+func (r *DelegationResolver) Hash() string {
+	return r.data.Hash
+}
+
+func (r *DelegationResolver) Timestamp() graphql.Time {
+	return timeValue(r.data.Timestamp)
+}
+
+func (r *DelegationResolver) Amount() string {
+	return r.data.Amount.String()
+}
+
+func (r *DelegationResolver) Level() int32 {
+	return int32(r.data.Height)
+}
+
+func (r *DelegationResolver) Delegator(ctx context.Context) (*DelegatorResolver, error) {
+	return r.root.loadDelegator(ctx, r.data.Delegator)
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"kiln-exercice/internal/usecase/delegator/profile"
+)
+
+type DelegatorResolver struct {
+	root    *Resolver
+	profile profile.Output
+}
+
+func (r *DelegatorResolver) Address() string {
+	return r.profile.Address
+}
+
+func (r *DelegatorResolver) CurrentBaker(ctx context.Context) (*BakerResolver, error) {
+	if r.profile.CurrentBaker == "" {
+		return nil, nil
+	}
+
+	return r.root.loadBaker(ctx, r.profile.CurrentBaker)
+}
+
+func (r *DelegatorResolver) CurrentBakerUnknown() bool {
+	return r.profile.CurrentBakerUnknown
+}
+
+func (r *DelegatorResolver) FirstDelegationAt() graphql.Time {
+	return timeValue(r.profile.FirstDelegationAt)
+}
+
+func (r *DelegatorResolver) LastDelegationAt() graphql.Time {
+	return timeValue(r.profile.LastDelegationAt)
+}
+
+func (r *DelegatorResolver) BakerSwitches() int32 {
+	return int32(r.profile.BakerSwitches)
+}
+
+func (r *DelegatorResolver) DelegatedBalance() string {
+	return r.profile.DelegatedBalance.String()
+}
+
+func (r *DelegatorResolver) DelegationCount() int32 {
+	return int32(r.profile.DelegationCount)
+}
+
+func (r *DelegatorResolver) History() []*DelegatorDelegationResolver {
+	history := make([]*DelegatorDelegationResolver, len(r.profile.Delegations))
+	for i, d := range r.profile.Delegations {
+		history[i] = &DelegatorDelegationResolver{root: r.root, data: d}
+	}
+
+	return history
+}
+
+func (r *DelegatorResolver) HistoryTruncated() bool {
+	return r.profile.HistoryTruncated
+}
+
+type DelegatorDelegationResolver struct {
+	root *Resolver
+	data profile.DelegationData
+}
+
+func (r *DelegatorDelegationResolver) Hash() string {
+	return r.data.Hash
+}
+
+func (r *DelegatorDelegationResolver) Timestamp() graphql.Time {
+	return timeValue(r.data.Timestamp)
+}
+
+func (r *DelegatorDelegationResolver) Amount() string {
+	return r.data.Amount.String()
+}
+
+func (r *DelegatorDelegationResolver) Level() int32 {
+	return int32(r.data.Height)
+}
+
+func (r *DelegatorDelegationResolver) Baker(ctx context.Context) (*BakerResolver, error) {
+	if r.data.Baker == "" {
+		return nil, nil
+	}
+
+	return r.root.loadBaker(ctx, r.data.Baker)
+}
+
+func (r *DelegatorDelegationResolver) BakerUnknown() bool {
+	return r.data.BakerUnknown
+}
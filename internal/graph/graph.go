@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/rs/zerolog/log"
+
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/api"
+)
+
+const (
+	maxPageSize = 100
+	maxDepth    = 10
+)
+
+//go:embed schema.graphql
+var schema string
+
+type DelegationListUseCase interface {
+	ListDelegations(ctx context.Context, input delegationlist.Input) (delegationlist.Output, error)
+	CountDelegations(ctx context.Context, input delegationlist.Input) (count int64, estimated bool, err error)
+}
+
+type DelegatorProfileUseCase interface {
+	GetDelegators(ctx context.Context, addresses []string) (map[string]profile.Output, error)
+}
+
+type BakerGetUseCase interface {
+	GetBaker(ctx context.Context, input bakerget.Input) (bakerget.Output, error)
+	GetBakers(ctx context.Context, addresses []string) (map[string]bakerget.Summary, error)
+}
+
+type BakerListUseCase interface {
+	ListBakers(ctx context.Context, input bakerlist.Input) (bakerlist.Output, error)
+}
+
+// newSchema parses the GraphQL schema, resolved by the given resolver.
+func newSchema(resolver *Resolver) *graphql.Schema {
+	return graphql.MustParseSchema(
+		schema,
+		resolver,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		// The nodes of a page must be resolved concurrently for their loads to be batched.
+		graphql.MaxParallelism(maxPageSize),
+	)
+}
+
+// Error is a resolver error, carrying the code of the use case error in its extensions.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+// resolverError converts a use case error, hiding the details of internal errors from clients.
+func resolverError(ctx context.Context, err error) error {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		apiErr = api.NewError(api.Unknown, "internal error", err)
+	}
+
+	switch apiErr.Code {
+	case api.InvalidArgument:
+		return &Error{Code: "INVALID_ARGUMENT", Message: apiErr.Message}
+	case api.NotFound:
+		return &Error{Code: "NOT_FOUND", Message: apiErr.Message}
+	case api.ResourceExhausted:
+		return &Error{Code: "RESOURCE_EXHAUSTED", Message: apiErr.Message}
+	case api.Unauthenticated:
+		return &Error{Code: "UNAUTHENTICATED", Message: apiErr.Message}
+	case api.PermissionDenied:
+		return &Error{Code: "PERMISSION_DENIED", Message: apiErr.Message}
+	default:
+		log.Ctx(ctx).Info().Err(apiErr.Err).Msg(apiErr.Message)
+
+		return &Error{Code: "INTERNAL", Message: "internal error"}
+	}
+}
+
+// invalidArgument returns a resolver error for invalid arguments.
+func invalidArgument(message string) error {
+	return &Error{Code: "INVALID_ARGUMENT", Message: message}
+}
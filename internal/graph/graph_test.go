@@ -0,0 +1,362 @@
+//go:generate mockery
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/graph/mocks"
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/api"
+)
+
+const (
+	delegatorA = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+	delegatorB = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+	bakerA     = "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd"
+	bakerB     = "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"
+)
+
+var timestamp = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type env struct {
+	delegationListUseCase *mocks.DelegationListUseCase
+	profileUseCase        *mocks.DelegatorProfileUseCase
+	bakerGetUseCase       *mocks.BakerGetUseCase
+	bakerListUseCase      *mocks.BakerListUseCase
+}
+
+func newEnv(t *testing.T) env {
+	return env{
+		delegationListUseCase: mocks.NewDelegationListUseCase(t),
+		profileUseCase:        mocks.NewDelegatorProfileUseCase(t),
+		bakerGetUseCase:       mocks.NewBakerGetUseCase(t),
+		bakerListUseCase:      mocks.NewBakerListUseCase(t),
+	}
+}
+
+// query posts a GraphQL query to a handler built from the env and returns the response.
+func query(t *testing.T, e env, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := NewHandler(e.delegationListUseCase, e.profileUseCase, e.bakerGetUseCase, e.bakerListUseCase)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/xtz/graphql", strings.NewReader(body)))
+
+	return rec
+}
+
+// graphQLBody returns the JSON body of a request for a query.
+func graphQLBody(t *testing.T, query string) string {
+	t.Helper()
+
+	b, err := json.Marshal(map[string]any{"query": query})
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+// sameAddresses matches a batch of addresses, whatever their order.
+func sameAddresses(want ...string) any {
+	return mock.MatchedBy(
+		func(got []string) bool {
+			got = slices.Clone(got)
+			slices.Sort(got)
+			slices.Sort(want)
+
+			return slices.Equal(got, want)
+		},
+	)
+}
+
+func TestHandler_Delegations(t *testing.T) {
+	t.Parallel()
+
+	e := newEnv(t)
+
+	input := delegationlist.Input{
+		Year:       2024,
+		Delegators: []string{delegatorA, delegatorB},
+		Sort:       api.Sort{Field: "amount", Order: api.OrderAsc},
+		SkipCount:  true,
+		Pagination: api.Pagination{PageNumber: 1, PageSize: 3, Cursor: "abc"},
+	}
+
+	e.delegationListUseCase.EXPECT().ListDelegations(mock.Anything, input).Return(
+		delegationlist.Output{
+			Delegations: []delegationlist.DelegationData{
+				{Hash: "op1", Timestamp: timestamp, Amount: decimal.NewFromInt(1), Delegator: delegatorA, Level: "1", Height: 1},
+				{Hash: "op2", Timestamp: timestamp, Amount: decimal.NewFromInt(2), Delegator: delegatorB, Level: "2", Height: 2},
+				{Hash: "op3", Timestamp: timestamp, Amount: decimal.NewFromInt(3), Delegator: delegatorA, Level: "3", Height: 3},
+			},
+			NextCursor: "next",
+		}, nil,
+	).Once()
+
+	// Both count fields share a single count.
+	e.delegationListUseCase.EXPECT().CountDelegations(mock.Anything, input).Return(10, false, nil).Once()
+
+	// The delegators of all nodes, then their bakers, are each loaded in a single batch.
+	e.profileUseCase.EXPECT().GetDelegators(mock.Anything, sameAddresses(delegatorA, delegatorB)).Return(
+		map[string]profile.Output{
+			delegatorA: {Address: delegatorA, CurrentBaker: bakerA, BakerSwitches: 1},
+			delegatorB: {Address: delegatorB, CurrentBaker: bakerB},
+		}, nil,
+	).Once()
+
+	e.bakerGetUseCase.EXPECT().GetBakers(mock.Anything, sameAddresses(bakerA, bakerB)).Return(
+		map[string]bakerget.Summary{
+			bakerA: {Address: bakerA, DelegatorCount: 2, DelegatedAmount: decimal.NewFromInt(4)},
+		}, nil,
+	).Once()
+
+	rec := query(
+		t, e, graphQLBody(
+			t, `{
+				delegations(
+					filter: {year: 2024, delegators: ["`+delegatorA+`", "`+delegatorB+`"]}
+					sort: {field: AMOUNT, order: ASC}
+					first: 3
+					after: "abc"
+				) {
+					nodes {
+						hash
+						amount
+						level
+						delegator { address bakerSwitches currentBaker { address delegatorCount delegatedAmount } }
+					}
+					pageInfo { hasNextPage endCursor }
+					totalCount
+					totalCountEstimated
+				}
+			}`,
+		),
+	)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(
+		t, `{"data": {"delegations": {
+			"nodes": [
+				{"hash": "op1", "amount": "1", "level": 1, "delegator": {"address": "`+delegatorA+`", "bakerSwitches": 1,
+					"currentBaker": {"address": "`+bakerA+`", "delegatorCount": 2, "delegatedAmount": "4"}}},
+				{"hash": "op2", "amount": "2", "level": 2, "delegator": {"address": "`+delegatorB+`", "bakerSwitches": 0,
+					"currentBaker": null}},
+				{"hash": "op3", "amount": "3", "level": 3, "delegator": {"address": "`+delegatorA+`", "bakerSwitches": 1,
+					"currentBaker": {"address": "`+bakerA+`", "delegatorCount": 2, "delegatedAmount": "4"}}}
+			],
+			"pageInfo": {"hasNextPage": true, "endCursor": "next"},
+			"totalCount": 10,
+			"totalCountEstimated": false
+		}}}`, rec.Body.String(),
+	)
+}
+
+func TestHandler_Delegator(t *testing.T) {
+	t.Parallel()
+
+	e := newEnv(t)
+
+	e.profileUseCase.EXPECT().GetDelegators(mock.Anything, []string{delegatorA}).Return(
+		map[string]profile.Output{
+			delegatorA: {
+				Address:           delegatorA,
+				CurrentBaker:      bakerB,
+				FirstDelegationAt: timestamp,
+				LastDelegationAt:  timestamp.Add(time.Hour),
+				DelegatedBalance:  decimal.NewFromInt(5),
+				Delegations: []profile.DelegationData{
+					{Hash: "op2", Timestamp: timestamp.Add(time.Hour), Amount: decimal.NewFromInt(5), Baker: bakerB, Level: "2", Height: 2},
+					{Hash: "op1", Timestamp: timestamp, Amount: decimal.NewFromInt(5), Baker: bakerA, Level: "1", Height: 1},
+				},
+			},
+		}, nil,
+	).Once()
+
+	// The current baker is loaded along with the bakers of the history.
+	e.bakerGetUseCase.EXPECT().GetBakers(mock.Anything, sameAddresses(bakerA, bakerB)).Return(
+		map[string]bakerget.Summary{
+			bakerA: {Address: bakerA, DelegatorCount: 1, DelegatedAmount: decimal.NewFromInt(1)},
+			bakerB: {Address: bakerB, DelegatorCount: 3, DelegatedAmount: decimal.NewFromInt(9)},
+		}, nil,
+	).Once()
+
+	e.bakerGetUseCase.EXPECT().GetBaker(mock.Anything, bakerget.Input{Address: bakerB, From: timestamp}).Return(
+		bakerget.Output{
+			Inflows:   bakerget.FlowData{Count: 2, Amount: decimal.NewFromInt(7)},
+			Outflows:  bakerget.FlowData{Count: 1, Amount: decimal.NewFromInt(1)},
+			NetChange: bakerget.FlowData{Count: 1, Amount: decimal.NewFromInt(6)},
+		}, nil,
+	).Once()
+
+	rec := query(
+		t, e, graphQLBody(
+			t, `{
+				delegator(address: "`+delegatorA+`") {
+					firstDelegationAt
+					lastDelegationAt
+					delegatedBalance
+					currentBaker {
+						delegatorCount
+						flows(from: "2024-01-01T00:00:00Z") { netChange { count amount } }
+					}
+					history { hash level baker { address delegatorCount } }
+				}
+			}`,
+		),
+	)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(
+		t, `{"data": {"delegator": {
+			"firstDelegationAt": "2024-01-01T00:00:00Z",
+			"lastDelegationAt": "2024-01-01T01:00:00Z",
+			"delegatedBalance": "5",
+			"currentBaker": {"delegatorCount": 3, "flows": {"netChange": {"count": 1, "amount": "6"}}},
+			"history": [
+				{"hash": "op2", "level": 2, "baker": {"address": "`+bakerB+`", "delegatorCount": 3}},
+				{"hash": "op1", "level": 1, "baker": {"address": "`+bakerA+`", "delegatorCount": 1}}
+			]
+		}}}`, rec.Body.String(),
+	)
+}
+
+func TestHandler_Bakers(t *testing.T) {
+	t.Parallel()
+
+	e := newEnv(t)
+
+	e.bakerListUseCase.EXPECT().ListBakers(
+		mock.Anything, bakerlist.Input{Pagination: api.Pagination{PageNumber: 1, PageSize: api.DefaultPageSize}},
+	).Return(
+		bakerlist.Output{
+			Bakers:     []bakerlist.BakerData{{Address: bakerA, DelegatorCount: 2, DelegatedAmount: decimal.NewFromInt(4)}},
+			TotalCount: 1,
+		}, nil,
+	).Once()
+
+	rec := query(t, e, graphQLBody(t, `{bakers { nodes { address delegatorCount } pageInfo { hasNextPage endCursor } totalCount }}`))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(
+		t, `{"data": {"bakers": {
+			"nodes": [{"address": "`+bakerA+`", "delegatorCount": 2}],
+			"pageInfo": {"hasNextPage": false, "endCursor": null},
+			"totalCount": 1
+		}}}`, rec.Body.String(),
+	)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(e env)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "invalid body",
+			body:       `{"query": 1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid query",
+			body:       `{"query": "{ unknown }"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid address",
+			body:       `{"query": "{ delegator(address: \"tz1\") { address } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"data": {"delegator": null}, "errors": [{
+				"message": "invalid address: invalid tezos address: \"tz1\" must be 36 characters long",
+				"path": ["delegator"], "extensions": {"code": "INVALID_ARGUMENT"}
+			}]}`,
+		},
+		{
+			name: "invalid sibling address",
+			body: `{"query": "{ a: delegator(address: \"tz1\") { address } b: delegator(address: \"` + delegatorA + `\") { address } }"}`,
+			setup: func(e env) {
+				e.profileUseCase.EXPECT().GetDelegators(mock.Anything, []string{delegatorA}).
+					Return(map[string]profile.Output{delegatorA: {Address: delegatorA}}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"data": {"a": null, "b": {"address": "` + delegatorA + `"}}, "errors": [{
+				"message": "invalid address: invalid tezos address: \"tz1\" must be 36 characters long",
+				"path": ["a"], "extensions": {"code": "INVALID_ARGUMENT"}
+			}]}`,
+		},
+		{
+			name:       "page too large",
+			body:       `{"query": "{ bakers(first: 101) { totalCount } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"data": null, "errors": [{"message": "first must be between 1 and 100",
+				"path": ["bakers"], "extensions": {"code": "INVALID_ARGUMENT"}}]}`,
+		},
+		{
+			name:       "query too costly",
+			body:       `{"query": "{ delegations { nodes { delegator { history { hash } } } } }"}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"errors": [{"message": "query reads up to 10200 rows, more than the 1000 allowed",
+				"extensions": {"code": "INVALID_ARGUMENT"}}]}`,
+		},
+		{
+			name: "unknown delegator",
+			body: `{"query": "{ delegator(address: \"` + delegatorA + `\") { address } }"}`,
+			setup: func(e env) {
+				e.profileUseCase.EXPECT().GetDelegators(mock.Anything, []string{delegatorA}).
+					Return(map[string]profile.Output{}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data": {"delegator": null}}`,
+		},
+		{
+			name: "internal error",
+			body: `{"query": "{ baker(address: \"` + bakerA + `\") { address } }"}`,
+			setup: func(e env) {
+				e.bakerGetUseCase.EXPECT().GetBakers(mock.Anything, []string{bakerA}).
+					Return(nil, api.NewError(api.Unknown, "error listing bakers", errors.New("connection refused"))).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"data": {"baker": null}, "errors": [{"message": "internal error",
+				"path": ["baker"], "extensions": {"code": "INTERNAL"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := newEnv(t)
+				if tt.setup != nil {
+					tt.setup(e)
+				}
+
+				rec := query(t, e, tt.body)
+
+				assert.Equal(t, tt.wantStatus, rec.Code)
+				if tt.wantBody != "" {
+					assert.JSONEq(t, tt.wantBody, rec.Body.String())
+				}
+			},
+		)
+	}
+}
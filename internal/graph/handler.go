@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+
+	"kiln-exercice/pkg/http/api"
+)
+
+type Handler struct {
+	schema          *graphql.Schema
+	profileUseCase  DelegatorProfileUseCase
+	bakerGetUseCase BakerGetUseCase
+}
+
+func NewHandler(
+	delegationListUseCase DelegationListUseCase,
+	profileUseCase DelegatorProfileUseCase,
+	bakerGetUseCase BakerGetUseCase,
+	bakerListUseCase BakerListUseCase,
+) *Handler {
+	return &Handler{
+		schema: newSchema(
+			&Resolver{
+				delegationListUseCase: delegationListUseCase,
+				bakerGetUseCase:       bakerGetUseCase,
+				bakerListUseCase:      bakerListUseCase,
+			},
+		),
+		profileUseCase:  profileUseCase,
+		bakerGetUseCase: bakerGetUseCase,
+	}
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    map[string]any `json:"extensions"` // Ignored, but sent by some clients.
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+// Handle executes a GraphQL query. Query errors are part of the response, which is sent with a 200 status code.
+// Queries reading more than maxCost rows are rejected before being executed; syntax errors are left to the schema.
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := api.JSONFromBody(w, r, &req); err != nil {
+		return err
+	}
+
+	var res *graphql.Response
+
+	if cost, err := queryCost(req.Query, req.OperationName, req.Variables); err == nil && cost > maxCost {
+		res = &graphql.Response{Errors: []*errors.QueryError{{
+			Message:    fmt.Sprintf("query reads up to %d rows, more than the %d allowed", cost, maxCost),
+			Extensions: map[string]any{"code": "INVALID_ARGUMENT"},
+		}}}
+	} else {
+		ctx := withLoaders(r.Context(), h.profileUseCase, h.bakerGetUseCase)
+		res = h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	}
+
+	// GraphQL responses have their own envelope, so they are not wrapped like api.JSONResponse does.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	return json.NewEncoder(w).Encode(res)
+}
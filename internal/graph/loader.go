@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	"kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/dataloader"
+	"kiln-exercice/pkg/tezos"
+)
+
+// loaderWait is how long loaders wait for the loads of sibling nodes before fetching a batch.
+const loaderWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch the loads of delegators and bakers within a request, so that resolving the delegator of each node
+// of a page, then their current baker, runs two queries instead of two per node.
+type loaders struct {
+	delegators *dataloader.Loader[string, profile.Output]
+	bakers     *dataloader.Loader[string, bakerget.Summary]
+}
+
+// withLoaders returns a context holding new loaders, to be scoped to a single request.
+func withLoaders(ctx context.Context, profileUseCase DelegatorProfileUseCase, bakerGetUseCase BakerGetUseCase) context.Context {
+	return context.WithValue(
+		ctx, loadersKey{}, &loaders{
+			delegators: dataloader.New(profileUseCase.GetDelegators, loaderWait, maxPageSize),
+			bakers:     dataloader.New(bakerGetUseCase.GetBakers, loaderWait, maxPageSize),
+		},
+	)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// loadDelegator returns the profile of a delegator, nil when it never delegated.
+func (r *Resolver) loadDelegator(ctx context.Context, address string) (*DelegatorResolver, error) {
+	if err := validateAddress(address); err != nil {
+		return nil, err
+	}
+
+	out, found, err := loadersFromContext(ctx).delegators.Load(ctx, address)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return &DelegatorResolver{root: r, profile: out}, nil
+}
+
+// loadBaker returns the summary of a baker, nil when it never received a delegation.
+func (r *Resolver) loadBaker(ctx context.Context, address string) (*BakerResolver, error) {
+	if err := validateAddress(address); err != nil {
+		return nil, err
+	}
+
+	out, found, err := loadersFromContext(ctx).bakers.Load(ctx, address)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return &BakerResolver{root: r, summary: out}, nil
+}
+
+// validateAddress checks an address before it is loaded, as the batch functions of the loaders leave invalid ones
+// out instead of failing the addresses of sibling nodes.
+func validateAddress(address string) error {
+	if err := tezos.ValidateAddress(address); err != nil {
+		return invalidArgument(fmt.Sprintf("invalid address: %s", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	list "kiln-exercice/internal/usecase/baker/list"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BakerListUseCase is an autogenerated mock type for the BakerListUseCase type
+type BakerListUseCase struct {
+	mock.Mock
+}
+
+type BakerListUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BakerListUseCase) EXPECT() *BakerListUseCase_Expecter {
+	return &BakerListUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ListBakers provides a mock function with given fields: ctx, input
+func (_m *BakerListUseCase) ListBakers(ctx context.Context, input list.Input) (list.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBakers")
+	}
+
+	var r0 list.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) (list.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) list.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(list.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, list.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerListUseCase_ListBakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBakers'
+type BakerListUseCase_ListBakers_Call struct {
+	*mock.Call
+}
+
+// ListBakers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input list.Input
+func (_e *BakerListUseCase_Expecter) ListBakers(ctx interface{}, input interface{}) *BakerListUseCase_ListBakers_Call {
+	return &BakerListUseCase_ListBakers_Call{Call: _e.mock.On("ListBakers", ctx, input)}
+}
+
+func (_c *BakerListUseCase_ListBakers_Call) Run(run func(ctx context.Context, input list.Input)) *BakerListUseCase_ListBakers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(list.Input))
+	})
+	return _c
+}
+
+func (_c *BakerListUseCase_ListBakers_Call) Return(_a0 list.Output, _a1 error) *BakerListUseCase_ListBakers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerListUseCase_ListBakers_Call) RunAndReturn(run func(context.Context, list.Input) (list.Output, error)) *BakerListUseCase_ListBakers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBakerListUseCase creates a new instance of BakerListUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBakerListUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BakerListUseCase {
+	mock := &BakerListUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	list "kiln-exercice/internal/usecase/delegation/list"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationListUseCase is an autogenerated mock type for the DelegationListUseCase type
+type DelegationListUseCase struct {
+	mock.Mock
+}
+
+type DelegationListUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationListUseCase) EXPECT() *DelegationListUseCase_Expecter {
+	return &DelegationListUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CountDelegations provides a mock function with given fields: ctx, input
+func (_m *DelegationListUseCase) CountDelegations(ctx context.Context, input list.Input) (int64, bool, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDelegations")
+	}
+
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) (int64, bool, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) int64); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, list.Input) bool); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, list.Input) error); ok {
+		r2 = rf(ctx, input)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DelegationListUseCase_CountDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDelegations'
+type DelegationListUseCase_CountDelegations_Call struct {
+	*mock.Call
+}
+
+// CountDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input list.Input
+func (_e *DelegationListUseCase_Expecter) CountDelegations(ctx interface{}, input interface{}) *DelegationListUseCase_CountDelegations_Call {
+	return &DelegationListUseCase_CountDelegations_Call{Call: _e.mock.On("CountDelegations", ctx, input)}
+}
+
+func (_c *DelegationListUseCase_CountDelegations_Call) Run(run func(ctx context.Context, input list.Input)) *DelegationListUseCase_CountDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(list.Input))
+	})
+	return _c
+}
+
+func (_c *DelegationListUseCase_CountDelegations_Call) Return(count int64, estimated bool, err error) *DelegationListUseCase_CountDelegations_Call {
+	_c.Call.Return(count, estimated, err)
+	return _c
+}
+
+func (_c *DelegationListUseCase_CountDelegations_Call) RunAndReturn(run func(context.Context, list.Input) (int64, bool, error)) *DelegationListUseCase_CountDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDelegations provides a mock function with given fields: ctx, input
+func (_m *DelegationListUseCase) ListDelegations(ctx context.Context, input list.Input) (list.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDelegations")
+	}
+
+	var r0 list.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) (list.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) list.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(list.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, list.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationListUseCase_ListDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDelegations'
+type DelegationListUseCase_ListDelegations_Call struct {
+	*mock.Call
+}
+
+// ListDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input list.Input
+func (_e *DelegationListUseCase_Expecter) ListDelegations(ctx interface{}, input interface{}) *DelegationListUseCase_ListDelegations_Call {
+	return &DelegationListUseCase_ListDelegations_Call{Call: _e.mock.On("ListDelegations", ctx, input)}
+}
+
+func (_c *DelegationListUseCase_ListDelegations_Call) Run(run func(ctx context.Context, input list.Input)) *DelegationListUseCase_ListDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(list.Input))
+	})
+	return _c
+}
+
+func (_c *DelegationListUseCase_ListDelegations_Call) Return(_a0 list.Output, _a1 error) *DelegationListUseCase_ListDelegations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationListUseCase_ListDelegations_Call) RunAndReturn(run func(context.Context, list.Input) (list.Output, error)) *DelegationListUseCase_ListDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationListUseCase creates a new instance of DelegationListUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationListUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationListUseCase {
+	mock := &DelegationListUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
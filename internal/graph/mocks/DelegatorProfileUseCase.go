@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	profile "kiln-exercice/internal/usecase/delegator/profile"
+)
+
+// DelegatorProfileUseCase is an autogenerated mock type for the DelegatorProfileUseCase type
+type DelegatorProfileUseCase struct {
+	mock.Mock
+}
+
+type DelegatorProfileUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegatorProfileUseCase) EXPECT() *DelegatorProfileUseCase_Expecter {
+	return &DelegatorProfileUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetDelegators provides a mock function with given fields: ctx, addresses
+func (_m *DelegatorProfileUseCase) GetDelegators(ctx context.Context, addresses []string) (map[string]profile.Output, error) {
+	ret := _m.Called(ctx, addresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegators")
+	}
+
+	var r0 map[string]profile.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]profile.Output, error)); ok {
+		return rf(ctx, addresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]profile.Output); ok {
+		r0 = rf(ctx, addresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]profile.Output)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegatorProfileUseCase_GetDelegators_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelegators'
+type DelegatorProfileUseCase_GetDelegators_Call struct {
+	*mock.Call
+}
+
+// GetDelegators is a helper method to define mock.On call
+//   - ctx context.Context
+//   - addresses []string
+func (_e *DelegatorProfileUseCase_Expecter) GetDelegators(ctx interface{}, addresses interface{}) *DelegatorProfileUseCase_GetDelegators_Call {
+	return &DelegatorProfileUseCase_GetDelegators_Call{Call: _e.mock.On("GetDelegators", ctx, addresses)}
+}
+
+func (_c *DelegatorProfileUseCase_GetDelegators_Call) Run(run func(ctx context.Context, addresses []string)) *DelegatorProfileUseCase_GetDelegators_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *DelegatorProfileUseCase_GetDelegators_Call) Return(_a0 map[string]profile.Output, _a1 error) *DelegatorProfileUseCase_GetDelegators_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegatorProfileUseCase_GetDelegators_Call) RunAndReturn(run func(context.Context, []string) (map[string]profile.Output, error)) *DelegatorProfileUseCase_GetDelegators_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegatorProfileUseCase creates a new instance of DelegatorProfileUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegatorProfileUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegatorProfileUseCase {
+	mock := &DelegatorProfileUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
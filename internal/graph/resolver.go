@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+)
+
+// Resolver resolves the root Query type.
+type Resolver struct {
+	delegationListUseCase DelegationListUseCase
+	bakerGetUseCase       BakerGetUseCase
+	bakerListUseCase      BakerListUseCase
+}
+
+type DelegationFilter struct {
+	Year       *int32
+	From       *graphql.Time
+	To         *graphql.Time
+	MinLevel   *int32
+	MaxLevel   *int32
+	Delegators *[]string
+}
+
+type SortInput struct {
+	Field string
+	Order string
+}
+
+type DelegationsArgs struct {
+	Filter *DelegationFilter
+	Sort   *SortInput
+	First  int32
+	After  *string
+}
+
+func (r *Resolver) Delegations(ctx context.Context, args DelegationsArgs) (*DelegationConnectionResolver, error) {
+	input := delegationlist.Input{Sort: sortFromArgs(args.Sort)}
+
+	if f := args.Filter; f != nil {
+		input.Year = intValue(f.Year)
+		input.MinLevel = intValue(f.MinLevel)
+		input.MaxLevel = intValue(f.MaxLevel)
+
+		if f.From != nil {
+			input.From = f.From.Time
+		}
+
+		if f.To != nil {
+			input.To = f.To.Time
+		}
+
+		if f.Delegators != nil {
+			input.Delegators = *f.Delegators
+		}
+	}
+
+	var err error
+	if input.Pagination, err = paginationFromArgs(args.First, args.After); err != nil {
+		return nil, err
+	}
+
+	// The delegations are only counted when totalCount is selected.
+	input.SkipCount = true
+
+	out, err := r.delegationListUseCase.ListDelegations(ctx, input)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &DelegationConnectionResolver{root: r, input: input, out: out}, nil
+}
+
+type AddressArgs struct {
+	Address string
+}
+
+func (r *Resolver) Delegator(ctx context.Context, args AddressArgs) (*DelegatorResolver, error) {
+	return r.loadDelegator(ctx, args.Address)
+}
+
+func (r *Resolver) Baker(ctx context.Context, args AddressArgs) (*BakerResolver, error) {
+	return r.loadBaker(ctx, args.Address)
+}
+
+type BakersArgs struct {
+	Sort  *SortInput
+	First int32
+	After *string
+}
+
+func (r *Resolver) Bakers(ctx context.Context, args BakersArgs) (*BakerConnectionResolver, error) {
+	input := bakerlist.Input{Sort: sortFromArgs(args.Sort)}
+
+	var err error
+	if input.Pagination, err = paginationFromArgs(args.First, args.After); err != nil {
+		return nil, err
+	}
+
+	out, err := r.bakerListUseCase.ListBakers(ctx, input)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &BakerConnectionResolver{root: r, out: out}, nil
+}
+
+// sortFromArgs converts a sort argument, whose values are checked by the schema enums.
+// An empty sort lets the use cases apply their default.
+func sortFromArgs(sort *SortInput) api.Sort {
+	if sort == nil {
+		return api.Sort{}
+	}
+
+	return api.Sort{Field: strings.ToLower(sort.Field), Order: strings.ToLower(sort.Order)}
+}
+
+// paginationFromArgs converts connection arguments to a keyset pagination. first defaults to 100 in the schema.
+func paginationFromArgs(first int32, after *string) (api.Pagination, error) {
+	if first < 1 || first > maxPageSize {
+		return api.Pagination{}, invalidArgument(fmt.Sprintf("first must be between 1 and %d", maxPageSize))
+	}
+
+	pagination := api.Pagination{
+		PageNumber: api.DefaultPageNumber,
+		PageSize:   int(first),
+	}
+
+	if after != nil {
+		pagination.Cursor = *after
+	}
+
+	return pagination, nil
+}
+
+type PageInfoResolver struct {
+	endCursor string
+}
+
+func (r PageInfoResolver) HasNextPage() bool {
+	return r.endCursor != ""
+}
+
+func (r PageInfoResolver) EndCursor() *string {
+	if r.endCursor == "" {
+		return nil
+	}
+
+	return &r.endCursor
+}
+
+func intValue(v *int32) int {
+	if v == nil {
+		return 0
+	}
+
+	return int(*v)
+}
+
+func timeValue(t time.Time) graphql.Time {
+	return graphql.Time{Time: t}
+}
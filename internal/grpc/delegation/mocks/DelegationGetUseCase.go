@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	get "kiln-exercice/internal/usecase/delegation/get"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationGetUseCase is an autogenerated mock type for the DelegationGetUseCase type
+type DelegationGetUseCase struct {
+	mock.Mock
+}
+
+type DelegationGetUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationGetUseCase) EXPECT() *DelegationGetUseCase_Expecter {
+	return &DelegationGetUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetDelegations provides a mock function with given fields: ctx, input
+func (_m *DelegationGetUseCase) GetDelegations(ctx context.Context, input get.Input) ([]get.DelegationData, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegations")
+	}
+
+	var r0 []get.DelegationData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, get.Input) ([]get.DelegationData, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, get.Input) []get.DelegationData); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]get.DelegationData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, get.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationGetUseCase_GetDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelegations'
+type DelegationGetUseCase_GetDelegations_Call struct {
+	*mock.Call
+}
+
+// GetDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input get.Input
+func (_e *DelegationGetUseCase_Expecter) GetDelegations(ctx interface{}, input interface{}) *DelegationGetUseCase_GetDelegations_Call {
+	return &DelegationGetUseCase_GetDelegations_Call{Call: _e.mock.On("GetDelegations", ctx, input)}
+}
+
+func (_c *DelegationGetUseCase_GetDelegations_Call) Run(run func(ctx context.Context, input get.Input)) *DelegationGetUseCase_GetDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(get.Input))
+	})
+	return _c
+}
+
+func (_c *DelegationGetUseCase_GetDelegations_Call) Return(_a0 []get.DelegationData, _a1 error) *DelegationGetUseCase_GetDelegations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationGetUseCase_GetDelegations_Call) RunAndReturn(run func(context.Context, get.Input) ([]get.DelegationData, error)) *DelegationGetUseCase_GetDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationGetUseCase creates a new instance of DelegationGetUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationGetUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationGetUseCase {
+	mock := &DelegationGetUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,195 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+	xtzv1 "kiln-exercice/proto/xtz/v1"
+)
+
+type DelegationListUseCase interface {
+	ListDelegations(ctx context.Context, input delegationlist.Input) (delegationlist.Output, error)
+}
+
+type DelegationGetUseCase interface {
+	GetDelegations(ctx context.Context, input delegationget.Input) (delegationget.Output, error)
+}
+
+type DelegationExportUseCase interface {
+	ExportDelegations(ctx context.Context, input delegationlist.Input, fn func(delegationlist.DelegationData) error) error
+}
+
+// Server implements the gRPC DelegationService on top of the use cases of the HTTP API.
+// Errors are returned as use case errors, converted to gRPC statuses by the interceptors of pkg/grpc/api.
+type Server struct {
+	xtzv1.UnimplementedDelegationServiceServer
+
+	listUseCase   DelegationListUseCase
+	getUseCase    DelegationGetUseCase
+	exportUseCase DelegationExportUseCase
+}
+
+func NewServer(listUseCase DelegationListUseCase, getUseCase DelegationGetUseCase, exportUseCase DelegationExportUseCase) *Server {
+	return &Server{
+		listUseCase:   listUseCase,
+		getUseCase:    getUseCase,
+		exportUseCase: exportUseCase,
+	}
+}
+
+func (s *Server) ListDelegations(ctx context.Context, req *xtzv1.ListDelegationsRequest) (*xtzv1.ListDelegationsResponse, error) {
+	input, err := inputFromRequest(req.GetFilter(), req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	input.Pagination, err = paginationFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.listUseCase.ListDelegations(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &xtzv1.ListDelegationsResponse{
+		Delegations:         make([]*xtzv1.Delegation, len(out.Delegations)),
+		NextCursor:          out.NextCursor,
+		TotalCount:          out.TotalCount,
+		TotalCountEstimated: out.TotalCountEstimated,
+	}
+
+	for i, d := range out.Delegations {
+		res.Delegations[i] = delegationFromData(d)
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetDelegations(ctx context.Context, req *xtzv1.GetDelegationsRequest) (*xtzv1.GetDelegationsResponse, error) {
+	out, err := s.getUseCase.GetDelegations(ctx, delegationget.Input{Hash: req.GetHash()})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &xtzv1.GetDelegationsResponse{Delegations: make([]*xtzv1.Delegation, len(out))}
+
+	for i, d := range out {
+		res.Delegations[i] = &xtzv1.Delegation{
+			Hash:      d.Hash,
+			Timestamp: timestamppb.New(d.Timestamp),
+			Amount:    d.Amount.String(),
+			Delegator: d.Delegator,
+			Level:     d.Level,
+			Baker:     d.Baker,
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Server) ExportDelegations(req *xtzv1.ExportDelegationsRequest, stream xtzv1.DelegationService_ExportDelegationsServer) error {
+	input, err := inputFromRequest(req.GetFilter(), req.GetSort())
+	if err != nil {
+		return err
+	}
+
+	return s.exportUseCase.ExportDelegations(
+		stream.Context(), input, func(d delegationlist.DelegationData) error {
+			return stream.Send(delegationFromData(d))
+		},
+	)
+}
+
+// inputFromRequest converts the filter and sort of a request, validating the sort like api.SortFromRequest.
+func inputFromRequest(filter *xtzv1.DelegationFilter, sort *xtzv1.Sort) (delegationlist.Input, error) {
+	input := delegationlist.Input{
+		Year:       int(filter.GetYear()),
+		MinLevel:   int(filter.GetMinLevel()),
+		MaxLevel:   int(filter.GetMaxLevel()),
+		Delegators: filter.GetDelegators(),
+		Sort: api.Sort{
+			Field: sort.GetField(),
+			Order: strings.ToLower(sort.GetOrder()),
+		},
+	}
+
+	if filter.GetFrom() != nil {
+		input.From = filter.GetFrom().AsTime()
+	}
+
+	if filter.GetTo() != nil {
+		input.To = filter.GetTo().AsTime()
+	}
+
+	if input.Sort.Field == "" {
+		input.Sort.Field = delegationlist.SortFields[0]
+	}
+
+	if !slices.Contains(delegationlist.SortFields, input.Sort.Field) {
+		return delegationlist.Input{}, api.NewError(
+			api.InvalidArgument, fmt.Sprintf("sort field must be one of %s", strings.Join(delegationlist.SortFields, ", ")), nil,
+		)
+	}
+
+	switch input.Sort.Order {
+	case "":
+		input.Sort.Order = api.OrderDesc
+	case api.OrderAsc, api.OrderDesc:
+	default:
+		return delegationlist.Input{}, api.NewError(
+			api.InvalidArgument, fmt.Sprintf("sort order must be one of %s, %s", api.OrderAsc, api.OrderDesc), nil,
+		)
+	}
+
+	return input, nil
+}
+
+// paginationFromRequest converts the pagination of a request, with the defaults of api.PaginationFromRequest.
+func paginationFromRequest(req *xtzv1.ListDelegationsRequest) (api.Pagination, error) {
+	pagination := api.Pagination{
+		PageNumber: int(req.GetPageNumber()),
+		PageSize:   int(req.GetPageSize()),
+		Cursor:     req.GetCursor(),
+	}
+
+	if pagination.PageNumber < 0 || pagination.PageSize < 0 {
+		return api.Pagination{}, api.NewError(api.InvalidArgument, "page_number and page_size must be greater than 0", nil)
+	}
+
+	if pagination.PageSize > api.MaxPageSize {
+		return api.Pagination{}, api.NewError(api.InvalidArgument, fmt.Sprintf("page_size must be at most %d", api.MaxPageSize), nil)
+	}
+
+	if pagination.Cursor != "" && pagination.PageNumber != 0 {
+		return api.Pagination{}, api.NewError(api.InvalidArgument, "page_number and cursor are mutually exclusive", nil)
+	}
+
+	if pagination.PageNumber == 0 {
+		pagination.PageNumber = api.DefaultPageNumber
+	}
+
+	if pagination.PageSize == 0 {
+		pagination.PageSize = api.DefaultPageSize
+	}
+
+	return pagination, nil
+}
+
+func delegationFromData(d delegationlist.DelegationData) *xtzv1.Delegation {
+	return &xtzv1.Delegation{
+		Hash:      d.Hash,
+		Timestamp: timestamppb.New(d.Timestamp),
+		Amount:    d.Amount.String(),
+		Delegator: d.Delegator,
+		Level:     d.Level,
+	}
+}
@@ -0,0 +1,315 @@
+//go:generate mockery
+package delegation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kiln-exercice/internal/grpc/delegation/mocks"
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+	grpcapi "kiln-exercice/pkg/grpc/api"
+	xtzv1 "kiln-exercice/proto/xtz/v1"
+)
+
+const (
+	delegator = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+	hash      = "opNZVbTD6vmkRj8XhcqBTsWSa8GKBBTFnkK1hxZtaZZ3CaLPrrK"
+)
+
+type env struct {
+	listUseCase   *mocks.DelegationListUseCase
+	getUseCase    *mocks.DelegationGetUseCase
+	exportUseCase *mocks.DelegationExportUseCase
+}
+
+// newClient serves the use cases of the env in memory, with the interceptors of cmd/api.
+func newClient(t *testing.T, e env) xtzv1.DelegationServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcapi.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcapi.StreamServerInterceptor),
+	)
+	xtzv1.RegisterDelegationServiceServer(server, NewServer(e.listUseCase, e.getUseCase, e.exportUseCase))
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return xtzv1.NewDelegationServiceClient(conn)
+}
+
+func TestServer_ListDelegations(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		req      *xtzv1.ListDelegationsRequest
+		init     func(*env)
+		want     *xtzv1.ListDelegationsResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "happy path",
+			req: &xtzv1.ListDelegationsRequest{
+				Filter:   &xtzv1.DelegationFilter{Year: 2024, From: timestamppb.New(timestamp), Delegators: []string{delegator}},
+				Sort:     &xtzv1.Sort{Field: "amount", Order: "ASC"},
+				PageSize: 10,
+			},
+			init: func(e *env) {
+				e.listUseCase.EXPECT().ListDelegations(
+					mock.Anything, delegationlist.Input{
+						Year: 2024, From: timestamp, Delegators: []string{delegator},
+						Sort:       api.Sort{Field: "amount", Order: "asc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 10},
+					},
+				).Return(
+					delegationlist.Output{
+						Delegations: []delegationlist.DelegationData{
+							{Hash: hash, Timestamp: timestamp, Amount: decimal.RequireFromString("125896"), Delegator: delegator, Level: "42"},
+						},
+						NextCursor: "next",
+						TotalCount: 12,
+					}, nil,
+				)
+			},
+			want: &xtzv1.ListDelegationsResponse{
+				Delegations: []*xtzv1.Delegation{
+					{Hash: hash, Timestamp: timestamppb.New(timestamp), Amount: "125896", Delegator: delegator, Level: "42"},
+				},
+				NextCursor: "next",
+				TotalCount: 12,
+			},
+		},
+		{
+			name:     "invalid sort",
+			req:      &xtzv1.ListDelegationsRequest{Sort: &xtzv1.Sort{Field: "baker"}},
+			init:     func(e *env) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "cursor and page number",
+			req:      &xtzv1.ListDelegationsRequest{PageNumber: 2, Cursor: "next"},
+			init:     func(e *env) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "page size too large",
+			req:      &xtzv1.ListDelegationsRequest{PageSize: api.MaxPageSize + 1},
+			init:     func(e *env) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "use case error",
+			req:  &xtzv1.ListDelegationsRequest{},
+			init: func(e *env) {
+				e.listUseCase.EXPECT().ListDelegations(mock.Anything, mock.Anything).Return(
+					delegationlist.Output{}, api.NewError(api.InvalidArgument, "invalid year", nil),
+				)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{listUseCase: mocks.NewDelegationListUseCase(t)}
+				tt.init(&e)
+
+				got, err := newClient(t, e).ListDelegations(context.Background(), tt.req)
+				if tt.wantCode != codes.OK {
+					assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Empty(t, cmp.Diff(tt.want, got, protocmp.Transform()))
+			},
+		)
+	}
+}
+
+func TestServer_GetDelegations(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		init     func(*env)
+		want     *xtzv1.GetDelegationsResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "happy path",
+			init: func(e *env) {
+				e.getUseCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					delegationget.Output{
+						{
+							Hash: hash, Timestamp: timestamp, Amount: decimal.RequireFromString("10"), Delegator: delegator,
+							Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Level: "42",
+						},
+					}, nil,
+				)
+			},
+			want: &xtzv1.GetDelegationsResponse{
+				Delegations: []*xtzv1.Delegation{
+					{
+						Hash: hash, Timestamp: timestamppb.New(timestamp), Amount: "10", Delegator: delegator,
+						Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Level: "42",
+					},
+				},
+			},
+		},
+		{
+			name: "not found",
+			init: func(e *env) {
+				e.getUseCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					nil, api.NewError(api.NotFound, "no delegation found for hash "+hash, nil),
+				)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{getUseCase: mocks.NewDelegationGetUseCase(t)}
+				tt.init(&e)
+
+				got, err := newClient(t, e).GetDelegations(context.Background(), &xtzv1.GetDelegationsRequest{Hash: hash})
+				if tt.wantCode != codes.OK {
+					assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Empty(t, cmp.Diff(tt.want, got, protocmp.Transform()))
+			},
+		)
+	}
+}
+
+func TestServer_ExportDelegations(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		init     func(*env)
+		want     []*xtzv1.Delegation
+		wantCode codes.Code
+	}{
+		{
+			name: "happy path",
+			init: func(e *env) {
+				e.exportUseCase.EXPECT().ExportDelegations(
+					mock.Anything, delegationlist.Input{Year: 2024, Sort: api.Sort{Field: "timestamp", Order: "desc"}}, mock.Anything,
+				).RunAndReturn(
+					func(_ context.Context, _ delegationlist.Input, fn func(delegationlist.DelegationData) error) error {
+						for _, level := range []string{"2", "1"} {
+							err := fn(
+								delegationlist.DelegationData{
+									Hash: hash, Timestamp: timestamp, Amount: decimal.RequireFromString("10"), Delegator: delegator, Level: level,
+								},
+							)
+							if err != nil {
+								return err
+							}
+						}
+						return nil
+					},
+				)
+			},
+			want: []*xtzv1.Delegation{
+				{Hash: hash, Timestamp: timestamppb.New(timestamp), Amount: "10", Delegator: delegator, Level: "2"},
+				{Hash: hash, Timestamp: timestamppb.New(timestamp), Amount: "10", Delegator: delegator, Level: "1"},
+			},
+		},
+		{
+			name: "use case error",
+			init: func(e *env) {
+				e.exportUseCase.EXPECT().ExportDelegations(mock.Anything, mock.Anything, mock.Anything).Return(
+					api.NewError(api.Unknown, "error exporting delegations", errors.New("connection refused")),
+				)
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{exportUseCase: mocks.NewDelegationExportUseCase(t)}
+				tt.init(&e)
+
+				stream, err := newClient(t, e).ExportDelegations(
+					context.Background(), &xtzv1.ExportDelegationsRequest{Filter: &xtzv1.DelegationFilter{Year: 2024}},
+				)
+				require.NoError(t, err)
+
+				var got []*xtzv1.Delegation
+				for {
+					d, err := stream.Recv()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+						return
+					}
+
+					got = append(got, d)
+				}
+
+				assert.Equal(t, codes.OK, tt.wantCode)
+				assert.Empty(t, cmp.Diff(tt.want, got, protocmp.Transform()))
+			},
+		)
+	}
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	usage "kiln-exercice/internal/usecase/apikey/usage"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyUsageUseCase is an autogenerated mock type for the APIKeyUsageUseCase type
+type APIKeyUsageUseCase struct {
+	mock.Mock
+}
+
+type APIKeyUsageUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyUsageUseCase) EXPECT() *APIKeyUsageUseCase_Expecter {
+	return &APIKeyUsageUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetUsage provides a mock function with given fields: ctx, input
+func (_m *APIKeyUsageUseCase) GetUsage(ctx context.Context, input usage.Input) (usage.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsage")
+	}
+
+	var r0 usage.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usage.Input) (usage.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usage.Input) usage.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(usage.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usage.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyUsageUseCase_GetUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsage'
+type APIKeyUsageUseCase_GetUsage_Call struct {
+	*mock.Call
+}
+
+// GetUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usage.Input
+func (_e *APIKeyUsageUseCase_Expecter) GetUsage(ctx interface{}, input interface{}) *APIKeyUsageUseCase_GetUsage_Call {
+	return &APIKeyUsageUseCase_GetUsage_Call{Call: _e.mock.On("GetUsage", ctx, input)}
+}
+
+func (_c *APIKeyUsageUseCase_GetUsage_Call) Run(run func(ctx context.Context, input usage.Input)) *APIKeyUsageUseCase_GetUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usage.Input))
+	})
+	return _c
+}
+
+func (_c *APIKeyUsageUseCase_GetUsage_Call) Return(_a0 usage.Output, _a1 error) *APIKeyUsageUseCase_GetUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyUsageUseCase_GetUsage_Call) RunAndReturn(run func(context.Context, usage.Input) (usage.Output, error)) *APIKeyUsageUseCase_GetUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyUsageUseCase creates a new instance of APIKeyUsageUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyUsageUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyUsageUseCase {
+	mock := &APIKeyUsageUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+package apikey
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"kiln-exercice/internal/usecase/apikey/usage"
+	"kiln-exercice/pkg/http/api"
+)
+
+type APIKeyUsageUseCase interface {
+	GetUsage(ctx context.Context, input usage.Input) (usage.Output, error)
+}
+
+type APIKeyUsageHandler struct {
+	useCase APIKeyUsageUseCase
+}
+
+func NewAPIKeyUsageHandler(useCase APIKeyUsageUseCase) *APIKeyUsageHandler {
+	return &APIKeyUsageHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *APIKeyUsageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *APIKeyUsageHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		query = r.URL.Query()
+		input usage.Input
+		err   error
+	)
+
+	raw := r.PathValue("id")
+	if input.ID, err = strconv.Atoi(raw); err != nil {
+		return api.BadRequestError("invalid id format: "+raw, err)
+	}
+
+	if input.From, err = api.TimeFromQuery(query, "from"); err != nil {
+		return err
+	}
+
+	if input.To, err = api.TimeFromQuery(query, "to"); err != nil {
+		return err
+	}
+
+	out, err := h.useCase.GetUsage(r.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return api.JSONResponse(w, http.StatusOK, out)
+}
@@ -0,0 +1,101 @@
+//go:generate mockery
+package apikey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/apikey/mocks"
+	"kiln-exercice/internal/usecase/apikey/usage"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestAPIKeyUsageHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.APIKeyUsageUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/keys/7/usage?from=2024-03-01T00:00:00Z", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetUsage(
+					mock.Anything, usage.Input{ID: 7, From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+				).Return(
+					usage.Output{
+						ID: 7, Name: "crm", DailyQuota: 1000,
+						Days: []usage.DayData{{Day: "2024-03-02", Requests: 12}},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": {
+				"id": 7,
+				"name": "crm",
+				"daily_quota": 1000,
+				"days": [{"day": "2024-03-02", "requests": 12}]
+			  }
+			}`,
+		},
+		{
+			name: "not found",
+			r:    httptest.NewRequest("GET", "/xtz/keys/7/usage", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetUsage(mock.Anything, usage.Input{ID: 7}).Return(
+					usage.Output{}, api.NewError(api.NotFound, "no API key found for id 7", nil),
+				)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"data": {"message": "no API key found for id 7"}}`,
+		},
+		{
+			name:     "invalid id",
+			r:        httptest.NewRequest("GET", "/xtz/keys/crm/usage", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid id format: crm"}}`,
+		},
+		{
+			name:     "invalid from",
+			r:        httptest.NewRequest("GET", "/xtz/keys/7/usage?from=yesterday", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid from format: yesterday, expected RFC 3339"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewAPIKeyUsageUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /xtz/keys/{id}/usage", NewAPIKeyUsageHandler(e.useCase))
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
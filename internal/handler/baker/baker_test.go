@@ -0,0 +1,184 @@
+//go:generate mockery
+package baker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/baker/mocks"
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+const address = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+
+func TestBakerListHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.BakerListUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/bakers?page_size=1&sort=amount", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().ListBakers(
+					mock.Anything, bakerlist.Input{
+						Sort:       api.Sort{Field: "amount", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 1},
+					},
+				).Return(
+					bakerlist.Output{
+						Bakers: []bakerlist.BakerData{
+							{Address: address, DelegatorCount: 12, DelegatedAmount: decimal.RequireFromString("1000")},
+						},
+						TotalCount: 1,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+				  "address": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				  "delegator_count": 12,
+				  "delegated_amount": "1000"
+				}
+			  ],
+			  "pagination": {
+				"page_size": 1,
+				"page_number": 1,
+				"total_count": 1,
+				"total_count_estimated": false,
+				"has_more": false,
+				"first": "/xtz/bakers?page_size=1&sort=amount"
+			  }
+			}`,
+		},
+		{
+			name:     "invalid sort",
+			r:        httptest.NewRequest("GET", "/xtz/bakers?sort=level", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Sort error: sort must be one of delegators, amount."}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewBakerListUseCase(t),
+				}
+
+				tt.init(&e)
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, NewBakerListHandler(e.useCase))
+			},
+		)
+	}
+}
+
+func TestBakerGetHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.BakerGetUseCase
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/bakers/"+address+"?from=2024-01-01T00:00:00Z", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetBaker(mock.Anything, bakerget.Input{Address: address, From: from}).Return(
+					bakerget.Output{
+						Address:         address,
+						DelegatorCount:  12,
+						DelegatedAmount: decimal.RequireFromString("1000"),
+						From:            &from,
+						Inflows:         bakerget.FlowData{Count: 3, Amount: decimal.RequireFromString("300")},
+						Outflows:        bakerget.FlowData{Count: 1, Amount: decimal.RequireFromString("100")},
+						NetChange:       bakerget.FlowData{Count: 2, Amount: decimal.RequireFromString("200")},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": {
+				"address": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				"delegator_count": 12,
+				"delegated_amount": "1000",
+				"from": "2024-01-01T00:00:00Z",
+				"inflows": {"count": 3, "amount": "300"},
+				"outflows": {"count": 1, "amount": "100"},
+				"net_change": {"count": 2, "amount": "200"}
+			  }
+			}`,
+		},
+		{
+			name: "not found",
+			r:    httptest.NewRequest("GET", "/xtz/bakers/"+address, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetBaker(mock.Anything, bakerget.Input{Address: address}).Return(
+					bakerget.Output{}, api.NewError(api.NotFound, "no delegation found for baker "+address, nil),
+				)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"data": {"message": "no delegation found for baker tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"}}`,
+		},
+		{
+			name:     "invalid to",
+			r:        httptest.NewRequest("GET", "/xtz/bakers/"+address+"?to=tomorrow", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid to format: tomorrow, expected RFC 3339"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewBakerGetUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /xtz/bakers/{address}", NewBakerGetHandler(e.useCase))
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
@@ -0,0 +1,50 @@
+package baker
+
+import (
+	"context"
+	"net/http"
+
+	bakerget "kiln-exercice/internal/usecase/baker/get"
+	"kiln-exercice/pkg/http/api"
+)
+
+type BakerGetUseCase interface {
+	GetBaker(ctx context.Context, input bakerget.Input) (bakerget.Output, error)
+}
+
+type BakerGetHandler struct {
+	useCase BakerGetUseCase
+}
+
+func NewBakerGetHandler(useCase BakerGetUseCase) *BakerGetHandler {
+	return &BakerGetHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *BakerGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *BakerGetHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		query = r.URL.Query()
+		input = bakerget.Input{Address: r.PathValue("address")}
+		err   error
+	)
+
+	if input.From, err = api.TimeFromQuery(query, "from"); err != nil {
+		return err
+	}
+
+	if input.To, err = api.TimeFromQuery(query, "to"); err != nil {
+		return err
+	}
+
+	baker, err := h.useCase.GetBaker(r.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return api.JSONResponse(w, http.StatusOK, baker)
+}
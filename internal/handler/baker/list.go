@@ -0,0 +1,60 @@
+package baker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	bakerlist "kiln-exercice/internal/usecase/baker/list"
+	"kiln-exercice/pkg/http/api"
+)
+
+type BakerListUseCase interface {
+	ListBakers(ctx context.Context, input bakerlist.Input) (bakerlist.Output, error)
+}
+
+type BakerListHandler struct {
+	useCase BakerListUseCase
+}
+
+func NewBakerListHandler(useCase BakerListUseCase) *BakerListHandler {
+	return &BakerListHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *BakerListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *BakerListHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		input bakerlist.Input
+		err   error
+	)
+
+	input.Pagination, err = api.PaginationFromRequest(r)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Pagination error: %s.", err.Error()))
+	}
+
+	input.Sort, err = api.SortFromRequest(r, bakerlist.SortFields...)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Sort error: %s.", err.Error()))
+	}
+
+	encoder, err := api.EncoderFromRequest(r)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Format error: %s.", err.Error()))
+	}
+
+	out, err := h.useCase.ListBakers(r.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return api.PageResponse(
+		w, r, encoder, http.StatusOK, out.Bakers,
+		api.NewPage(input.Pagination, out.TotalCount, false, out.NextCursor),
+	)
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	get "kiln-exercice/internal/usecase/baker/get"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BakerGetUseCase is an autogenerated mock type for the BakerGetUseCase type
+type BakerGetUseCase struct {
+	mock.Mock
+}
+
+type BakerGetUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BakerGetUseCase) EXPECT() *BakerGetUseCase_Expecter {
+	return &BakerGetUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetBaker provides a mock function with given fields: ctx, input
+func (_m *BakerGetUseCase) GetBaker(ctx context.Context, input get.Input) (get.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBaker")
+	}
+
+	var r0 get.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, get.Input) (get.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, get.Input) get.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(get.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, get.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerGetUseCase_GetBaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBaker'
+type BakerGetUseCase_GetBaker_Call struct {
+	*mock.Call
+}
+
+// GetBaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input get.Input
+func (_e *BakerGetUseCase_Expecter) GetBaker(ctx interface{}, input interface{}) *BakerGetUseCase_GetBaker_Call {
+	return &BakerGetUseCase_GetBaker_Call{Call: _e.mock.On("GetBaker", ctx, input)}
+}
+
+func (_c *BakerGetUseCase_GetBaker_Call) Run(run func(ctx context.Context, input get.Input)) *BakerGetUseCase_GetBaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(get.Input))
+	})
+	return _c
+}
+
+func (_c *BakerGetUseCase_GetBaker_Call) Return(_a0 get.Output, _a1 error) *BakerGetUseCase_GetBaker_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerGetUseCase_GetBaker_Call) RunAndReturn(run func(context.Context, get.Input) (get.Output, error)) *BakerGetUseCase_GetBaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBakerGetUseCase creates a new instance of BakerGetUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBakerGetUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BakerGetUseCase {
+	mock := &BakerGetUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,90 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/http/api"
+)
+
+type DelegationExportUseCase interface {
+	ExportDelegations(ctx context.Context, input delegationlist.Input, fn func(delegationlist.DelegationData) error) error
+}
+
+type DelegationExportHandler struct {
+	useCase DelegationExportUseCase
+	v2      bool
+}
+
+func NewDelegationExportHandler(useCase DelegationExportUseCase) *DelegationExportHandler {
+	return &DelegationExportHandler{
+		useCase: useCase,
+	}
+}
+
+// NewDelegationExportHandlerV2 returns a handler exporting delegations in their v2 representation.
+func NewDelegationExportHandlerV2(useCase DelegationExportUseCase) *DelegationExportHandler {
+	return &DelegationExportHandler{
+		useCase: useCase,
+		v2:      true,
+	}
+}
+
+func (h *DelegationExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+// Handle streams every delegation matching the filters of the list endpoint, without pagination.
+// The export stops as soon as the client disconnects, since the request context is then canceled.
+func (h *DelegationExportHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		input delegationlist.Input
+		err   error
+	)
+
+	if err = filterFromQuery(r.URL.Query(), &input); err != nil {
+		return err
+	}
+
+	input.Sort, err = api.SortFromRequest(r, delegationlist.SortFields...)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Sort error: %s.", err.Error()))
+	}
+
+	encoder, err := api.StreamEncoderFromRequest(r)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Format error: %s.", err.Error()))
+	}
+
+	var (
+		stream    = api.NewStream[delegationlist.DelegationData](w, encoder, "delegations")
+		represent = func(d delegationlist.DelegationData) any { return d }
+	)
+
+	if h.v2 {
+		currencies, err := quoteFromQuery(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		stream = api.NewStream[delegationV2](w, encoder, "delegations")
+		represent = func(d delegationlist.DelegationData) any { return delegationV2FromList(d, currencies) }
+	}
+
+	err = h.useCase.ExportDelegations(
+		r.Context(), input, func(d delegationlist.DelegationData) error {
+			return stream.Write(represent(d))
+		},
+	)
+	if err == nil {
+		err = stream.Close()
+	}
+
+	if err != nil && stream.Started() {
+		stream.Abort(r.Context(), err)
+	}
+
+	return err
+}
@@ -0,0 +1,118 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegation/mocks"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegationExportHandler(t *testing.T) {
+	t.Parallel()
+
+	delegation := delegationlist.DelegationData{
+		Hash:      "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+		Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+		Amount:    decimal.RequireFromString("125896"),
+		Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+		Level:     "2338084",
+	}
+
+	type env struct {
+		useCase *mocks.DelegationExportUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "csv",
+			r:    httptest.NewRequest("GET", "/xtz/delegations/export?year=2022", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().ExportDelegations(
+					mock.Anything,
+					delegationlist.Input{Year: 2022, Sort: api.Sort{Field: "timestamp", Order: "desc"}},
+					mock.Anything,
+				).RunAndReturn(
+					func(_ context.Context, _ delegationlist.Input, fn func(delegationlist.DelegationData) error) error {
+						return fn(delegation)
+					},
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "hash,timestamp,amount,delegator,level\n" +
+				"ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b,2022-05-05T06:29:14Z,125896,tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL,2338084\n",
+		},
+		{
+			name: "error before the first record",
+			r:    httptest.NewRequest("GET", "/xtz/delegations/export?format=ndjson", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().ExportDelegations(mock.Anything, mock.Anything, mock.Anything).
+					Return(api.NewError(api.InvalidArgument, "invalid year: 1990", nil))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid year: 1990"}}`,
+		},
+		{
+			name:     "json is not streamable",
+			r:        httptest.NewRequest("GET", "/xtz/delegations/export?format=json", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Format error: format must be one of csv, ndjson."}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegationExportUseCase(t),
+				}
+
+				tt.init(&e)
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, NewDelegationExportHandler(e.useCase))
+			},
+		)
+	}
+}
+
+func TestDelegationExportHandler_AbortsStartedStream(t *testing.T) {
+	t.Parallel()
+
+	useCase := mocks.NewDelegationExportUseCase(t)
+	useCase.EXPECT().ExportDelegations(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, _ delegationlist.Input, fn func(delegationlist.DelegationData) error) error {
+			if err := fn(delegationlist.DelegationData{}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		},
+	)
+
+	w := httptest.NewRecorder()
+
+	assert.PanicsWithValue(
+		t, http.ErrAbortHandler, func() {
+			NewDelegationExportHandler(useCase).ServeHTTP(w, httptest.NewRequest("GET", "/xtz/delegations/export", nil))
+		},
+	)
+	assert.Equal(t, `attachment; filename="delegations.csv"`, w.Header().Get("Content-Disposition"))
+}
@@ -0,0 +1,57 @@
+package delegation
+
+import (
+	"context"
+	"net/http"
+
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	"kiln-exercice/pkg/http/api"
+)
+
+type DelegationGetUseCase interface {
+	GetDelegations(ctx context.Context, input delegationget.Input) (delegationget.Output, error)
+}
+
+type DelegationGetHandler struct {
+	useCase DelegationGetUseCase
+	v2      bool
+}
+
+func NewDelegationGetHandler(useCase DelegationGetUseCase) *DelegationGetHandler {
+	return &DelegationGetHandler{
+		useCase: useCase,
+	}
+}
+
+// NewDelegationGetHandlerV2 returns a handler getting delegations in their v2 representation.
+func NewDelegationGetHandlerV2(useCase DelegationGetUseCase) *DelegationGetHandler {
+	return &DelegationGetHandler{
+		useCase: useCase,
+		v2:      true,
+	}
+}
+
+func (h *DelegationGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *DelegationGetHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var currencies []string
+	if h.v2 {
+		var err error
+		if currencies, err = quoteFromQuery(r.URL.Query()); err != nil {
+			return err
+		}
+	}
+
+	delegations, err := h.useCase.GetDelegations(r.Context(), delegationget.Input{Hash: r.PathValue("hash")})
+	if err != nil {
+		return err
+	}
+
+	if h.v2 {
+		return api.JSONResponse(w, http.StatusOK, delegationsV2(delegations, currencies, delegationV2FromGet))
+	}
+
+	return api.JSONResponse(w, http.StatusOK, delegations)
+}
@@ -0,0 +1,101 @@
+package delegation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegation/mocks"
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegationGetHandler(t *testing.T) {
+	t.Parallel()
+
+	const hash = "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b"
+
+	type env struct {
+		useCase *mocks.DelegationGetUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/delegations/"+hash, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					delegationget.Output{
+						{
+							ID:          12,
+							OperationID: 1098907648,
+							Hash:        hash,
+							Timestamp:   time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:      decimal.RequireFromString("25079312620"),
+							Delegator:   "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Baker:       "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Level:       "109",
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+					"id": 12,
+					"operation_id": 1098907648,
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+					"timestamp": "2018-06-30T19:30:27Z",
+					"amount": "25079312620",
+					"delegator": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					"baker": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					"level": "109"
+				}
+			  ]
+			}`,
+		},
+		{
+			name: "not found",
+			r:    httptest.NewRequest("GET", "/xtz/delegations/"+hash, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					nil, api.NewError(api.NotFound, "no delegation found for hash "+hash, nil),
+				)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"data": {"message": "no delegation found for hash ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegationGetUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /xtz/delegations/{hash}", &DelegationGetHandler{useCase: e.useCase})
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
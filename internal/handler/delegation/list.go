@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strconv"
+	"net/url"
+	"strings"
 
 	delegationlist "kiln-exercice/internal/usecase/delegation/list"
 	"kiln-exercice/pkg/http/api"
@@ -16,6 +17,7 @@ type DelegationUseCase interface {
 
 type DelegationListHandler struct {
 	useCase DelegationUseCase
+	v2      bool
 }
 
 func NewDelegationHandler(useCase DelegationUseCase) *DelegationListHandler {
@@ -24,6 +26,14 @@ func NewDelegationHandler(useCase DelegationUseCase) *DelegationListHandler {
 	}
 }
 
+// NewDelegationHandlerV2 returns a handler listing delegations in their v2 representation.
+func NewDelegationHandlerV2(useCase DelegationUseCase) *DelegationListHandler {
+	return &DelegationListHandler{
+		useCase: useCase,
+		v2:      true,
+	}
+}
+
 func (h *DelegationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	api.Handle(h.Handle)(w, r)
 }
@@ -35,12 +45,8 @@ func (h *DelegationListHandler) Handle(w http.ResponseWriter, r *http.Request) e
 		err   error
 	)
 
-	yearQuery := r.URL.Query().Get("year")
-	if yearQuery != "" {
-		input.Year, err = strconv.Atoi(yearQuery)
-		if err != nil {
-			return api.BadRequestError(fmt.Sprintf("invalid year format: %s", yearQuery), err)
-		}
+	if err = filterFromQuery(r.URL.Query(), &input); err != nil {
+		return err
 	}
 
 	pagination, err := api.PaginationFromRequest(r)
@@ -50,10 +56,68 @@ func (h *DelegationListHandler) Handle(w http.ResponseWriter, r *http.Request) e
 
 	input.Pagination = pagination
 
-	delegations, err := h.useCase.ListDelegations(ctx, input)
+	input.Sort, err = api.SortFromRequest(r, delegationlist.SortFields...)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Sort error: %s.", err.Error()))
+	}
+
+	encoder, err := api.EncoderFromRequest(r)
+	if err != nil {
+		return api.BadRequestError(fmt.Sprintf("Format error: %s.", err.Error()))
+	}
+
+	var currencies []string
+	if h.v2 {
+		if currencies, err = quoteFromQuery(r.URL.Query()); err != nil {
+			return err
+		}
+	}
+
+	out, err := h.useCase.ListDelegations(ctx, input)
 	if err != nil {
 		return err
 	}
 
-	return api.JSONResponse(w, http.StatusOK, delegations)
+	var data any = out.Delegations
+	if h.v2 {
+		data = delegationsV2(out.Delegations, currencies, delegationV2FromList)
+	}
+
+	return api.PageResponse(
+		w, r, encoder, http.StatusOK, data,
+		api.NewPage(input.Pagination, out.TotalCount, out.TotalCountEstimated, out.NextCursor),
+	)
+}
+
+// filterFromQuery parses the delegation filters of a query into the input.
+func filterFromQuery(query url.Values, input *delegationlist.Input) (err error) {
+	if input.Year, err = api.IntFromQuery(query, "year"); err != nil {
+		return err
+	}
+
+	if input.From, err = api.TimeFromQuery(query, "from"); err != nil {
+		return err
+	}
+
+	if input.To, err = api.TimeFromQuery(query, "to"); err != nil {
+		return err
+	}
+
+	if input.MinLevel, err = api.IntFromQuery(query, "min_level"); err != nil {
+		return err
+	}
+
+	if input.MaxLevel, err = api.IntFromQuery(query, "max_level"); err != nil {
+		return err
+	}
+
+	for _, value := range query["delegator"] {
+		for _, delegator := range strings.Split(value, ",") {
+			if delegator = strings.TrimSpace(delegator); delegator != "" {
+				input.Delegators = append(input.Delegators, delegator)
+			}
+		}
+	}
+
+	return nil
 }
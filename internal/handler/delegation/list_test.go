@@ -45,21 +45,31 @@ func TestDelegationListHandler(t *testing.T) {
 			init: func(e *env) {
 				e.useCase.EXPECT().ListDelegations(
 					mock.Anything,
-					delegationlist.Input{Year: 2021, Pagination: api.Pagination{PageNumber: 1, PageSize: 10}},
+					delegationlist.Input{
+						Year:       2021,
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 10},
+					},
 				).Return(
 					delegationlist.Output{
-						{
-							Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
-							Amount:    decimal.RequireFromString("125896"),
-							Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-							Level:     "2338084",
-						},
-						{
-							Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
-							Amount:    decimal.RequireFromString("9856354"),
-							Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
-							Level:     "1461334",
+						Delegations: []delegationlist.DelegationData{
+							{
+								Hash:      "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+								Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+								Amount:    decimal.RequireFromString("125896"),
+								Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+								Level:     "2338084",
+							},
+							{
+								Hash:      "ooedoJWn6fFXaiCkNDftRVCvEbJ855M7fD7gzHryL6x6FXdejP4",
+								Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+								Amount:    decimal.RequireFromString("9856354"),
+								Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+								Level:     "1461334",
+							},
 						},
+						NextCursor: "next",
+						TotalCount: 2,
 					}, nil,
 				)
 			},
@@ -68,21 +78,289 @@ func TestDelegationListHandler(t *testing.T) {
 			{
 			  "data": [ 
 				{
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
 					"timestamp": "2022-05-05T06:29:14Z",
 					"amount": "125896",
 					"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
 					"level": "2338084"
 				},
 				{
+					"hash": "ooedoJWn6fFXaiCkNDftRVCvEbJ855M7fD7gzHryL6x6FXdejP4",
 					"timestamp": "2021-05-07T14:48:07Z",
 					"amount": "9856354",
 					"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
 					"level": "1461334"
 				}
-			  ]
+			  ],
+			  "pagination": {
+				"page_size": 10,
+				"page_number": 1,
+				"total_count": 2,
+				"total_count_estimated": false,
+				"has_more": true,
+				"next_cursor": "next",
+				"next": "/?page=1&page_number=2&page_size=10&year=2021",
+				"first": "/?page=1&page_size=10&year=2021"
+			  }
 			}`,
 			wantErr: false,
 		},
+		{
+			name: "filter by delegators",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(
+					"GET",
+					"/?delegator=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL,KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf&delegator=tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+					nil,
+				),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Delegators: []string{
+							"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+							"KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+							"tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						},
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 100},
+					},
+				).Return(delegationlist.Output{Delegations: []delegationlist.DelegationData{}}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"data": [], "pagination": {"page_size": 100, "page_number": 1, "total_count": 0, "total_count_estimated": false, "has_more": false, "first": "/?delegator=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL%2CKT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf&delegator=tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "invalid delegator",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?delegator=tz1abc", nil),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Delegators: []string{"tz1abc"},
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 100},
+					},
+				).Return(
+					delegationlist.Output{},
+					api.NewError(api.InvalidArgument, "invalid delegator: tz1abc", nil),
+				)
+			},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid delegator: tz1abc"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "filter by ranges",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(
+					"GET", "/?from=2021-01-01T00:00:00Z&to=2021-02-01T00:00:00%2B01:00&min_level=100&max_level=200", nil,
+				),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						From:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						To:         time.Date(2021, 2, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+						MinLevel:   100,
+						MaxLevel:   200,
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 100},
+					},
+				).Return(delegationlist.Output{Delegations: []delegationlist.DelegationData{}}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"data": [], "pagination": {"page_size": 100, "page_number": 1, "total_count": 0, "total_count_estimated": false, "has_more": false, "first": "/?from=2021-01-01T00%3A00%3A00Z&max_level=200&min_level=100&to=2021-02-01T00%3A00%3A00%2B01%3A00"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "invalid from",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?from=2021-01-01", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid from format: 2021-01-01, expected RFC 3339"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "invalid min_level",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?min_level=abc", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid min_level format: abc"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "sort by amount",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?sort=amount&order=ASC", nil),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Sort:       api.Sort{Field: "amount", Order: "asc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 100},
+					},
+				).Return(delegationlist.Output{Delegations: []delegationlist.DelegationData{}}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"data": [], "pagination": {"page_size": 100, "page_number": 1, "total_count": 0, "total_count_estimated": false, "has_more": false, "first": "/?order=ASC&sort=amount"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "invalid sort",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?sort=delegator", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Sort error: sort must be one of timestamp, amount, level."}}`,
+			wantErr:  false,
+		},
+		{
+			name: "invalid order",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?order=up", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Sort error: order must be one of asc, desc."}}`,
+			wantErr:  false,
+		},
+		{
+			name: "with cursor",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?cursor=abc&page_size=10", nil),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 10, Cursor: "abc"},
+					},
+				).Return(delegationlist.Output{Delegations: []delegationlist.DelegationData{}}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"data": [], "pagination": {"page_size": 10, "has_more": false, "first": "/?page_size=10"}}`,
+			wantErr:  false,
+		},
+		{
+			name: "cursor with page number",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?cursor=abc&page_number=2", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Pagination error: page_number and cursor are mutually exclusive."}}`,
+			wantErr:  false,
+		},
+		{
+			name: "page size too large",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?page_size=1001", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Pagination error: page_size must be at most 1000."}}`,
+			wantErr:  false,
+		},
+		{
+			name: "csv",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?page_size=1", nil)
+					r.Header.Set("Accept", "application/json;q=0.5, text/csv")
+					return r
+				}(),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 1},
+					},
+				).Return(
+					delegationlist.Output{
+						Delegations: []delegationlist.DelegationData{
+							{
+								Hash:      "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+								Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+								Amount:    decimal.RequireFromString("1.25E+5"),
+								Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+								Level:     "2338084",
+							},
+						},
+						TotalCount: 1,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "hash,timestamp,amount,delegator,level\n" +
+				"ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b,2022-05-05T06:29:14Z,125000,tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL,2338084\n",
+			wantErr: false,
+		},
+		{
+			name: "ndjson format",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?format=ndjson", nil),
+			},
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDelegations(
+					mock.Anything,
+					delegationlist.Input{
+						Sort:       api.Sort{Field: "timestamp", Order: "desc"},
+						Pagination: api.Pagination{PageNumber: 1, PageSize: 100},
+					},
+				).Return(
+					delegationlist.Output{
+						Delegations: []delegationlist.DelegationData{
+							{Hash: "hash_1", Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC), Amount: decimal.RequireFromString("1")},
+							{Hash: "hash_2", Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC), Amount: decimal.RequireFromString("2")},
+						},
+						TotalCount: 2,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"hash":"hash_1","timestamp":"2022-05-05T06:29:14Z","amount":"1","delegator":"","level":""}` + "\n" +
+				`{"hash":"hash_2","timestamp":"2022-05-05T06:29:14Z","amount":"2","delegator":"","level":""}` + "\n",
+			wantErr: false,
+		},
+		{
+			name: "invalid format",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest("GET", "/?format=xml", nil),
+			},
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "Format error: format must be one of csv, json, ndjson."}}`,
+			wantErr:  false,
+		},
 		{
 			name: "invalid year",
 			args: args{
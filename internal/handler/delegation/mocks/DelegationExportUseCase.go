@@ -0,0 +1,86 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	list "kiln-exercice/internal/usecase/delegation/list"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationExportUseCase is an autogenerated mock type for the DelegationExportUseCase type
+type DelegationExportUseCase struct {
+	mock.Mock
+}
+
+type DelegationExportUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationExportUseCase) EXPECT() *DelegationExportUseCase_Expecter {
+	return &DelegationExportUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ExportDelegations provides a mock function with given fields: ctx, input, fn
+func (_m *DelegationExportUseCase) ExportDelegations(ctx context.Context, input list.Input, fn func(list.DelegationData) error) error {
+	ret := _m.Called(ctx, input, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDelegations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input, func(list.DelegationData) error) error); ok {
+		r0 = rf(ctx, input, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DelegationExportUseCase_ExportDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportDelegations'
+type DelegationExportUseCase_ExportDelegations_Call struct {
+	*mock.Call
+}
+
+// ExportDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input list.Input
+//   - fn func(list.DelegationData) error
+func (_e *DelegationExportUseCase_Expecter) ExportDelegations(ctx interface{}, input interface{}, fn interface{}) *DelegationExportUseCase_ExportDelegations_Call {
+	return &DelegationExportUseCase_ExportDelegations_Call{Call: _e.mock.On("ExportDelegations", ctx, input, fn)}
+}
+
+func (_c *DelegationExportUseCase_ExportDelegations_Call) Run(run func(ctx context.Context, input list.Input, fn func(list.DelegationData) error)) *DelegationExportUseCase_ExportDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(list.Input), args[2].(func(list.DelegationData) error))
+	})
+	return _c
+}
+
+func (_c *DelegationExportUseCase_ExportDelegations_Call) Return(_a0 error) *DelegationExportUseCase_ExportDelegations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DelegationExportUseCase_ExportDelegations_Call) RunAndReturn(run func(context.Context, list.Input, func(list.DelegationData) error) error) *DelegationExportUseCase_ExportDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationExportUseCase creates a new instance of DelegationExportUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationExportUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationExportUseCase {
+	mock := &DelegationExportUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
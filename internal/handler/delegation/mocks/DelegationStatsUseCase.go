@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	stats "kiln-exercice/internal/usecase/delegation/stats"
+)
+
+// DelegationStatsUseCase is an autogenerated mock type for the DelegationStatsUseCase type
+type DelegationStatsUseCase struct {
+	mock.Mock
+}
+
+type DelegationStatsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationStatsUseCase) EXPECT() *DelegationStatsUseCase_Expecter {
+	return &DelegationStatsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetStats provides a mock function with given fields: ctx, input
+func (_m *DelegationStatsUseCase) GetStats(ctx context.Context, input stats.Input) ([]stats.BucketData, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 []stats.BucketData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Input) ([]stats.BucketData, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Input) []stats.BucketData); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stats.BucketData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, stats.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationStatsUseCase_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type DelegationStatsUseCase_GetStats_Call struct {
+	*mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input stats.Input
+func (_e *DelegationStatsUseCase_Expecter) GetStats(ctx interface{}, input interface{}) *DelegationStatsUseCase_GetStats_Call {
+	return &DelegationStatsUseCase_GetStats_Call{Call: _e.mock.On("GetStats", ctx, input)}
+}
+
+func (_c *DelegationStatsUseCase_GetStats_Call) Run(run func(ctx context.Context, input stats.Input)) *DelegationStatsUseCase_GetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(stats.Input))
+	})
+	return _c
+}
+
+func (_c *DelegationStatsUseCase_GetStats_Call) Return(_a0 []stats.BucketData, _a1 error) *DelegationStatsUseCase_GetStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationStatsUseCase_GetStats_Call) RunAndReturn(run func(context.Context, stats.Input) ([]stats.BucketData, error)) *DelegationStatsUseCase_GetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationStatsUseCase creates a new instance of DelegationStatsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationStatsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationStatsUseCase {
+	mock := &DelegationStatsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	list "kiln-exercice/internal/usecase/delegation/list"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationStreamUseCase is an autogenerated mock type for the DelegationStreamUseCase type
+type DelegationStreamUseCase struct {
+	mock.Mock
+}
+
+type DelegationStreamUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationStreamUseCase) EXPECT() *DelegationStreamUseCase_Expecter {
+	return &DelegationStreamUseCase_Expecter{mock: &_m.Mock}
+}
+
+// StreamDelegations provides a mock function with given fields: ctx, input, fn
+func (_m *DelegationStreamUseCase) StreamDelegations(ctx context.Context, input list.StreamInput, fn func([]list.StreamEvent) error) error {
+	ret := _m.Called(ctx, input, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamDelegations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, list.StreamInput, func([]list.StreamEvent) error) error); ok {
+		r0 = rf(ctx, input, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DelegationStreamUseCase_StreamDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamDelegations'
+type DelegationStreamUseCase_StreamDelegations_Call struct {
+	*mock.Call
+}
+
+// StreamDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input list.StreamInput
+//   - fn func([]list.StreamEvent) error
+func (_e *DelegationStreamUseCase_Expecter) StreamDelegations(ctx interface{}, input interface{}, fn interface{}) *DelegationStreamUseCase_StreamDelegations_Call {
+	return &DelegationStreamUseCase_StreamDelegations_Call{Call: _e.mock.On("StreamDelegations", ctx, input, fn)}
+}
+
+func (_c *DelegationStreamUseCase_StreamDelegations_Call) Run(run func(ctx context.Context, input list.StreamInput, fn func([]list.StreamEvent) error)) *DelegationStreamUseCase_StreamDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(list.StreamInput), args[2].(func([]list.StreamEvent) error))
+	})
+	return _c
+}
+
+func (_c *DelegationStreamUseCase_StreamDelegations_Call) Return(_a0 error) *DelegationStreamUseCase_StreamDelegations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DelegationStreamUseCase_StreamDelegations_Call) RunAndReturn(run func(context.Context, list.StreamInput, func([]list.StreamEvent) error) error) *DelegationStreamUseCase_StreamDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationStreamUseCase creates a new instance of DelegationStreamUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationStreamUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationStreamUseCase {
+	mock := &DelegationStreamUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
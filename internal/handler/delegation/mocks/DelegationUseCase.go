@@ -24,24 +24,22 @@ func (_m *DelegationUseCase) EXPECT() *DelegationUseCase_Expecter {
 }
 
 // ListDelegations provides a mock function with given fields: ctx, input
-func (_m *DelegationUseCase) ListDelegations(ctx context.Context, input list.Input) ([]list.DelegationData, error) {
+func (_m *DelegationUseCase) ListDelegations(ctx context.Context, input list.Input) (list.Output, error) {
 	ret := _m.Called(ctx, input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDelegations")
 	}
 
-	var r0 []list.DelegationData
+	var r0 list.Output
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, list.Input) ([]list.DelegationData, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) (list.Output, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, list.Input) []list.DelegationData); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, list.Input) list.Output); ok {
 		r0 = rf(ctx, input)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]list.DelegationData)
-		}
+		r0 = ret.Get(0).(list.Output)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, list.Input) error); ok {
@@ -72,12 +70,12 @@ func (_c *DelegationUseCase_ListDelegations_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *DelegationUseCase_ListDelegations_Call) Return(_a0 []list.DelegationData, _a1 error) *DelegationUseCase_ListDelegations_Call {
+func (_c *DelegationUseCase_ListDelegations_Call) Return(_a0 list.Output, _a1 error) *DelegationUseCase_ListDelegations_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DelegationUseCase_ListDelegations_Call) RunAndReturn(run func(context.Context, list.Input) ([]list.DelegationData, error)) *DelegationUseCase_ListDelegations_Call {
+func (_c *DelegationUseCase_ListDelegations_Call) RunAndReturn(run func(context.Context, list.Input) (list.Output, error)) *DelegationUseCase_ListDelegations_Call {
 	_c.Call.Return(run)
 	return _c
 }
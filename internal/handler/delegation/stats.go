@@ -0,0 +1,59 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	delegationstats "kiln-exercice/internal/usecase/delegation/stats"
+	"kiln-exercice/pkg/http/api"
+)
+
+type DelegationStatsUseCase interface {
+	GetStats(ctx context.Context, input delegationstats.Input) (delegationstats.Output, error)
+}
+
+type DelegationStatsHandler struct {
+	useCase DelegationStatsUseCase
+}
+
+func NewDelegationStatsHandler(useCase DelegationStatsUseCase) *DelegationStatsHandler {
+	return &DelegationStatsHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *DelegationStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *DelegationStatsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		query = r.URL.Query()
+		input = delegationstats.Input{Interval: query.Get("interval")}
+		err   error
+	)
+
+	if input.From, err = api.TimeFromQuery(query, "from"); err != nil {
+		return err
+	}
+
+	if input.To, err = api.TimeFromQuery(query, "to"); err != nil {
+		return err
+	}
+
+	switch groupBy := query.Get("group_by"); groupBy {
+	case "":
+	case "baker":
+		input.GroupByBaker = true
+	default:
+		return api.BadRequestError(fmt.Sprintf("invalid group_by: %s, must be baker", groupBy))
+	}
+
+	stats, err := h.useCase.GetStats(r.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return api.JSONResponse(w, http.StatusOK, stats)
+}
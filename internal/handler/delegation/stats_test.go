@@ -0,0 +1,107 @@
+package delegation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegation/mocks"
+	delegationstats "kiln-exercice/internal/usecase/delegation/stats"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegationStatsHandler(t *testing.T) {
+	t.Parallel()
+
+	baker := "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+
+	type env struct {
+		useCase *mocks.DelegationStatsUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r: httptest.NewRequest(
+				"GET", "/xtz/delegations/stats?interval=week&from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z&group_by=baker", nil,
+			),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetStats(
+					mock.Anything, delegationstats.Input{
+						Interval:     "week",
+						From:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						To:           time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+						GroupByBaker: true,
+					},
+				).Return(
+					delegationstats.Output{
+						{
+							Start:            time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+							Baker:            &baker,
+							Count:            3,
+							UniqueDelegators: 2,
+							SumAmount:        decimal.RequireFromString("600"),
+							AvgAmount:        decimal.RequireFromString("200"),
+							MedianAmount:     decimal.RequireFromString("150"),
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+				  "start": "2024-01-08T00:00:00Z",
+				  "baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				  "count": 3,
+				  "unique_delegators": 2,
+				  "sum_amount": "600",
+				  "avg_amount": "200",
+				  "median_amount": "150"
+				}
+			  ]
+			}`,
+		},
+		{
+			name:     "invalid group by",
+			r:        httptest.NewRequest("GET", "/xtz/delegations/stats?group_by=delegator", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid group_by: delegator, must be baker"}}`,
+		},
+		{
+			name:     "invalid from",
+			r:        httptest.NewRequest("GET", "/xtz/delegations/stats?from=yesterday", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid from format: yesterday, expected RFC 3339"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegationStatsUseCase(t),
+				}
+
+				tt.init(&e)
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, NewDelegationStatsHandler(e.useCase))
+			},
+		)
+	}
+}
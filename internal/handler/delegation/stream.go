@@ -0,0 +1,116 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/http/api"
+)
+
+const (
+	lastEventIDQueryKey = "last_event_id" // For clients which cannot set the Last-Event-ID header on the first connection.
+	delegationEvent     = "delegation"
+)
+
+type DelegationStreamUseCase interface {
+	StreamDelegations(
+		ctx context.Context, input delegationlist.StreamInput, fn func([]delegationlist.StreamEvent) error,
+	) error
+}
+
+type DelegationStreamHandler struct {
+	useCase DelegationStreamUseCase
+	v2      bool
+}
+
+func NewDelegationStreamHandler(useCase DelegationStreamUseCase) *DelegationStreamHandler {
+	return &DelegationStreamHandler{
+		useCase: useCase,
+	}
+}
+
+// NewDelegationStreamHandlerV2 returns a handler pushing delegations in their v2 representation.
+func NewDelegationStreamHandlerV2(useCase DelegationStreamUseCase) *DelegationStreamHandler {
+	return &DelegationStreamHandler{
+		useCase: useCase,
+		v2:      true,
+	}
+}
+
+func (h *DelegationStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+// Handle pushes new delegations matching the filters of the list endpoint as Server-Sent Events.
+// Event ids allow clients to resume the stream, and comments are sent as heartbeats on idle streams.
+func (h *DelegationStreamHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var input delegationlist.StreamInput
+
+	if err := filterFromQuery(r.URL.Query(), &input.Input); err != nil {
+		return err
+	}
+
+	rawID := r.Header.Get(api.LastEventIDKey)
+	if rawID == "" {
+		rawID = r.URL.Query().Get(lastEventIDQueryKey)
+	}
+
+	if rawID != "" {
+		id, err := strconv.Atoi(rawID)
+		if err != nil || id < 0 {
+			return api.BadRequestError(fmt.Sprintf("invalid %s: %s", api.LastEventIDKey, rawID), err)
+		}
+
+		input.LastEventID = &id
+	}
+
+	var currencies []string
+	if h.v2 {
+		var err error
+		if currencies, err = quoteFromQuery(r.URL.Query()); err != nil {
+			return err
+		}
+	}
+
+	var sse *api.SSE
+
+	err := h.useCase.StreamDelegations(
+		r.Context(), input, func(events []delegationlist.StreamEvent) (err error) {
+			// The stream only starts once the use case is ready, so that errors get a regular response.
+			if sse == nil {
+				if sse, err = api.NewSSE(w); err != nil {
+					return err
+				}
+			}
+
+			if len(events) == 0 {
+				return sse.Comment("heartbeat")
+			}
+
+			for _, e := range events {
+				var data any = e.Delegation
+				if h.v2 {
+					data = delegationV2FromList(e.Delegation, currencies)
+				}
+
+				if err = sse.Event(strconv.Itoa(e.ID), delegationEvent, data); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+	if err != nil && sse != nil {
+		// Headers are sent already: the client will reconnect with the last event id it received.
+		log.Ctx(r.Context()).Warn().Err(err).Msg("Delegation stream interrupted")
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,114 @@
+package delegation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegation/mocks"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegationStreamHandler(t *testing.T) {
+	t.Parallel()
+
+	lastEventID := 41
+
+	type env struct {
+		useCase *mocks.DelegationStreamUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "resume with header",
+			r: func() *http.Request {
+				r := httptest.NewRequest("GET", "/xtz/delegations/stream?delegator=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", nil)
+				r.Header.Set("Last-Event-ID", "41")
+				return r
+			}(),
+			init: func(e *env) {
+				e.useCase.EXPECT().StreamDelegations(
+					mock.Anything,
+					delegationlist.StreamInput{
+						Input:       delegationlist.Input{Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"}},
+						LastEventID: &lastEventID,
+					},
+					mock.Anything,
+				).RunAndReturn(
+					func(_ context.Context, _ delegationlist.StreamInput, fn func([]delegationlist.StreamEvent) error) error {
+						if err := fn(nil); err != nil {
+							return err
+						}
+
+						return fn(
+							[]delegationlist.StreamEvent{
+								{
+									ID: 42,
+									Delegation: delegationlist.DelegationData{
+										Hash:      "hash",
+										Timestamp: time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+										Amount:    decimal.RequireFromString("125896"),
+										Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+										Level:     "2338084",
+									},
+								},
+							},
+						)
+					},
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: ": heartbeat\n\n" +
+				"id: 42\nevent: delegation\n" +
+				`data: {"hash":"hash","timestamp":"2024-05-05T06:29:14Z","amount":"125896","delegator":"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL","level":"2338084"}` +
+				"\n\n",
+		},
+		{
+			name: "error before the stream starts",
+			r:    httptest.NewRequest("GET", "/xtz/delegations/stream?last_event_id=41", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().StreamDelegations(
+					mock.Anything, delegationlist.StreamInput{LastEventID: &lastEventID}, mock.Anything,
+				).Return(api.NewError(api.Unknown, "error getting last delegation", nil))
+			},
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"data": {"message": "error getting last delegation"}}`,
+		},
+		{
+			name:     "invalid last event id",
+			r:        httptest.NewRequest("GET", "/xtz/delegations/stream?last_event_id=abc", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid Last-Event-ID: abc"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegationStreamUseCase(t),
+				}
+
+				tt.init(&e)
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, NewDelegationStreamHandler(e.useCase))
+			},
+		)
+	}
+}
@@ -0,0 +1,166 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	delegationlist "kiln-exercice/internal/usecase/delegation/list"
+	"kiln-exercice/pkg/http/api"
+)
+
+// tezDecimals is the number of decimal places of an amount in tez.
+const tezDecimals = 6
+
+// delegationV2 is a delegation as represented by the v2 API, with typed fields and explicit units.
+type delegationV2 struct {
+	Hash        string          `json:"hash"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Level       int             `json:"level"`
+	AmountMutez int64           `json:"amount_mutez"`
+	AmountTez   decimal.Decimal `json:"amount_tez"` // A decimal string, so that no precision is lost.
+	AmountFiat  fiatAmounts     `json:"amount_fiat,omitempty"`
+	Delegator   string          `json:"delegator"`
+	Baker       *string         `json:"baker"` // Null for undelegations, and when unknown.
+	Kind        string          `json:"kind"`  // model.KindDelegation, model.KindUndelegation or model.KindUnknown.
+}
+
+// fiatAmount is the value of a delegated amount in a currency at the time of the delegation.
+type fiatAmount struct {
+	Currency string
+	Amount   *decimal.Decimal // Nil when the price of the currency is unknown.
+}
+
+// fiatAmounts are represented as a JSON object, and in CSV as "usd=1.5;eur=1.4", in the requested order.
+type fiatAmounts []fiatAmount
+
+func (a fiatAmounts) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, f := range a {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		currency, err := json.Marshal(f.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := json.Marshal(f.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(currency)
+		buf.WriteByte(':')
+		buf.Write(amount)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func (a fiatAmounts) MarshalText() ([]byte, error) {
+	values := make([]string, len(a))
+
+	for i, f := range a {
+		values[i] = f.Currency + "="
+		if f.Amount != nil {
+			values[i] += f.Amount.String()
+		}
+	}
+
+	return []byte(strings.Join(values, ";")), nil
+}
+
+func newDelegationV2(
+	hash string, timestamp time.Time, height int, amount decimal.Decimal, delegator, baker string, bakerUnknown bool,
+	quote model.Quote, currencies []string,
+) delegationV2 {
+	d := delegationV2{
+		Hash:        hash,
+		Timestamp:   timestamp,
+		Level:       height,
+		AmountMutez: amount.IntPart(),
+		AmountTez:   amount.Shift(-tezDecimals),
+		Delegator:   delegator,
+		Kind:        model.KindUndelegation,
+	}
+
+	switch {
+	case bakerUnknown:
+		d.Kind = model.KindUnknown
+	case baker != "":
+		d.Baker = &baker
+		d.Kind = model.KindDelegation
+	}
+
+	for _, currency := range currencies {
+		f := fiatAmount{Currency: currency}
+
+		if price, ok := quote[currency]; ok {
+			value := d.AmountTez.Mul(price)
+			f.Amount = &value
+		}
+
+		d.AmountFiat = append(d.AmountFiat, f)
+	}
+
+	return d
+}
+
+func delegationV2FromList(d delegationlist.DelegationData, currencies []string) delegationV2 {
+	return newDelegationV2(d.Hash, d.Timestamp, d.Height, d.Amount, d.Delegator, d.Baker, d.BakerUnknown, d.Quote, currencies)
+}
+
+func delegationV2FromGet(d delegationget.DelegationData, currencies []string) delegationV2 {
+	return newDelegationV2(d.Hash, d.Timestamp, d.Height, d.Amount, d.Delegator, d.Baker, d.BakerUnknown, d.Quote, currencies)
+}
+
+// delegationsV2 converts delegations to their v2 representation.
+func delegationsV2[T any](delegations []T, currencies []string, convert func(T, []string) delegationV2) []delegationV2 {
+	out := make([]delegationV2, len(delegations))
+
+	for i, d := range delegations {
+		out[i] = convert(d, currencies)
+	}
+
+	return out
+}
+
+// quoteFromQuery returns the currencies of the quote query parameter, repeated or comma separated, in order and
+// without duplicates.
+func quoteFromQuery(query url.Values) ([]string, error) {
+	var currencies []string
+
+	for _, value := range query["quote"] {
+		for _, currency := range strings.Split(value, ",") {
+			currency = strings.TrimSpace(currency)
+			if currency == "" || slices.Contains(currencies, currency) {
+				continue
+			}
+
+			if !slices.Contains(model.Currencies, currency) {
+				return nil, api.BadRequestError(
+					fmt.Sprintf("invalid quote: %s, must be among %s", currency, strings.Join(model.Currencies, ", ")),
+				)
+			}
+
+			currencies = append(currencies, currency)
+		}
+	}
+
+	return currencies, nil
+}
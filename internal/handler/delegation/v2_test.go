@@ -0,0 +1,187 @@
+package delegation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegation/mocks"
+	"kiln-exercice/internal/model"
+	delegationget "kiln-exercice/internal/usecase/delegation/get"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegationGetHandlerV2(t *testing.T) {
+	t.Parallel()
+
+	const hash = "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b"
+
+	type env struct {
+		useCase *mocks.DelegationGetUseCase
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "delegation and undelegation",
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					delegationget.Output{
+						{
+							Hash:      hash,
+							Timestamp: time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:    decimal.RequireFromString("25079312620"),
+							Delegator: "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Baker:     "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb",
+							Level:     "109",
+							Height:    109,
+						},
+						{
+							Hash:      hash,
+							Timestamp: time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:    decimal.RequireFromString("5"),
+							Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+							Level:     "109",
+							Height:    109,
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+					"timestamp": "2018-06-30T19:30:27Z",
+					"level": 109,
+					"amount_mutez": 25079312620,
+					"amount_tez": "25079.31262",
+					"delegator": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					"baker": "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb",
+					"kind": "delegation"
+				},
+				{
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+					"timestamp": "2018-06-30T19:30:27Z",
+					"level": 109,
+					"amount_mutez": 5,
+					"amount_tez": "0.000005",
+					"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					"baker": null,
+					"kind": "undelegation"
+				}
+			  ]
+			}`,
+		},
+		{
+			name: "unknown baker",
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					delegationget.Output{
+						{
+							Hash:         hash,
+							Timestamp:    time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:       decimal.RequireFromString("5"),
+							Delegator:    "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+							BakerUnknown: true,
+							Level:        "109",
+							Height:       109,
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+					"timestamp": "2018-06-30T19:30:27Z",
+					"level": 109,
+					"amount_mutez": 5,
+					"amount_tez": "0.000005",
+					"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					"baker": null,
+					"kind": "unknown"
+				}
+			  ]
+			}`,
+		},
+		{
+			name:  "fiat amounts",
+			query: "?quote=usd,eur&quote=jpy,usd",
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegations(mock.Anything, delegationget.Input{Hash: hash}).Return(
+					delegationget.Output{
+						{
+							Hash:      hash,
+							Timestamp: time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:    decimal.RequireFromString("2500000"),
+							Delegator: "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Baker:     "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb",
+							Level:     "109",
+							Height:    109,
+							Quote: model.Quote{
+								"usd": decimal.RequireFromString("1.79"),
+								"eur": decimal.RequireFromString("1.53"),
+							},
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+					"hash": "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
+					"timestamp": "2018-06-30T19:30:27Z",
+					"level": 109,
+					"amount_mutez": 2500000,
+					"amount_tez": "2.5",
+					"amount_fiat": {"usd": "4.475", "eur": "3.825", "jpy": null},
+					"delegator": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					"baker": "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb",
+					"kind": "delegation"
+				}
+			  ]
+			}`,
+		},
+		{
+			name:     "invalid quote",
+			query:    "?quote=usd,xyz",
+			init:     func(*env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid quote: xyz, must be among btc, eur, usd, cny, jpy, krw, eth, gbp"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegationGetUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /v2/xtz/delegations/{hash}", NewDelegationGetHandlerV2(e.useCase))
+
+				apitest.TestHandler(t, httptest.NewRequest("GET", "/v2/xtz/delegations/"+hash+tt.query, nil), tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
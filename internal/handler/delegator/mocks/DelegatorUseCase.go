@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	profile "kiln-exercice/internal/usecase/delegator/profile"
+)
+
+// DelegatorUseCase is an autogenerated mock type for the DelegatorUseCase type
+type DelegatorUseCase struct {
+	mock.Mock
+}
+
+type DelegatorUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegatorUseCase) EXPECT() *DelegatorUseCase_Expecter {
+	return &DelegatorUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetDelegator provides a mock function with given fields: ctx, input
+func (_m *DelegatorUseCase) GetDelegator(ctx context.Context, input profile.Input) (profile.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegator")
+	}
+
+	var r0 profile.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, profile.Input) (profile.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, profile.Input) profile.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(profile.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, profile.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegatorUseCase_GetDelegator_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelegator'
+type DelegatorUseCase_GetDelegator_Call struct {
+	*mock.Call
+}
+
+// GetDelegator is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input profile.Input
+func (_e *DelegatorUseCase_Expecter) GetDelegator(ctx interface{}, input interface{}) *DelegatorUseCase_GetDelegator_Call {
+	return &DelegatorUseCase_GetDelegator_Call{Call: _e.mock.On("GetDelegator", ctx, input)}
+}
+
+func (_c *DelegatorUseCase_GetDelegator_Call) Run(run func(ctx context.Context, input profile.Input)) *DelegatorUseCase_GetDelegator_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(profile.Input))
+	})
+	return _c
+}
+
+func (_c *DelegatorUseCase_GetDelegator_Call) Return(_a0 profile.Output, _a1 error) *DelegatorUseCase_GetDelegator_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegatorUseCase_GetDelegator_Call) RunAndReturn(run func(context.Context, profile.Input) (profile.Output, error)) *DelegatorUseCase_GetDelegator_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegatorUseCase creates a new instance of DelegatorUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegatorUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegatorUseCase {
+	mock := &DelegatorUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+package delegator
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	delegatorprofile "kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/http/api"
+)
+
+type DelegatorUseCase interface {
+	GetDelegator(ctx context.Context, input delegatorprofile.Input) (delegatorprofile.Output, error)
+}
+
+// profileResponse is the profile of a delegator, linking to its whole history when the profile truncates it.
+type profileResponse struct {
+	delegatorprofile.Output
+	FullHistory string `json:"full_history,omitempty"`
+}
+
+type DelegatorProfileHandler struct {
+	useCase DelegatorUseCase
+}
+
+func NewDelegatorProfileHandler(useCase DelegatorUseCase) *DelegatorProfileHandler {
+	return &DelegatorProfileHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *DelegatorProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *DelegatorProfileHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	profile, err := h.useCase.GetDelegator(r.Context(), delegatorprofile.Input{Address: r.PathValue("address")})
+	if err != nil {
+		return err
+	}
+
+	res := profileResponse{Output: profile}
+	if profile.HistoryTruncated {
+		res.FullHistory = "/xtz/delegations?" + url.Values{"delegator": {profile.Address}}.Encode()
+	}
+
+	return api.JSONResponse(w, http.StatusOK, res)
+}
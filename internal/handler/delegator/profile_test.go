@@ -0,0 +1,151 @@
+//go:generate mockery
+package delegator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/delegator/mocks"
+	delegatorprofile "kiln-exercice/internal/usecase/delegator/profile"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestDelegatorProfileHandler(t *testing.T) {
+	t.Parallel()
+
+	const address = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+
+	type env struct {
+		useCase *mocks.DelegatorUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/delegators/"+address, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegator(mock.Anything, delegatorprofile.Input{Address: address}).Return(
+					delegatorprofile.Output{
+						Address:           address,
+						CurrentBaker:      "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						FirstDelegationAt: time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						LastDelegationAt:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						DelegatedBalance:  decimal.RequireFromString("125896"),
+						DelegationCount:   1,
+						Delegations: []delegatorprofile.DelegationData{
+							{
+								Hash:      "hash",
+								Timestamp: time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+								Amount:    decimal.RequireFromString("125896"),
+								Baker:     "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+								Level:     "2338084",
+							},
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": {
+				"address": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+				"current_baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				"current_baker_unknown": false,
+				"first_delegation_at": "2024-05-05T06:29:14Z",
+				"history_truncated": false,
+				"last_delegation_at": "2024-05-05T06:29:14Z",
+				"baker_switches": 0,
+				"delegated_balance": "125896",
+				"delegation_count": 1,
+				"delegations": [
+				  {
+					"hash": "hash",
+					"timestamp": "2024-05-05T06:29:14Z",
+					"amount": "125896",
+					"baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+					"baker_unknown": false,
+					"level": "2338084"
+				  }
+				]
+			  }
+			}`,
+		},
+		{
+			name: "truncated history",
+			r:    httptest.NewRequest("GET", "/xtz/delegators/"+address, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegator(mock.Anything, delegatorprofile.Input{Address: address}).Return(
+					delegatorprofile.Output{
+						Address:           address,
+						FirstDelegationAt: time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						LastDelegationAt:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						DelegatedBalance:  decimal.RequireFromString("125896"),
+						DelegationCount:   101,
+						Delegations:       []delegatorprofile.DelegationData{},
+						HistoryTruncated:  true,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": {
+				"address": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+				"current_baker": "",
+				"current_baker_unknown": false,
+				"first_delegation_at": "2024-05-05T06:29:14Z",
+				"last_delegation_at": "2024-05-05T06:29:14Z",
+				"baker_switches": 0,
+				"delegated_balance": "125896",
+				"delegation_count": 101,
+				"delegations": [],
+				"history_truncated": true,
+				"full_history": "/xtz/delegations?delegator=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+			  }
+			}`,
+		},
+		{
+			name: "not found",
+			r:    httptest.NewRequest("GET", "/xtz/delegators/"+address, nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().GetDelegator(mock.Anything, delegatorprofile.Input{Address: address}).Return(
+					delegatorprofile.Output{},
+					api.NewError(api.NotFound, "no delegation found for delegator "+address, nil),
+				)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"data": {"message": "no delegation found for delegator tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewDelegatorUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /xtz/delegators/{address}", &DelegatorProfileHandler{useCase: e.useCase})
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
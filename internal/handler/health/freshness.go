@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/pkg/http/api"
+)
+
+type FreshnessUseCase interface {
+	GetFreshness(ctx context.Context) (freshness.Output, error)
+}
+
+type FreshnessHandler struct {
+	useCase FreshnessUseCase
+}
+
+func NewFreshnessHandler(useCase FreshnessUseCase) *FreshnessHandler {
+	return &FreshnessHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *FreshnessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *FreshnessHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	out, err := h.useCase.GetFreshness(r.Context())
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	return api.JSONResponse(w, http.StatusOK, out)
+}
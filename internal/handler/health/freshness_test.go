@@ -0,0 +1,88 @@
+//go:generate mockery
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/health/mocks"
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestFreshnessHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.FreshnessUseCase
+	}
+
+	var (
+		polledAt = time.Date(2024, 5, 8, 14, 59, 0, 0, time.UTC)
+		lag      = int64(50)
+	)
+
+	tests := []struct {
+		name     string
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			init: func(e *env) {
+				e.useCase.EXPECT().GetFreshness(mock.Anything).Return(
+					freshness.Output{
+						Head:              &freshness.HeadData{Level: 5000100, Timestamp: polledAt.Add(50 * time.Second)},
+						LastPolledAt:      &polledAt,
+						PollingLagSeconds: &lag,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": {
+				"head": {"level": 5000100, "timestamp": "2024-05-08T14:59:50Z"},
+				"last_polled_at": "2024-05-08T14:59:00Z",
+				"polling_lag_seconds": 50,
+				"latest_delegation": null,
+				"delegation_lag_seconds": null,
+				"delegation_lag_levels": null,
+				"stale": false
+			  }
+			}`,
+		},
+		{
+			name: "error",
+			init: func(e *env) {
+				e.useCase.EXPECT().GetFreshness(mock.Anything).Return(
+					freshness.Output{}, api.NewError(api.Unknown, "error getting last polling", errors.New("boom")),
+				)
+			},
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"data": {"message": "error getting last polling"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{useCase: mocks.NewFreshnessUseCase(t)}
+				tt.init(&e)
+
+				apitest.TestHandler(
+					t, httptest.NewRequest("GET", "/xtz/freshness", nil), tt.wantCode, tt.wantBody, NewFreshnessHandler(e.useCase),
+				)
+			},
+		)
+	}
+}
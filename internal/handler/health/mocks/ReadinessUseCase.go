@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	ready "kiln-exercice/internal/usecase/health/ready"
+)
+
+// ReadinessUseCase is an autogenerated mock type for the ReadinessUseCase type
+type ReadinessUseCase struct {
+	mock.Mock
+}
+
+type ReadinessUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReadinessUseCase) EXPECT() *ReadinessUseCase_Expecter {
+	return &ReadinessUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CheckReadiness provides a mock function with given fields: ctx
+func (_m *ReadinessUseCase) CheckReadiness(ctx context.Context) ready.Output {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReadiness")
+	}
+
+	var r0 ready.Output
+	if rf, ok := ret.Get(0).(func(context.Context) ready.Output); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(ready.Output)
+	}
+
+	return r0
+}
+
+// ReadinessUseCase_CheckReadiness_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckReadiness'
+type ReadinessUseCase_CheckReadiness_Call struct {
+	*mock.Call
+}
+
+// CheckReadiness is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ReadinessUseCase_Expecter) CheckReadiness(ctx interface{}) *ReadinessUseCase_CheckReadiness_Call {
+	return &ReadinessUseCase_CheckReadiness_Call{Call: _e.mock.On("CheckReadiness", ctx)}
+}
+
+func (_c *ReadinessUseCase_CheckReadiness_Call) Run(run func(ctx context.Context)) *ReadinessUseCase_CheckReadiness_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ReadinessUseCase_CheckReadiness_Call) Return(_a0 ready.Output) *ReadinessUseCase_CheckReadiness_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReadinessUseCase_CheckReadiness_Call) RunAndReturn(run func(context.Context) ready.Output) *ReadinessUseCase_CheckReadiness_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReadinessUseCase creates a new instance of ReadinessUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReadinessUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReadinessUseCase {
+	mock := &ReadinessUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"kiln-exercice/internal/usecase/health/ready"
+	"kiln-exercice/pkg/http/api"
+)
+
+type ReadinessUseCase interface {
+	CheckReadiness(ctx context.Context) ready.Output
+}
+
+type ReadinessHandler struct {
+	useCase ReadinessUseCase
+}
+
+func NewReadinessHandler(useCase ReadinessUseCase) *ReadinessHandler {
+	return &ReadinessHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+// Handle answers a 200 when the API is ready, and a 503 listing the failed checks otherwise.
+func (h *ReadinessHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	out := h.useCase.CheckReadiness(r.Context())
+
+	status := http.StatusOK
+	if !out.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	return api.JSONResponse(w, status, out)
+}
@@ -0,0 +1,79 @@
+//go:generate mockery
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/health/mocks"
+	"kiln-exercice/internal/usecase/health/ready"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.ReadinessUseCase
+	}
+
+	tests := []struct {
+		name     string
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "ready",
+			init: func(e *env) {
+				e.useCase.EXPECT().CheckReadiness(mock.Anything).Return(
+					ready.Output{Ready: true, Checks: []ready.CheckData{{Name: ready.CheckDatabase, OK: true}}},
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"data": {"ready": true, "checks": [{"name": "database", "ok": true}]}}`,
+		},
+		{
+			name: "not ready",
+			init: func(e *env) {
+				e.useCase.EXPECT().CheckReadiness(mock.Anything).Return(
+					ready.Output{
+						Checks: []ready.CheckData{
+							{Name: ready.CheckDatabase, OK: true},
+							{Name: ready.CheckFreshness, Message: "never polled"},
+						},
+					},
+				)
+			},
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: `
+			{
+			  "data": {
+				"ready": false,
+				"checks": [
+				  {"name": "database", "ok": true},
+				  {"name": "freshness", "ok": false, "message": "never polled"}
+				]
+			  }
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{useCase: mocks.NewReadinessUseCase(t)}
+				tt.init(&e)
+
+				apitest.TestHandler(
+					t, httptest.NewRequest("GET", "/readyz", nil), tt.wantCode, tt.wantBody, NewReadinessHandler(e.useCase),
+				)
+			},
+		)
+	}
+}
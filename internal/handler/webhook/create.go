@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	webhookcreate "kiln-exercice/internal/usecase/webhook/create"
+	"kiln-exercice/pkg/http/api"
+)
+
+type WebhookCreateUseCase interface {
+	CreateWebhook(ctx context.Context, input webhookcreate.Input) (webhookcreate.Output, error)
+}
+
+type WebhookCreateHandler struct {
+	useCase WebhookCreateUseCase
+}
+
+func NewWebhookCreateHandler(useCase WebhookCreateUseCase) *WebhookCreateHandler {
+	return &WebhookCreateHandler{
+		useCase: useCase,
+	}
+}
+
+type createRequest struct {
+	URL       string          `json:"url"`
+	Delegator string          `json:"delegator"`
+	Baker     string          `json:"baker"`
+	MinAmount decimal.Decimal `json:"min_amount"`
+	Kind      string          `json:"kind"`
+}
+
+func (h *WebhookCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *WebhookCreateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var req createRequest
+	if err := api.JSONFromBody(w, r, &req); err != nil {
+		return err
+	}
+
+	webhook, err := h.useCase.CreateWebhook(
+		r.Context(), webhookcreate.Input{
+			URL:       req.URL,
+			Delegator: req.Delegator,
+			Baker:     req.Baker,
+			MinAmount: req.MinAmount,
+			Kind:      req.Kind,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", "/xtz/webhooks/"+strconv.Itoa(webhook.ID))
+
+	return api.JSONResponse(w, http.StatusCreated, webhook)
+}
+
+// idFromPath parses the webhook id of the request path.
+func idFromPath(r *http.Request) (int, error) {
+	raw := r.PathValue("id")
+
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, api.BadRequestError("invalid id format: "+raw, err)
+	}
+
+	return id, nil
+}
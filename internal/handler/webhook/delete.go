@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	webhookdelete "kiln-exercice/internal/usecase/webhook/delete"
+	"kiln-exercice/pkg/http/api"
+)
+
+type WebhookDeleteUseCase interface {
+	DeleteWebhook(ctx context.Context, input webhookdelete.Input) error
+}
+
+type WebhookDeleteHandler struct {
+	useCase WebhookDeleteUseCase
+}
+
+func NewWebhookDeleteHandler(useCase WebhookDeleteUseCase) *WebhookDeleteHandler {
+	return &WebhookDeleteHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *WebhookDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *WebhookDeleteHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	id, err := idFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	if err = h.useCase.DeleteWebhook(r.Context(), webhookdelete.Input{ID: id}); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	webhookdeliveries "kiln-exercice/internal/usecase/webhook/deliveries"
+	"kiln-exercice/pkg/http/api"
+)
+
+type WebhookDeliveriesUseCase interface {
+	ListDeliveries(ctx context.Context, input webhookdeliveries.Input) (webhookdeliveries.Output, error)
+}
+
+type WebhookDeliveriesHandler struct {
+	useCase WebhookDeliveriesUseCase
+}
+
+func NewWebhookDeliveriesHandler(useCase WebhookDeliveriesUseCase) *WebhookDeliveriesHandler {
+	return &WebhookDeliveriesHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *WebhookDeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.Handle(h.Handle)(w, r)
+}
+
+func (h *WebhookDeliveriesHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		input webhookdeliveries.Input
+		err   error
+	)
+
+	if input.WebhookID, err = idFromPath(r); err != nil {
+		return err
+	}
+
+	if input.Limit, err = api.IntFromQuery(r.URL.Query(), "limit"); err != nil {
+		return err
+	}
+
+	out, err := h.useCase.ListDeliveries(r.Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return api.JSONResponse(w, http.StatusOK, out.Attempts)
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	create "kiln-exercice/internal/usecase/webhook/create"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookCreateUseCase is an autogenerated mock type for the WebhookCreateUseCase type
+type WebhookCreateUseCase struct {
+	mock.Mock
+}
+
+type WebhookCreateUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookCreateUseCase) EXPECT() *WebhookCreateUseCase_Expecter {
+	return &WebhookCreateUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, input
+func (_m *WebhookCreateUseCase) CreateWebhook(ctx context.Context, input create.Input) (create.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 create.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, create.Input) (create.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, create.Input) create.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(create.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, create.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookCreateUseCase_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type WebhookCreateUseCase_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input create.Input
+func (_e *WebhookCreateUseCase_Expecter) CreateWebhook(ctx interface{}, input interface{}) *WebhookCreateUseCase_CreateWebhook_Call {
+	return &WebhookCreateUseCase_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, input)}
+}
+
+func (_c *WebhookCreateUseCase_CreateWebhook_Call) Run(run func(ctx context.Context, input create.Input)) *WebhookCreateUseCase_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(create.Input))
+	})
+	return _c
+}
+
+func (_c *WebhookCreateUseCase_CreateWebhook_Call) Return(_a0 create.Output, _a1 error) *WebhookCreateUseCase_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookCreateUseCase_CreateWebhook_Call) RunAndReturn(run func(context.Context, create.Input) (create.Output, error)) *WebhookCreateUseCase_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookCreateUseCase creates a new instance of WebhookCreateUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookCreateUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookCreateUseCase {
+	mock := &WebhookCreateUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	delete "kiln-exercice/internal/usecase/webhook/delete"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookDeleteUseCase is an autogenerated mock type for the WebhookDeleteUseCase type
+type WebhookDeleteUseCase struct {
+	mock.Mock
+}
+
+type WebhookDeleteUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookDeleteUseCase) EXPECT() *WebhookDeleteUseCase_Expecter {
+	return &WebhookDeleteUseCase_Expecter{mock: &_m.Mock}
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, input
+func (_m *WebhookDeleteUseCase) DeleteWebhook(ctx context.Context, input delete.Input) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, delete.Input) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeleteUseCase_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type WebhookDeleteUseCase_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input delete.Input
+func (_e *WebhookDeleteUseCase_Expecter) DeleteWebhook(ctx interface{}, input interface{}) *WebhookDeleteUseCase_DeleteWebhook_Call {
+	return &WebhookDeleteUseCase_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, input)}
+}
+
+func (_c *WebhookDeleteUseCase_DeleteWebhook_Call) Run(run func(ctx context.Context, input delete.Input)) *WebhookDeleteUseCase_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(delete.Input))
+	})
+	return _c
+}
+
+func (_c *WebhookDeleteUseCase_DeleteWebhook_Call) Return(_a0 error) *WebhookDeleteUseCase_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeleteUseCase_DeleteWebhook_Call) RunAndReturn(run func(context.Context, delete.Input) error) *WebhookDeleteUseCase_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookDeleteUseCase creates a new instance of WebhookDeleteUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookDeleteUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookDeleteUseCase {
+	mock := &WebhookDeleteUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
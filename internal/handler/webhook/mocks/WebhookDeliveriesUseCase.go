@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	deliveries "kiln-exercice/internal/usecase/webhook/deliveries"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookDeliveriesUseCase is an autogenerated mock type for the WebhookDeliveriesUseCase type
+type WebhookDeliveriesUseCase struct {
+	mock.Mock
+}
+
+type WebhookDeliveriesUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookDeliveriesUseCase) EXPECT() *WebhookDeliveriesUseCase_Expecter {
+	return &WebhookDeliveriesUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, input
+func (_m *WebhookDeliveriesUseCase) ListDeliveries(ctx context.Context, input deliveries.Input) (deliveries.Output, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 deliveries.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, deliveries.Input) (deliveries.Output, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, deliveries.Input) deliveries.Output); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(deliveries.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, deliveries.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveriesUseCase_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type WebhookDeliveriesUseCase_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input deliveries.Input
+func (_e *WebhookDeliveriesUseCase_Expecter) ListDeliveries(ctx interface{}, input interface{}) *WebhookDeliveriesUseCase_ListDeliveries_Call {
+	return &WebhookDeliveriesUseCase_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, input)}
+}
+
+func (_c *WebhookDeliveriesUseCase_ListDeliveries_Call) Run(run func(ctx context.Context, input deliveries.Input)) *WebhookDeliveriesUseCase_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(deliveries.Input))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveriesUseCase_ListDeliveries_Call) Return(_a0 deliveries.Output, _a1 error) *WebhookDeliveriesUseCase_ListDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveriesUseCase_ListDeliveries_Call) RunAndReturn(run func(context.Context, deliveries.Input) (deliveries.Output, error)) *WebhookDeliveriesUseCase_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookDeliveriesUseCase creates a new instance of WebhookDeliveriesUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookDeliveriesUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookDeliveriesUseCase {
+	mock := &WebhookDeliveriesUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
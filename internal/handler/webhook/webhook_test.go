@@ -0,0 +1,256 @@
+//go:generate mockery
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/handler/webhook/mocks"
+	webhookcreate "kiln-exercice/internal/usecase/webhook/create"
+	webhookdelete "kiln-exercice/internal/usecase/webhook/delete"
+	webhookdeliveries "kiln-exercice/internal/usecase/webhook/deliveries"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/http/apitest"
+)
+
+const (
+	hookURL = "https://crm.example.com/hooks/delegations"
+	baker   = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+)
+
+func TestWebhookCreateHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.WebhookCreateUseCase
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         string
+		init         func(*env)
+		wantCode     int
+		wantBody     string
+		wantLocation string
+	}{
+		{
+			name: "happy path",
+			body: `{"url": "` + hookURL + `", "baker": "` + baker + `", "min_amount": "1000"}`,
+			init: func(e *env) {
+				e.useCase.EXPECT().CreateWebhook(
+					mock.Anything, webhookcreate.Input{URL: hookURL, Baker: baker, MinAmount: decimal.RequireFromString("1000")},
+				).Return(
+					webhookcreate.Output{
+						ID: 7, URL: hookURL, Secret: "s3cr3t", Baker: baker, MinAmount: decimal.RequireFromString("1000"), CreatedAt: createdAt,
+					}, nil,
+				)
+			},
+			wantCode: http.StatusCreated,
+			wantBody: `
+			{
+			  "data": {
+				"id": 7,
+				"url": "https://crm.example.com/hooks/delegations",
+				"secret": "s3cr3t",
+				"baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				"min_amount": "1000",
+				"created_at": "2024-01-01T00:00:00Z"
+			  }
+			}`,
+			wantLocation: "/xtz/webhooks/7",
+		},
+		{
+			name:     "unknown field",
+			body:     `{"url": "` + hookURL + `", "amount": "1000"}`,
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid body: json: unknown field \"amount\""}}`,
+		},
+		{
+			name:     "trailing data",
+			body:     `{"url": "` + hookURL + `"} {}`,
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid body: unexpected data after the JSON value"}}`,
+		},
+		{
+			name: "invalid input",
+			body: `{"url": "/hooks"}`,
+			init: func(e *env) {
+				e.useCase.EXPECT().CreateWebhook(mock.Anything, webhookcreate.Input{URL: "/hooks"}).Return(
+					webhookcreate.Output{}, api.NewError(api.InvalidArgument, `invalid url: "/hooks", expected an absolute http(s) url`, nil),
+				)
+			},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid url: \"/hooks\", expected an absolute http(s) url"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewWebhookCreateUseCase(t),
+				}
+
+				tt.init(&e)
+
+				w := httptest.NewRecorder()
+				NewWebhookCreateHandler(e.useCase).ServeHTTP(w, httptest.NewRequest("POST", "/xtz/webhooks", strings.NewReader(tt.body)))
+
+				assert.Equal(t, tt.wantCode, w.Code)
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			},
+		)
+	}
+}
+
+func TestWebhookDeleteHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.WebhookDeleteUseCase
+	}
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("DELETE", "/xtz/webhooks/7", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().DeleteWebhook(mock.Anything, webhookdelete.Input{ID: 7}).Return(nil)
+			},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name: "not found",
+			r:    httptest.NewRequest("DELETE", "/xtz/webhooks/7", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().DeleteWebhook(mock.Anything, webhookdelete.Input{ID: 7}).Return(
+					api.NewError(api.NotFound, "no webhook found for id 7", nil),
+				)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"data": {"message": "no webhook found for id 7"}}`,
+		},
+		{
+			name:     "invalid id",
+			r:        httptest.NewRequest("DELETE", "/xtz/webhooks/abc", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid id format: abc"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewWebhookDeleteUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("DELETE /xtz/webhooks/{id}", NewWebhookDeleteHandler(e.useCase))
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
+
+func TestWebhookDeliveriesHandler(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		useCase *mocks.WebhookDeliveriesUseCase
+	}
+
+	attemptedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		init     func(*env)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "happy path",
+			r:    httptest.NewRequest("GET", "/xtz/webhooks/7/deliveries?limit=10", nil),
+			init: func(e *env) {
+				e.useCase.EXPECT().ListDeliveries(mock.Anything, webhookdeliveries.Input{WebhookID: 7, Limit: 10}).Return(
+					webhookdeliveries.Output{
+						Attempts: []webhookdeliveries.AttemptData{
+							{
+								ID: 1, DeliveryID: 2, DelegationID: 3, Status: "pending",
+								AttemptedAt: attemptedAt, Error: "connection refused", DurationMS: 5,
+							},
+						},
+					}, nil,
+				)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `
+			{
+			  "data": [
+				{
+				  "id": 1,
+				  "delivery_id": 2,
+				  "delegation_id": 3,
+				  "status": "pending",
+				  "attempted_at": "2024-01-01T00:00:00Z",
+				  "error": "connection refused",
+				  "duration_ms": 5
+				}
+			  ]
+			}`,
+		},
+		{
+			name:     "invalid limit",
+			r:        httptest.NewRequest("GET", "/xtz/webhooks/7/deliveries?limit=ten", nil),
+			init:     func(e *env) {},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"data": {"message": "invalid limit format: ten"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{
+					useCase: mocks.NewWebhookDeliveriesUseCase(t),
+				}
+
+				tt.init(&e)
+
+				mux := http.NewServeMux()
+				mux.Handle("GET /xtz/webhooks/{id}/deliveries", NewWebhookDeliveriesHandler(e.useCase))
+
+				apitest.TestHandler(t, tt.r, tt.wantCode, tt.wantBody, mux)
+			},
+		)
+	}
+}
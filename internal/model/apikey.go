@@ -0,0 +1,42 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Scopes of an API key.
+const (
+	ScopeRead     = "read"     // Reading delegations, bakers and delegators.
+	ScopeExport   = "export"   // Exporting every delegation matching a filter.
+	ScopeWebhooks = "webhooks" // Managing webhooks.
+	ScopeAdmin    = "admin"    // Every scope, along with the usage of every key.
+)
+
+// Scopes lists the scopes an API key can be granted.
+var Scopes = []string{ScopeRead, ScopeExport, ScopeWebhooks, ScopeAdmin}
+
+// APIKey authenticates a client of the API. Only the hash of its secret is stored.
+type APIKey struct {
+	ID         int            `db:"id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"` // First characters of the secret, telling keys apart.
+	Hash       string         `db:"hash"`   // SHA-256 of the secret, hex encoded.
+	Scopes     pq.StringArray `db:"scopes"`
+	DailyQuota int            `db:"daily_quota"` // Requests per UTC day, 0 for no quota.
+	CreatedAt  time.Time      `db:"created_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+}
+
+// HasScope reports whether the key is granted a scope, admin keys being granted every scope.
+func (k APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope) || slices.Contains(k.Scopes, ScopeAdmin)
+}
+
+// APIKeyUsage is the number of requests authenticated by an API key during a UTC day.
+type APIKeyUsage struct {
+	Day      time.Time `db:"day"`
+	Requests int64     `db:"requests"`
+}
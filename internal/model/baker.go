@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Baker aggregates the delegators whose latest delegation points to a baker.
+type Baker struct {
+	Address         string          `db:"baker"`
+	DelegatorCount  int64           `db:"delegator_count"`
+	DelegatedAmount decimal.Decimal `db:"delegated_amount"` // Sum of the amounts of the current delegations.
+}
+
+// BakerPage describes which page of bakers to list.
+type BakerPage struct {
+	Sort   Sort
+	Offset int
+	Limit  int
+	After  *Baker // Keyset pagination: only bakers after this one in the sort order. Overrides Offset.
+}
+
+// BakerFlows summarizes the delegators moving to and away from a baker.
+// Redelegations to the same baker are neither inflows nor outflows.
+type BakerFlows struct {
+	InflowCount   int64           `db:"inflow_count"`
+	InflowAmount  decimal.Decimal `db:"inflow_amount"`
+	OutflowCount  int64           `db:"outflow_count"`
+	OutflowAmount decimal.Decimal `db:"outflow_amount"` // Amounts delegated to the next baker, or undelegated.
+}
@@ -6,11 +6,62 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Kinds of delegation.
+const (
+	KindDelegation   = "delegation"
+	KindUndelegation = "undelegation"
+	KindUnknown      = "unknown" // Of the delegations stored before bakers were, until polled again.
+)
+
 type Delegation struct {
-	ID        int             `db:"id"`
-	Datetime  time.Time       `db:"datetime"`
-	Amount    decimal.Decimal `db:"amount"`
-	Delegator string          `db:"delegator"`
-	Height    int             `db:"height"`
-	TxHash    string          `db:"tx_hash"`
+	ID           int             `db:"id"`
+	OperationID  int64           `db:"operation_id"`
+	Datetime     time.Time       `db:"datetime"`
+	Amount       decimal.Decimal `db:"amount"`
+	Delegator    string          `db:"delegator"`
+	Baker        string          `db:"baker"`         // Empty for undelegations.
+	BakerUnknown bool            `db:"baker_unknown"` // Whether an empty baker is unknown rather than an undelegation.
+	Height       int             `db:"height"`
+	TxHash       string          `db:"tx_hash"`
+	Quote        Quote           `db:"quote"` // Empty when polled without quotes.
+}
+
+// Kind returns KindDelegation, or KindUndelegation when the delegation has no baker, or KindUnknown when its baker is
+// unknown.
+func (d Delegation) Kind() string {
+	if d.BakerUnknown {
+		return KindUnknown
+	}
+
+	if d.Baker == "" {
+		return KindUndelegation
+	}
+
+	return KindDelegation
+}
+
+// DelegationFilter holds the criteria used to select delegations.
+// Zero values mean no filtering.
+type DelegationFilter struct {
+	Year       int
+	From       time.Time // Inclusive.
+	To         time.Time // Exclusive.
+	MinLevel   int       // Inclusive.
+	MaxLevel   int       // Inclusive.
+	Delegators []string
+}
+
+// Sort describes the ordering of a list by one of its public field names.
+// Order is either "asc" or "desc".
+type Sort struct {
+	Field string
+	Order string
+}
+
+// DelegationPage describes which page of delegations to list.
+type DelegationPage struct {
+	Sort   Sort
+	Offset int
+	Limit  int
+	After  *Delegation // Keyset pagination: only delegations after this one in the sort order. Overrides Offset.
 }
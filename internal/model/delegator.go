@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// DelegatorSummary aggregates the whole delegation history of a delegator.
+type DelegatorSummary struct {
+	Delegator         string    `db:"delegator"`
+	FirstDelegationAt time.Time `db:"first_delegation_at"`
+	DelegationCount   int       `db:"delegation_count"`
+	BakerSwitches     int       `db:"baker_switches"` // Moves from a baker to another, undelegations in between aside.
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Currencies are the currencies quoted by TzKT, as lowercase ticker symbols.
+var Currencies = []string{"btc", "eur", "usd", "cny", "jpy", "krw", "eth", "gbp"}
+
+// Quote holds the price of a tez per currency at the time of a delegation.
+// Currencies without a known price are missing.
+type Quote map[string]decimal.Decimal
+
+// Value stores a quote as a JSON object.
+func (q Quote) Value() (driver.Value, error) {
+	if q == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(map[string]decimal.Decimal(q))
+}
+
+// Scan reads a quote stored as a JSON object.
+func (q *Quote) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*q = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a quote", src)
+	}
+
+	var m map[string]decimal.Decimal
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	if len(m) == 0 {
+		m = nil
+	}
+
+	*q = m
+
+	return nil
+}
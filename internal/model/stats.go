@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DelegationStats aggregates the delegations of a time bucket, optionally for a single baker.
+type DelegationStats struct {
+	Bucket           time.Time       `db:"bucket"`
+	Baker            string          `db:"baker"` // Empty when not grouped by baker, or for undelegations.
+	Count            int64           `db:"count"`
+	UniqueDelegators int64           `db:"unique_delegators"`
+	SumAmount        decimal.Decimal `db:"sum_amount"`
+	AvgAmount        decimal.Decimal `db:"avg_amount"`
+	MedianAmount     decimal.Decimal `db:"median_amount"`
+}
+
+// DelegationStatsQuery describes how delegations are aggregated.
+type DelegationStatsQuery struct {
+	Interval     string // One of "day", "week" or "month".
+	GroupByBaker bool
+}
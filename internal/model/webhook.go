@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a webhook delivery.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead" // Given up after too many failed attempts.
+)
+
+// Webhook is a subscription to the delegations matching its filters.
+// Empty filters, and a zero minimum amount, match every delegation.
+type Webhook struct {
+	ID        int             `db:"id"`
+	URL       string          `db:"url"`
+	Secret    string          `db:"secret"` // Signs the payloads sent to the URL.
+	Delegator string          `db:"delegator"`
+	Baker     string          `db:"baker"`
+	MinAmount decimal.Decimal `db:"min_amount"`
+	Kind      string          `db:"kind"`       // KindDelegation, KindUndelegation or empty.
+	APIKeyID  int             `db:"api_key_id"` // API key that created the webhook, 0 for none.
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// WebhookDelivery is the delivery of a delegation to a webhook.
+type WebhookDelivery struct {
+	ID            int64     `db:"id"`
+	WebhookID     int       `db:"webhook_id"`
+	DelegationID  int       `db:"delegation_id"`
+	Status        string    `db:"status"`
+	Attempts      int       `db:"attempts"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// PendingDelivery is a delivery claimed for sending, along with its destination and content.
+type PendingDelivery struct {
+	WebhookDelivery
+	URL        string     `db:"url"`
+	Secret     string     `db:"secret"`
+	Delegation Delegation `db:"delegation"`
+}
+
+// WebhookDeliveryAttempt is an entry of the delivery log.
+type WebhookDeliveryAttempt struct {
+	ID           int64     `db:"id"`
+	DeliveryID   int64     `db:"delivery_id"`
+	DelegationID int       `db:"delegation_id"`
+	Status       string    `db:"status"` // Status of the delivery after the attempt.
+	AttemptedAt  time.Time `db:"attempted_at"`
+	StatusCode   int       `db:"status_code"` // 0 when no response was received.
+	Error        string    `db:"error"`
+	DurationMS   int64     `db:"duration_ms"`
+}
@@ -0,0 +1,161 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"kiln-exercice/internal/model"
+)
+
+// apiKeyColumns lists the columns selected to build a model.APIKey.
+const apiKeyColumns = `id, name, prefix, hash, scopes, daily_quota, created_at, revoked_at`
+
+type APIKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+// CreateAPIKey inserts an API key and returns it with its id and creation time.
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	const query = `
+	INSERT INTO api_key (name, prefix, hash, scopes, daily_quota)
+	VALUES (:name, :prefix, :hash, :scopes, :daily_quota)
+	RETURNING ` + apiKeyColumns
+
+	rows, err := r.db.NamedQueryContext(ctx, query, key)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return model.APIKey{}, fmt.Errorf("no API key returned: %w", rows.Err())
+	}
+
+	var created model.APIKey
+	if err = rows.StructScan(&created); err != nil {
+		return model.APIKey{}, err
+	}
+
+	return created, nil
+}
+
+// GetAPIKey returns an API key, revoked or not, or sql.ErrNoRows when it does not exist.
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_key WHERE id = $1`
+
+	var key model.APIKey
+	if err := r.db.GetContext(ctx, &key, query, id); err != nil {
+		return model.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// GetActiveAPIKeyByHash returns the API key whose secret has the hash, or sql.ErrNoRows when there is none or it is
+// revoked.
+func (r *APIKeyRepository) GetActiveAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_key WHERE hash = $1 AND revoked_at IS NULL`
+
+	var key model.APIKey
+	if err := r.db.GetContext(ctx, &key, query, hash); err != nil {
+		return model.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys returns every API key, revoked or not, by id.
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	const query = `SELECT ` + apiKeyColumns + ` FROM api_key ORDER BY id`
+
+	var keys []model.APIKey
+	if err := r.db.SelectContext(ctx, &keys, query); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RotateAPIKey replaces the secret of an API key, the previous one being invalid at once, and returns the key.
+// It returns sql.ErrNoRows when the key does not exist or is revoked.
+func (r *APIKeyRepository) RotateAPIKey(ctx context.Context, id int, prefix, hash string) (model.APIKey, error) {
+	const query = `
+	UPDATE api_key SET prefix = $2, hash = $3
+	WHERE id = $1 AND revoked_at IS NULL
+	RETURNING ` + apiKeyColumns
+
+	var key model.APIKey
+	if err := r.db.GetContext(ctx, &key, query, id, prefix, hash); err != nil {
+		return model.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey invalidates an API key for good, keeping it along with its usage. It returns sql.ErrNoRows when the
+// key does not exist or is already revoked.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id int) error {
+	const query = `UPDATE api_key SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if revoked == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// IncrementUsage counts a request of an API key during a day, unless the key already made quota requests that day,
+// in which case it returns sql.ErrNoRows. A zero quota is unlimited. It returns the requests of the day.
+func (r *APIKeyRepository) IncrementUsage(ctx context.Context, id int, day time.Time, quota int) (int64, error) {
+	const query = `
+	INSERT INTO api_key_usage AS u (api_key_id, day, requests)
+	VALUES ($1, $2, 1)
+	ON CONFLICT (api_key_id, day) DO UPDATE SET requests = u.requests + 1
+	WHERE $3 = 0 OR u.requests < $3
+	RETURNING requests`
+
+	var requests int64
+	if err := r.db.GetContext(ctx, &requests, query, id, day.Format(time.DateOnly), quota); err != nil {
+		return 0, err
+	}
+
+	return requests, nil
+}
+
+// ListUsage returns the usage of an API key from one day to another, inclusive, oldest first.
+// Days without requests are missing.
+func (r *APIKeyRepository) ListUsage(ctx context.Context, id int, from, to time.Time) ([]model.APIKeyUsage, error) {
+	const query = `
+	SELECT day, requests
+	FROM api_key_usage
+	WHERE api_key_id = $1 AND day BETWEEN $2 AND $3
+	ORDER BY day`
+
+	var usage []model.APIKeyUsage
+	err := r.db.SelectContext(ctx, &usage, query, id, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,82 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/pgtest"
+)
+
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c := pgtest.NewPostgresContainer(ctx, t)
+	repo := NewAPIKeyRepository(c.GetDB())
+
+	const (
+		hashA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		hashB = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+
+	created, err := repo.CreateAPIKey(
+		ctx, model.APIKey{Name: "crm", Prefix: "kiln_aaaaaaaa", Hash: hashA, Scopes: []string{model.ScopeRead}, DailyQuota: 2},
+	)
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Nil(t, created.RevokedAt)
+
+	got, err := repo.GetActiveAPIKeyByHash(ctx, hashA)
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	// The quota is enforced per day.
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for want := int64(1); want <= 2; want++ {
+		requests, err := repo.IncrementUsage(ctx, created.ID, day, created.DailyQuota)
+		require.NoError(t, err)
+		assert.Equal(t, want, requests)
+	}
+
+	_, err = repo.IncrementUsage(ctx, created.ID, day, created.DailyQuota)
+	require.ErrorIs(t, err, sql.ErrNoRows, "over quota")
+
+	_, err = repo.IncrementUsage(ctx, created.ID, day.AddDate(0, 0, 2), created.DailyQuota)
+	require.NoError(t, err)
+
+	usage, err := repo.ListUsage(ctx, created.ID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.True(t, day.Equal(usage[0].Day))
+	assert.Equal(t, int64(2), usage[0].Requests)
+
+	rotated, err := repo.RotateAPIKey(ctx, created.ID, "kiln_bbbbbbbb", hashB)
+	require.NoError(t, err)
+	assert.Equal(t, "kiln_bbbbbbbb", rotated.Prefix)
+
+	_, err = repo.GetActiveAPIKeyByHash(ctx, hashA)
+	require.ErrorIs(t, err, sql.ErrNoRows, "previous secret")
+
+	require.NoError(t, repo.RevokeAPIKey(ctx, created.ID))
+	require.ErrorIs(t, repo.RevokeAPIKey(ctx, created.ID), sql.ErrNoRows, "already revoked")
+
+	_, err = repo.GetActiveAPIKeyByHash(ctx, hashB)
+	require.ErrorIs(t, err, sql.ErrNoRows, "revoked")
+
+	_, err = repo.RotateAPIKey(ctx, created.ID, "kiln_aaaaaaaa", hashA)
+	require.ErrorIs(t, err, sql.ErrNoRows, "revoked")
+
+	keys, err := repo.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].RevokedAt)
+}
@@ -0,0 +1,223 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/cache"
+	"kiln-exercice/pkg/pg"
+)
+
+// currentDelegationCTE selects the latest delegation of every delegator, the one deciding its current baker.
+const currentDelegationCTE = `
+	current_delegation AS (
+		SELECT DISTINCT ON (delegator) delegator, baker, amount
+		FROM delegation
+		ORDER BY delegator, datetime DESC, operation_id DESC
+	)`
+
+// bakerLeaderboardQuery aggregates the current delegations per baker. Undelegated delegators are left out.
+const bakerLeaderboardQuery = `
+	WITH ` + currentDelegationCTE + `
+	SELECT baker, COUNT(*) AS delegator_count, SUM(amount) AS delegated_amount
+	FROM current_delegation
+	WHERE baker IS NOT NULL
+	GROUP BY baker`
+
+// bakerSortColumns whitelists the columns bakers can be sorted by.
+var bakerSortColumns = pg.SortColumns{
+	"delegators": "delegator_count",
+	"amount":     "delegated_amount",
+}
+
+type BakerRepository struct {
+	options
+	db *sqlx.DB
+}
+
+func NewBakerRepository(db *sqlx.DB, opts ...Option) *BakerRepository {
+	return &BakerRepository{
+		options: newOptions(opts),
+		db:      db,
+	}
+}
+
+// ListBakers returns a page of bakers with at least one current delegator.
+// Bakers are sorted by delegator count in descending order by default.
+func (r *BakerRepository) ListBakers(ctx context.Context, page model.BakerPage) ([]model.Baker, error) {
+	return cache.Load(
+		ctx, r.cache, cache.Key("list_bakers", page), func(ctx context.Context) ([]model.Baker, error) {
+			return r.listBakers(ctx, page)
+		},
+	)
+}
+
+func (r *BakerRepository) listBakers(ctx context.Context, page model.BakerPage) ([]model.Baker, error) {
+	pagination, err := bakerPagination(page)
+	if err != nil {
+		return nil, err
+	}
+
+	// The leaderboard is wrapped so that the keyset predicate applies to the aggregated columns.
+	query := `SELECT baker, delegator_count, delegated_amount FROM (` + bakerLeaderboardQuery + `) AS leaderboard`
+
+	predicate, queryArgs := pagination.KeysetPredicate(1)
+	if predicate != "" {
+		query += " WHERE " + predicate
+	}
+
+	query = pagination.Embed(query)
+
+	var bakers []model.Baker
+	err = r.db.SelectContext(ctx, &bakers, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return bakers, nil
+}
+
+// CountBakers returns the number of bakers with at least one current delegator.
+func (r *BakerRepository) CountBakers(ctx context.Context) (int64, error) {
+	return cache.Load(ctx, r.cache, cache.Key("count_bakers"), r.countBakers)
+}
+
+func (r *BakerRepository) countBakers(ctx context.Context) (int64, error) {
+	const query = `
+	WITH ` + currentDelegationCTE + `
+	SELECT COUNT(DISTINCT baker) FROM current_delegation`
+
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetBaker returns the current delegators of a baker.
+// It returns sql.ErrNoRows when nobody ever delegated to the baker.
+func (r *BakerRepository) GetBaker(ctx context.Context, address string) (model.Baker, error) {
+	const query = `
+	WITH ` + currentDelegationCTE + `
+	SELECT known.baker, COUNT(c.delegator) AS delegator_count, COALESCE(SUM(c.amount), 0) AS delegated_amount
+	FROM (SELECT baker FROM delegation WHERE baker = $1 LIMIT 1) AS known
+	LEFT JOIN current_delegation AS c ON c.baker = known.baker
+	GROUP BY known.baker`
+
+	var baker model.Baker
+	if err := r.db.GetContext(ctx, &baker, query, address); err != nil {
+		return model.Baker{}, err
+	}
+
+	return baker, nil
+}
+
+// ListBakersByAddress returns the bakers among the addresses that ever received a delegation, in no particular order.
+func (r *BakerRepository) ListBakersByAddress(ctx context.Context, addresses []string) ([]model.Baker, error) {
+	const query = `
+	WITH ` + currentDelegationCTE + `
+	SELECT known.baker, COUNT(c.delegator) AS delegator_count, COALESCE(SUM(c.amount), 0) AS delegated_amount
+	FROM (SELECT DISTINCT baker FROM delegation WHERE baker = ANY($1)) AS known
+	LEFT JOIN current_delegation AS c ON c.baker = known.baker
+	GROUP BY known.baker`
+
+	var bakers []model.Baker
+	if err := r.db.SelectContext(ctx, &bakers, query, pq.Array(addresses)); err != nil {
+		return nil, err
+	}
+
+	return bakers, nil
+}
+
+// GetBakerFlows returns the delegators moving to and away from a baker during the period of the filter.
+// Only the From and To fields of the filter are used.
+func (r *BakerRepository) GetBakerFlows(
+	ctx context.Context, address string, filter model.DelegationFilter,
+) (model.BakerFlows, error) {
+	return cache.Load(
+		ctx, r.cache, cache.Key("baker_flows", address, filter.From, filter.To), func(ctx context.Context) (model.BakerFlows, error) {
+			return r.getBakerFlows(ctx, address, filter)
+		},
+	)
+}
+
+func (r *BakerRepository) getBakerFlows(
+	ctx context.Context, address string, filter model.DelegationFilter,
+) (model.BakerFlows, error) {
+	whereClauses, queryArgs := delegationWhereClauses(model.DelegationFilter{From: filter.From, To: filter.To})
+
+	queryArgs = append(queryArgs, address)
+	baker := fmt.Sprintf("$%d", len(queryArgs))
+
+	// Every delegation of a delegator that ever delegated to the baker is compared to the previous one. Delegations
+	// of an unknown baker are left out, being neither moves nor undelegations.
+	query := `
+	WITH move AS (
+		SELECT datetime, amount, baker,
+			LAG(baker) OVER (PARTITION BY delegator ORDER BY datetime, operation_id) AS previous_baker
+		FROM delegation
+		WHERE delegator IN (SELECT delegator FROM delegation WHERE baker = ` + baker + `) AND NOT baker_unknown
+	)
+	SELECT
+		COUNT(*) FILTER (WHERE inflow) AS inflow_count,
+		COALESCE(SUM(amount) FILTER (WHERE inflow), 0) AS inflow_amount,
+		COUNT(*) FILTER (WHERE outflow) AS outflow_count,
+		COALESCE(SUM(amount) FILTER (WHERE outflow), 0) AS outflow_amount
+	FROM (
+		SELECT datetime, amount,
+			baker = ` + baker + ` AND previous_baker IS DISTINCT FROM baker AS inflow,
+			previous_baker = ` + baker + ` AND baker IS DISTINCT FROM previous_baker AS outflow
+		FROM move
+	) AS flow`
+
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var flows model.BakerFlows
+	if err := r.db.GetContext(ctx, &flows, query, queryArgs...); err != nil {
+		return model.BakerFlows{}, err
+	}
+
+	return flows, nil
+}
+
+// bakerPagination converts a page of bakers into a SQL pagination.
+func bakerPagination(page model.BakerPage) (pg.Pagination, error) {
+	field := page.Sort.Field
+	if field == "" {
+		field = "delegators"
+	}
+
+	orderBy, err := bakerSortColumns.Column(field)
+	if err != nil {
+		return pg.Pagination{}, err
+	}
+
+	order, err := pg.ParseOrder(page.Sort.Order)
+	if err != nil {
+		return pg.Pagination{}, err
+	}
+
+	pagination := pg.NewPagination(page.Limit, page.Offset, orderBy).WithOrder(order).WithTieBreaker("baker")
+
+	if page.After != nil {
+		var value any
+		switch orderBy {
+		case "delegator_count":
+			value = page.After.DelegatorCount
+		case "delegated_amount":
+			value = page.After.DelegatedAmount
+		}
+
+		pagination = pagination.WithKeyset(value, page.After.Address)
+	}
+
+	return pagination, nil
+}
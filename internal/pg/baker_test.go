@@ -0,0 +1,195 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/pgtest"
+)
+
+const (
+	bakerA = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+	bakerB = "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd"
+)
+
+// initBakerDeps injects the following history:
+//   - delegator 1 delegates to A, then switches to B in February;
+//   - delegator 2 delegates to A;
+//   - delegator 3 delegates to B, then undelegates in March.
+func initBakerDeps(ctx context.Context, t *testing.T) *BakerRepository {
+	t.Helper()
+
+	c := pgtest.NewPostgresContainer(ctx, t)
+
+	pgtest.NewHelper(c.GetDB()).MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime": time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), "delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"baker": bakerA, "amount": decimal.RequireFromString("100"), "height": 1, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime": time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), "delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"baker": bakerA, "amount": decimal.RequireFromString("50"), "height": 2, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+					{
+						"datetime": time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), "delegator": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+						"baker": bakerB, "amount": decimal.RequireFromString("500"), "height": 3, "tx_hash": "tx_hash_3", "operation_id": 3,
+					},
+					{
+						"datetime": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"baker": bakerB, "amount": decimal.RequireFromString("120"), "height": 4, "tx_hash": "tx_hash_4", "operation_id": 4,
+					},
+					{
+						"datetime": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "delegator": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+						"amount": decimal.RequireFromString("510"), "height": 5, "tx_hash": "tx_hash_5", "operation_id": 5,
+					},
+				},
+			},
+		},
+	)
+
+	return NewBakerRepository(c.GetDB())
+}
+
+func TestListBakers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	repo := initBakerDeps(ctx, t)
+
+	tests := []struct {
+		name string
+		page model.BakerPage
+		want []model.Baker
+	}{
+		{
+			name: "by delegators",
+			page: model.BakerPage{Limit: 10},
+			want: []model.Baker{
+				// Both bakers have a single current delegator, the tie breaker orders them.
+				{Address: bakerB, DelegatorCount: 1, DelegatedAmount: decimal.RequireFromString("120")},
+				{Address: bakerA, DelegatorCount: 1, DelegatedAmount: decimal.RequireFromString("50")},
+			},
+		},
+		{
+			name: "by amount after keyset",
+			page: model.BakerPage{
+				Sort:  model.Sort{Field: "amount", Order: "desc"},
+				Limit: 10,
+				After: &model.Baker{Address: bakerB, DelegatedAmount: decimal.RequireFromString("120")},
+			},
+			want: []model.Baker{
+				{Address: bakerA, DelegatorCount: 1, DelegatedAmount: decimal.RequireFromString("50")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := repo.ListBakers(ctx, tt.page)
+				require.NoError(t, err)
+				require.Len(t, got, len(tt.want))
+
+				for i := range got {
+					assert.Equal(t, tt.want[i].Address, got[i].Address)
+					assert.Equal(t, tt.want[i].DelegatorCount, got[i].DelegatorCount)
+					assert.True(t, tt.want[i].DelegatedAmount.Equal(got[i].DelegatedAmount), "amount %d: got %v", i, got[i].DelegatedAmount)
+				}
+			},
+		)
+	}
+
+	count, err := repo.CountBakers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestGetBakerFlows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	repo := initBakerDeps(ctx, t)
+
+	baker, err := repo.GetBaker(ctx, bakerB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), baker.DelegatorCount)
+
+	_, err = repo.GetBaker(ctx, "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	tests := []struct {
+		name   string
+		filter model.DelegationFilter
+		want   model.BakerFlows
+	}{
+		{
+			name: "all time",
+			want: model.BakerFlows{
+				InflowCount: 2, InflowAmount: decimal.RequireFromString("620"),
+				OutflowCount: 1, OutflowAmount: decimal.RequireFromString("510"),
+			},
+		},
+		{
+			name:   "february",
+			filter: model.DelegationFilter{From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			want: model.BakerFlows{
+				InflowCount: 1, InflowAmount: decimal.RequireFromString("120"),
+				OutflowAmount: decimal.Zero,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := repo.GetBakerFlows(ctx, bakerB, tt.filter)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.want.InflowCount, got.InflowCount)
+				assert.True(t, tt.want.InflowAmount.Equal(got.InflowAmount), "inflow amount: got %v", got.InflowAmount)
+				assert.Equal(t, tt.want.OutflowCount, got.OutflowCount)
+				assert.True(t, tt.want.OutflowAmount.Equal(got.OutflowAmount), "outflow amount: got %v", got.OutflowAmount)
+			},
+		)
+	}
+}
+
+func TestListBakersByAddress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	repo := initBakerDeps(ctx, t)
+
+	// Baker A lost delegator 1 to B, but still has delegator 2. The last address never received a delegation.
+	got, err := repo.ListBakersByAddress(ctx, []string{bakerA, bakerB, "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	byAddress := make(map[string]model.Baker)
+	for _, b := range got {
+		byAddress[b.Address] = b
+	}
+
+	assert.Equal(t, int64(1), byAddress[bakerA].DelegatorCount)
+	assert.True(t, decimal.RequireFromString("50").Equal(byAddress[bakerA].DelegatedAmount))
+	assert.Equal(t, int64(1), byAddress[bakerB].DelegatorCount)
+	assert.True(t, decimal.RequireFromString("120").Equal(byAddress[bakerB].DelegatedAmount))
+}
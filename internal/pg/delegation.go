@@ -4,35 +4,98 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/cache"
 	"kiln-exercice/pkg/pg"
 )
 
+// DelegationChannel is the notification channel signaled whenever new delegations are committed.
+const DelegationChannel = "delegation_inserted"
+
+// delegationColumns lists the columns selected to build a model.Delegation.
+const delegationColumns = `id, operation_id, datetime, amount, delegator, COALESCE(baker, '') AS baker, baker_unknown,
+	height, tx_hash, quote`
+
+// fillUnknownBakersQuery fills the bakers of the delegations of an unknown baker polled again. Those stored before
+// operation ids were, one per operation group, get the operation id of the delegation of their delegator.
+const fillUnknownBakersQuery = `
+	UPDATE delegation AS d
+	SET operation_id = polled.operation_id, baker = NULLIF(polled.baker, ''), baker_unknown = false
+	FROM unnest($1::BIGINT[], $2::TEXT[], $3::TEXT[], $4::TEXT[]) AS polled (operation_id, tx_hash, delegator, baker)
+	WHERE d.baker_unknown AND d.tx_hash = polled.tx_hash AND d.delegator = polled.delegator
+		AND (d.operation_id = polled.operation_id OR d.operation_id < 0)
+	`
+
+// queueWebhookDeliveriesQuery writes to the outbox a delivery per webhook matching a delegation inserted after an id.
+const queueWebhookDeliveriesQuery = `
+	INSERT INTO webhook_delivery (webhook_id, delegation_id)
+	SELECT w.id, d.id
+	FROM delegation d
+	JOIN webhook w ON (w.delegator IS NULL OR w.delegator = d.delegator)
+		AND (w.baker IS NULL OR w.baker = d.baker)
+		AND d.amount >= w.min_amount
+		AND (w.kind IS NULL OR w.kind = CASE WHEN d.baker IS NULL THEN '` + model.KindUndelegation + `' ELSE '` + model.KindDelegation + `' END)
+	WHERE d.id > $1
+	ON CONFLICT (webhook_id, delegation_id) DO NOTHING
+	`
+
+// delegationSortColumns whitelists the columns delegations can be sorted by.
+var delegationSortColumns = pg.SortColumns{
+	"timestamp": "datetime",
+	"amount":    "amount",
+	"level":     "height",
+}
+
+// delegationStatsIntervals whitelists the date_trunc fields delegations can be bucketed by.
+var delegationStatsIntervals = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+}
+
 type DelegationRepository struct {
+	options
 	db        *sqlx.DB
 	batchSize int
 }
 
-func NewDelegationRepository(db *sqlx.DB, batchSize int) *DelegationRepository {
+func NewDelegationRepository(db *sqlx.DB, batchSize int, opts ...Option) *DelegationRepository {
 	return &DelegationRepository{
+		options:   newOptions(opts),
 		db:        db,
 		batchSize: batchSize,
 	}
 }
 
+// delegationCount is the result of CountDelegations, as cached.
+type delegationCount struct {
+	Count     int64
+	Estimated bool
+}
+
 // InsertDelegations bulk inserts a list of delegations into the database.
+// Deliveries to the matching webhooks are queued in the same transaction, and
+// listeners of DelegationChannel are notified once the delegations are committed.
 func (r *DelegationRepository) InsertDelegations(ctx context.Context, delegations []model.Delegation) error {
 	const query = `
-	INSERT INTO delegation (datetime, amount, delegator, height, tx_hash)
-	VALUES (:datetime, :amount, :delegator, :height, :tx_hash)
-	ON CONFLICT (tx_hash) DO NOTHING
+	INSERT INTO delegation (operation_id, datetime, amount, delegator, baker, height, tx_hash, quote)
+	VALUES (:operation_id, :datetime, :amount, :delegator, NULLIF(:baker, ''), :height, :tx_hash, :quote)
+	ON CONFLICT (operation_id) DO NOTHING
 	`
 
 	return pg.Tx(
 		ctx, r.db, func(tx *sqlx.Tx) error {
+			// Delegations are inserted by a single poller, so the new ones are those above the current last id.
+			var lastID int
+			if err := tx.GetContext(ctx, &lastID, `SELECT COALESCE(MAX(id), 0) FROM delegation`); err != nil {
+				return fmt.Errorf("last id: %w", err)
+			}
+
 			for i := 0; i < len(delegations); i += r.batchSize {
 				end := i + r.batchSize
 				if end > len(delegations) {
@@ -40,35 +103,129 @@ func (r *DelegationRepository) InsertDelegations(ctx context.Context, delegation
 				}
 
 				batch := delegations[i:end]
+				if err := fillUnknownBakers(ctx, tx, batch); err != nil {
+					return fmt.Errorf("fill unknown bakers: %w", err)
+				}
+
 				_, err := tx.NamedExecContext(ctx, query, batch)
 				if err != nil {
 					return fmt.Errorf("batch insert: %w", err)
 				}
 			}
+
+			if _, err := tx.ExecContext(ctx, queueWebhookDeliveriesQuery, lastID); err != nil {
+				return fmt.Errorf("queue webhook deliveries: %w", err)
+			}
+
+			// Notifications are only delivered when the transaction commits.
+			if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, '')`, DelegationChannel); err != nil {
+				return fmt.Errorf("notify: %w", err)
+			}
+
 			return nil
 		},
 	)
 }
 
-// ListDelegations returns a list of delegations paginated.
-func (r *DelegationRepository) ListDelegations(ctx context.Context, year, offset, limit int) ([]model.Delegation, error) {
+// fillUnknownBakers fills the bakers of the stored delegations of an unknown baker among the delegations, before they
+// are inserted: their conflicts are then left as they are.
+func fillUnknownBakers(ctx context.Context, tx *sqlx.Tx, delegations []model.Delegation) error {
 	var (
-		whereClauses []string
-		queryArgs    []any
+		operationIDs = make([]int64, len(delegations))
+		txHashes     = make([]string, len(delegations))
+		delegators   = make([]string, len(delegations))
+		bakers       = make([]string, len(delegations))
 	)
 
-	query := `SELECT datetime, amount, delegator, height FROM delegation`
+	for i, d := range delegations {
+		operationIDs[i], txHashes[i], delegators[i], bakers[i] = d.OperationID, d.TxHash, d.Delegator, d.Baker
+	}
+
+	_, err := tx.ExecContext(
+		ctx, fillUnknownBakersQuery,
+		pq.Array(operationIDs), pq.Array(txHashes), pq.Array(delegators), pq.Array(bakers),
+	)
+
+	return err
+}
+
+// ListDelegations returns a page of delegations matching the filter.
+// Delegations are sorted by timestamp in descending order by default.
+func (r *DelegationRepository) ListDelegations(
+	ctx context.Context, filter model.DelegationFilter, page model.DelegationPage,
+) ([]model.Delegation, error) {
+	whereClauses, queryArgs := delegationWhereClauses(filter)
+
+	query := `SELECT ` + delegationColumns + ` FROM delegation`
+
+	pagination, err := delegationPagination(page)
+	if err != nil {
+		return nil, err
+	}
 
-	if year != 0 {
-		whereClauses = append(whereClauses, "EXTRACT(YEAR FROM datetime) = $1")
-		queryArgs = append(queryArgs, year)
+	if predicate, args := pagination.KeysetPredicate(len(queryArgs) + 1); predicate != "" {
+		queryArgs = append(queryArgs, args...)
+		whereClauses = append(whereClauses, predicate)
 	}
 
 	if len(whereClauses) > 0 {
 		query += " WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	query = pg.NewPagination(limit, offset, "datetime").Embed(query)
+	query = pagination.Embed(query)
+
+	var delegations []model.Delegation
+	err = r.db.SelectContext(ctx, &delegations, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return delegations, nil
+}
+
+// StreamDelegations calls fn on every delegation matching the filter, in the order of the sort.
+// Delegations are read through a server-side cursor, so that memory does not grow with the number of delegations.
+func (r *DelegationRepository) StreamDelegations(
+	ctx context.Context, filter model.DelegationFilter, sort model.Sort, fn func(model.Delegation) error,
+) error {
+	whereClauses, queryArgs := delegationWhereClauses(filter)
+
+	query := `SELECT ` + delegationColumns + ` FROM delegation`
+
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	pagination, err := delegationPagination(model.DelegationPage{Sort: sort, Limit: pg.LimitNone})
+	if err != nil {
+		return err
+	}
+
+	return pg.Cursor(
+		ctx, r.db, r.batchSize, pagination.Embed(query), queryArgs, func(rows *sqlx.Rows) error {
+			var d model.Delegation
+			if err := rows.StructScan(&d); err != nil {
+				return err
+			}
+
+			return fn(d)
+		},
+	)
+}
+
+// ListDelegationsAfterID returns up to limit delegations matching the filter, inserted after the delegation with
+// the given id, in insertion order. Delegations are inserted by a single poller, so ids are committed in order.
+func (r *DelegationRepository) ListDelegationsAfterID(
+	ctx context.Context, filter model.DelegationFilter, afterID, limit int,
+) ([]model.Delegation, error) {
+	whereClauses, queryArgs := delegationWhereClauses(filter)
+
+	queryArgs = append(queryArgs, afterID)
+	whereClauses = append(whereClauses, fmt.Sprintf("id > $%d", len(queryArgs)))
+
+	query := pg.NewPagination(limit, 0, "id").WithOrder("ASC").Embed(
+		`SELECT ` + delegationColumns + ` FROM delegation WHERE ` + strings.Join(whereClauses, " AND "),
+	)
 
 	var delegations []model.Delegation
 	err := r.db.SelectContext(ctx, &delegations, query, queryArgs...)
@@ -78,3 +235,265 @@ func (r *DelegationRepository) ListDelegations(ctx context.Context, year, offset
 
 	return delegations, nil
 }
+
+// GetLastDelegationID returns the id of the last inserted delegation, 0 when there is none.
+func (r *DelegationRepository) GetLastDelegationID(ctx context.Context) (int, error) {
+	const query = `SELECT COALESCE(MAX(id), 0) FROM delegation`
+
+	var id int
+	if err := r.db.GetContext(ctx, &id, query); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetLatestDelegation returns the most recent delegation, or sql.ErrNoRows when there is none.
+func (r *DelegationRepository) GetLatestDelegation(ctx context.Context) (model.Delegation, error) {
+	const query = `SELECT ` + delegationColumns + ` FROM delegation ORDER BY datetime DESC, id DESC LIMIT 1`
+
+	var delegation model.Delegation
+	if err := r.db.GetContext(ctx, &delegation, query); err != nil {
+		return model.Delegation{}, err
+	}
+
+	return delegation, nil
+}
+
+// ListDelegationsByHash returns every delegation of an operation group, in operation order.
+func (r *DelegationRepository) ListDelegationsByHash(ctx context.Context, hash string) ([]model.Delegation, error) {
+	const query = `
+	SELECT ` + delegationColumns + `
+	FROM delegation
+	WHERE tx_hash = $1
+	ORDER BY operation_id`
+
+	var delegations []model.Delegation
+	err := r.db.SelectContext(ctx, &delegations, query, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return delegations, nil
+}
+
+// GetDelegatorSummaries returns the summaries of the delegators among the addresses that ever delegated, in no
+// particular order.
+func (r *DelegationRepository) GetDelegatorSummaries(ctx context.Context, delegators []string) ([]model.DelegatorSummary, error) {
+	// Undelegations are left out of the moves, so that leaving a baker then coming back is not a switch.
+	const query = `
+	WITH move AS (
+		SELECT delegator, baker,
+			LAG(baker) OVER (PARTITION BY delegator ORDER BY datetime, operation_id) AS previous_baker
+		FROM delegation
+		WHERE delegator = ANY($1) AND baker IS NOT NULL
+	), switches AS (
+		SELECT delegator, COUNT(*) FILTER (WHERE baker <> previous_baker) AS baker_switches
+		FROM move
+		GROUP BY delegator
+	)
+	SELECT d.delegator, MIN(d.datetime) AS first_delegation_at, COUNT(*) AS delegation_count,
+		COALESCE(MAX(s.baker_switches), 0) AS baker_switches
+	FROM delegation d
+	LEFT JOIN switches s ON s.delegator = d.delegator
+	WHERE d.delegator = ANY($1)
+	GROUP BY d.delegator`
+
+	var summaries []model.DelegatorSummary
+	if err := r.db.SelectContext(ctx, &summaries, query, pq.Array(delegators)); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// ListLatestDelegations returns up to limit delegations of each delegator among the addresses, grouped by delegator
+// and sorted from the latest to the oldest.
+func (r *DelegationRepository) ListLatestDelegations(ctx context.Context, delegators []string, limit int) ([]model.Delegation, error) {
+	const query = `
+	SELECT id, operation_id, datetime, amount, delegator, baker, baker_unknown, height, tx_hash, quote
+	FROM (
+		SELECT ` + delegationColumns + `,
+			ROW_NUMBER() OVER (PARTITION BY delegator ORDER BY datetime DESC, operation_id DESC) AS rank
+		FROM delegation
+		WHERE delegator = ANY($1)
+	) AS latest
+	WHERE rank <= $2
+	ORDER BY delegator, datetime DESC, operation_id DESC`
+
+	var delegations []model.Delegation
+	if err := r.db.SelectContext(ctx, &delegations, query, pq.Array(delegators), limit); err != nil {
+		return nil, err
+	}
+
+	return delegations, nil
+}
+
+// CountDelegations returns the number of delegations matching the filter.
+// Counting the whole table is slow, so without filter the count is estimated from the table statistics.
+func (r *DelegationRepository) CountDelegations(ctx context.Context, filter model.DelegationFilter) (int64, bool, error) {
+	c, err := cache.Load(
+		ctx, r.cache, cache.Key("count_delegations", filter), func(ctx context.Context) (delegationCount, error) {
+			count, estimated, err := r.countDelegations(ctx, filter)
+			return delegationCount{Count: count, Estimated: estimated}, err
+		},
+	)
+
+	return c.Count, c.Estimated, err
+}
+
+func (r *DelegationRepository) countDelegations(ctx context.Context, filter model.DelegationFilter) (count int64, estimated bool, err error) {
+	whereClauses, queryArgs := delegationWhereClauses(filter)
+
+	if len(whereClauses) == 0 {
+		const estimateQuery = `SELECT reltuples::BIGINT FROM pg_class WHERE relname = 'delegation'`
+
+		if err = r.db.GetContext(ctx, &count, estimateQuery); err != nil {
+			return 0, false, err
+		}
+
+		// reltuples is -1 until the table is analyzed for the first time.
+		if count >= 0 {
+			return count, true, nil
+		}
+	}
+
+	query := `SELECT COUNT(*) FROM delegation`
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	if err = r.db.GetContext(ctx, &count, query, queryArgs...); err != nil {
+		return 0, false, err
+	}
+
+	return count, false, nil
+}
+
+// DelegationStats aggregates the delegations matching the filter per time bucket, in chronological order.
+// Buckets are truncated in UTC, weeks starting on Monday.
+func (r *DelegationRepository) DelegationStats(
+	ctx context.Context, filter model.DelegationFilter, statsQuery model.DelegationStatsQuery,
+) ([]model.DelegationStats, error) {
+	return cache.Load(
+		ctx, r.cache, cache.Key("delegation_stats", filter, statsQuery),
+		func(ctx context.Context) ([]model.DelegationStats, error) {
+			return r.delegationStats(ctx, filter, statsQuery)
+		},
+	)
+}
+
+func (r *DelegationRepository) delegationStats(
+	ctx context.Context, filter model.DelegationFilter, statsQuery model.DelegationStatsQuery,
+) ([]model.DelegationStats, error) {
+	if !delegationStatsIntervals[statsQuery.Interval] {
+		return nil, fmt.Errorf("invalid stats interval: %q", statsQuery.Interval)
+	}
+
+	whereClauses, queryArgs := delegationWhereClauses(filter)
+
+	queryArgs = append(queryArgs, statsQuery.Interval)
+	bucket := fmt.Sprintf("date_trunc($%d, datetime, 'UTC')", len(queryArgs))
+
+	// Grouping by position, as the baker output column shadows the baker table column.
+	baker, groupBy := "''", "1"
+	if statsQuery.GroupByBaker {
+		baker, groupBy = "COALESCE(baker, '')", "1, 2"
+	}
+
+	query := `
+	SELECT ` + bucket + ` AS bucket,
+		` + baker + ` AS baker,
+		COUNT(*) AS count,
+		COUNT(DISTINCT delegator) AS unique_delegators,
+		SUM(amount) AS sum_amount,
+		ROUND(AVG(amount)) AS avg_amount,
+		ROUND(percentile_cont(0.5) WITHIN GROUP (ORDER BY amount)::NUMERIC) AS median_amount
+	FROM delegation`
+
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query += " GROUP BY " + groupBy + " ORDER BY " + groupBy
+
+	var stats []model.DelegationStats
+	err := r.db.SelectContext(ctx, &stats, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// delegationWhereClauses converts a filter into WHERE clauses and their arguments.
+// Every clause is a plain comparison on a column, so that indexes can be used.
+func delegationWhereClauses(filter model.DelegationFilter) (whereClauses []string, queryArgs []any) {
+	where := func(clause string, arg any) {
+		queryArgs = append(queryArgs, arg)
+		whereClauses = append(whereClauses, fmt.Sprintf(clause, len(queryArgs)))
+	}
+
+	if filter.Year != 0 {
+		where("datetime >= $%d", time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.UTC))
+		where("datetime < $%d", time.Date(filter.Year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	if !filter.From.IsZero() {
+		where("datetime >= $%d", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		where("datetime < $%d", filter.To)
+	}
+
+	if filter.MinLevel != 0 {
+		where("height >= $%d", filter.MinLevel)
+	}
+
+	if filter.MaxLevel != 0 {
+		where("height <= $%d", filter.MaxLevel)
+	}
+
+	if len(filter.Delegators) > 0 {
+		where("delegator = ANY($%d)", pq.Array(filter.Delegators))
+	}
+
+	return whereClauses, queryArgs
+}
+
+// delegationPagination converts a page of delegations into a SQL pagination.
+func delegationPagination(page model.DelegationPage) (pg.Pagination, error) {
+	field := page.Sort.Field
+	if field == "" {
+		field = "timestamp"
+	}
+
+	orderBy, err := delegationSortColumns.Column(field)
+	if err != nil {
+		return pg.Pagination{}, err
+	}
+
+	order, err := pg.ParseOrder(page.Sort.Order)
+	if err != nil {
+		return pg.Pagination{}, err
+	}
+
+	pagination := pg.NewPagination(page.Limit, page.Offset, orderBy).WithOrder(order).WithTieBreaker("id")
+
+	if page.After != nil {
+		var value any
+		switch orderBy {
+		case "datetime":
+			value = page.After.Datetime
+		case "amount":
+			value = page.After.Amount
+		case "height":
+			value = page.After.Height
+		}
+
+		pagination = pagination.WithKeyset(value, page.After.ID)
+	}
+
+	return pagination, nil
+}
@@ -2,6 +2,7 @@ package pg
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -45,18 +46,21 @@ func TestInsertDelegations(t *testing.T) {
 				ctx: ctx,
 				delegations: []model.Delegation{
 					{
-						Datetime:  time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
-						Amount:    decimal.RequireFromString("125896"),
-						Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-						Height:    2338084,
-						TxHash:    "tx_hash_1",
+						Datetime:    time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+						Amount:      decimal.RequireFromString("125896"),
+						Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						Baker:       "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						Height:      2338084,
+						TxHash:      "tx_hash_1",
+						OperationID: 1,
 					},
 					{
-						Datetime:  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
-						Amount:    decimal.RequireFromString("9856354"),
-						Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
-						Height:    1461334,
-						TxHash:    "tx_hash_2",
+						Datetime:    time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+						Amount:      decimal.RequireFromString("9856354"),
+						Delegator:   "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						Height:      1461334,
+						TxHash:      "tx_hash_2",
+						OperationID: 2,
 					},
 				},
 			},
@@ -68,13 +72,14 @@ func TestInsertDelegations(t *testing.T) {
 						{
 							"datetime":  time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
 							"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-							"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1",
+							"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+							"baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
 						},
 						{
 							"datetime":  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
 							"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
 							"amount":    decimal.RequireFromString("9856354"), "height": 1461334,
-							"tx_hash": "tx_hash_2",
+							"tx_hash": "tx_hash_2", "operation_id": 2, "baker": nil,
 						},
 					},
 				},
@@ -100,6 +105,76 @@ func TestInsertDelegations(t *testing.T) {
 	}
 }
 
+func TestInsertDelegations_FillsUnknownBakers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		delegatorA = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+		delegatorB = "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"
+		baker      = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+	)
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	datetime := time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC)
+
+	// Stored before operation ids, then before bakers.
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime": datetime, "delegator": delegatorA, "amount": decimal.RequireFromString("1"),
+						"height": 1461334, "tx_hash": "tx_hash_1", "operation_id": -1, "baker_unknown": true,
+					},
+					{
+						"datetime": datetime, "delegator": delegatorB, "amount": decimal.RequireFromString("2"),
+						"height": 1461334, "tx_hash": "tx_hash_2", "operation_id": 20, "baker_unknown": true,
+					},
+				},
+			},
+		},
+	)
+
+	err := repo.InsertDelegations(
+		ctx, []model.Delegation{
+			{
+				Datetime: datetime, Amount: decimal.RequireFromString("1"), Delegator: delegatorA, Baker: baker,
+				Height: 1461334, TxHash: "tx_hash_1", OperationID: 10,
+			},
+			{
+				Datetime: datetime, Amount: decimal.RequireFromString("2"), Delegator: delegatorB,
+				Height: 1461334, TxHash: "tx_hash_2", OperationID: 20,
+			},
+		},
+	)
+	assert.NoError(t, err)
+
+	h.MustCheck(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{"tx_hash": "tx_hash_1", "operation_id": 10, "baker": baker, "baker_unknown": false},
+					{"tx_hash": "tx_hash_2", "operation_id": 20, "baker": nil, "baker_unknown": false},
+				},
+			},
+			{
+				Table:     "delegation",
+				Records:   []pgtest.Record{{"operation_id": -1}},
+				IsDeleted: true,
+			},
+		},
+	)
+
+	var count int
+	assert.NoError(t, repo.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM delegation`))
+	assert.Equal(t, 2, count, "delegations polled again are not inserted twice")
+}
+
 func TestListDelegations(t *testing.T) {
 	t.Parallel()
 
@@ -115,12 +190,12 @@ func TestListDelegations(t *testing.T) {
 					{
 						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
 						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
 					},
 					{
 						"datetime":  time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
 						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
-						"amount":    decimal.RequireFromString("9856354"), "height": 1461334, "tx_hash": "tx_hash_2",
+						"amount":    decimal.RequireFromString("9856354"), "height": 1461334, "tx_hash": "tx_hash_2", "operation_id": 2,
 					},
 				},
 			},
@@ -129,9 +204,8 @@ func TestListDelegations(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		year   int
-		offset int
-		limit  int
+		filter model.DelegationFilter
+		page   model.DelegationPage
 	}
 
 	tests := []struct {
@@ -144,26 +218,286 @@ func TestListDelegations(t *testing.T) {
 			name: "list with year",
 			args: args{
 				ctx:    ctx,
-				year:   2024,
-				offset: 0,
-				limit:  10,
+				filter: model.DelegationFilter{Year: 2024},
+				page:   model.DelegationPage{Limit: 10},
+			},
+			wantErr: assert.NoError,
+			want: []model.Delegation{
+				{
+					ID:          2,
+					OperationID: 2,
+					TxHash:      "tx_hash_2",
+					Datetime:    time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
+					Amount:      decimal.RequireFromString("9856354"),
+					Delegator:   "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+					Height:      1461334,
+				},
+				{
+					ID:          1,
+					OperationID: 1,
+					TxHash:      "tx_hash_1",
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+				},
+			},
+		},
+		{
+			name: "list sorted by amount ascending",
+			args: args{
+				ctx:  ctx,
+				page: model.DelegationPage{Sort: model.Sort{Field: "amount", Order: "asc"}, Limit: 10},
+			},
+			wantErr: assert.NoError,
+			want: []model.Delegation{
+				{
+					ID:          1,
+					OperationID: 1,
+					TxHash:      "tx_hash_1",
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+				},
+				{
+					ID:          2,
+					OperationID: 2,
+					TxHash:      "tx_hash_2",
+					Datetime:    time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
+					Amount:      decimal.RequireFromString("9856354"),
+					Delegator:   "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+					Height:      1461334,
+				},
+			},
+		},
+		{
+			name: "list after keyset",
+			args: args{
+				ctx: ctx,
+				page: model.DelegationPage{
+					Limit: 10,
+					After: &model.Delegation{ID: 2, Datetime: time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC)},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []model.Delegation{
+				{
+					ID:          1,
+					OperationID: 1,
+					TxHash:      "tx_hash_1",
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+				},
+			},
+		},
+		{
+			name: "list with unknown sort",
+			args: args{
+				ctx:  ctx,
+				page: model.DelegationPage{Sort: model.Sort{Field: "delegator"}, Limit: 10},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "list with time and level ranges",
+			args: args{
+				ctx: ctx,
+				filter: model.DelegationFilter{
+					From:     time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+					To:       time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC),
+					MinLevel: 2000000,
+				},
+				page: model.DelegationPage{Limit: 10},
+			},
+			wantErr: assert.NoError,
+			want: []model.Delegation{
+				{
+					ID:          1,
+					OperationID: 1,
+					TxHash:      "tx_hash_1",
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+				},
+			},
+		},
+		{
+			name: "list with delegators",
+			args: args{
+				ctx: ctx,
+				filter: model.DelegationFilter{
+					Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"},
+				},
+				page: model.DelegationPage{Limit: 10},
+			},
+			wantErr: assert.NoError,
+			want: []model.Delegation{
+				{
+					ID:          1,
+					OperationID: 1,
+					TxHash:      "tx_hash_1",
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+				},
 			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := repo.ListDelegations(ctx, tt.args.filter, tt.args.page)
+				if !tt.wantErr(t, err, fmt.Sprintf("ListDelegations(%v)", tt.args.filter)) {
+					return
+				}
+
+				assert.Equalf(t, tt.want, got, "ListDelegations(%v)", tt.args.filter)
+			},
+		)
+	}
+}
+
+func TestCountDelegations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime":  time.Date(2023, 5, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("9856354"), "height": 1461334, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+				},
+			},
+		},
+	)
+
+	tests := []struct {
+		name          string
+		filter        model.DelegationFilter
+		want          int64
+		wantEstimated bool
+	}{
+		{
+			name:   "with filter",
+			filter: model.DelegationFilter{Year: 2024},
+			want:   1,
+		},
+		{
+			// The table has never been analyzed, so the count falls back to an exact one.
+			name: "without filter",
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, estimated, err := repo.CountDelegations(ctx, tt.filter)
+				assert.NoError(t, err)
+				assert.Equalf(t, tt.want, got, "CountDelegations(%v)", tt.filter)
+				assert.Equalf(t, tt.wantEstimated, estimated, "CountDelegations(%v)", tt.filter)
+			},
+		)
+	}
+}
+
+func TestListDelegationsByHash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 2,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("9856354"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+						"quote": `{"eur": 1.53, "usd": 1.79}`,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						"amount":    decimal.RequireFromString("1"), "height": 2338100, "tx_hash": "tx_hash_2", "operation_id": 3,
+					},
+				},
+			},
+		},
+	)
+
+	tests := []struct {
+		name    string
+		hash    string
+		want    []model.Delegation
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "operation group",
+			hash:    "tx_hash_1",
 			wantErr: assert.NoError,
 			want: []model.Delegation{
 				{
-					Datetime:  time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
-					Amount:    decimal.RequireFromString("9856354"),
-					Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
-					Height:    1461334,
+					ID:          2,
+					OperationID: 1,
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("9856354"),
+					Delegator:   "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+					Height:      2338084,
+					TxHash:      "tx_hash_1",
+					Quote: model.Quote{
+						"eur": decimal.RequireFromString("1.53"),
+						"usd": decimal.RequireFromString("1.79"),
+					},
 				},
 				{
-					Datetime:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
-					Amount:    decimal.RequireFromString("125896"),
-					Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-					Height:    2338084,
+					ID:          1,
+					OperationID: 2,
+					Datetime:    time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+					Amount:      decimal.RequireFromString("125896"),
+					Delegator:   "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+					Height:      2338084,
+					TxHash:      "tx_hash_1",
 				},
 			},
 		},
+		{
+			name:    "unknown hash",
+			hash:    "tx_hash_3",
+			wantErr: assert.NoError,
+			want:    nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -171,13 +505,370 @@ func TestListDelegations(t *testing.T) {
 			tt.name, func(t *testing.T) {
 				t.Parallel()
 
-				got, err := repo.ListDelegations(ctx, tt.args.year, tt.args.offset, tt.args.limit)
-				if !tt.wantErr(t, err, fmt.Sprintf("ListDelegations(%v)", tt.args.year)) {
+				got, err := repo.ListDelegationsByHash(ctx, tt.hash)
+				if !tt.wantErr(t, err, fmt.Sprintf("ListDelegationsByHash(%v)", tt.hash)) {
 					return
 				}
 
-				assert.Equalf(t, tt.want, got, "ListDelegations(%v)", tt.args.year)
+				assert.Equalf(t, tt.want, got, "ListDelegationsByHash(%v)", tt.hash)
 			},
 		)
 	}
 }
+
+func TestDelegationStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						"amount": decimal.RequireFromString("100"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 5, 18, 0, 0, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						"amount": decimal.RequireFromString("200"), "height": 2338900, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 5, 20, 0, 0, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("600"), "height": 2339000, "tx_hash": "tx_hash_3", "operation_id": 3,
+					},
+					{
+						"datetime":  time.Date(2024, 6, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf", "baker": "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						"amount": decimal.RequireFromString("50"), "height": 2400000, "tx_hash": "tx_hash_4", "operation_id": 4,
+					},
+				},
+			},
+		},
+	)
+
+	tests := []struct {
+		name    string
+		filter  model.DelegationFilter
+		query   model.DelegationStatsQuery
+		want    []model.DelegationStats
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "per day",
+			filter:  model.DelegationFilter{To: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+			query:   model.DelegationStatsQuery{Interval: "day"},
+			wantErr: assert.NoError,
+			want: []model.DelegationStats{
+				{
+					Bucket:           time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+					Count:            3,
+					UniqueDelegators: 2,
+					SumAmount:        decimal.RequireFromString("900"),
+					AvgAmount:        decimal.RequireFromString("300"),
+					MedianAmount:     decimal.RequireFromString("200"),
+				},
+			},
+		},
+		{
+			name:    "per month and baker",
+			query:   model.DelegationStatsQuery{Interval: "month", GroupByBaker: true},
+			wantErr: assert.NoError,
+			want: []model.DelegationStats{
+				{
+					Bucket:           time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					Count:            1,
+					UniqueDelegators: 1,
+					SumAmount:        decimal.RequireFromString("600"),
+					AvgAmount:        decimal.RequireFromString("600"),
+					MedianAmount:     decimal.RequireFromString("600"),
+				},
+				{
+					Bucket:           time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					Baker:            "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+					Count:            2,
+					UniqueDelegators: 1,
+					SumAmount:        decimal.RequireFromString("300"),
+					AvgAmount:        decimal.RequireFromString("150"),
+					MedianAmount:     decimal.RequireFromString("150"),
+				},
+				{
+					Bucket:           time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					Baker:            "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+					Count:            1,
+					UniqueDelegators: 1,
+					SumAmount:        decimal.RequireFromString("50"),
+					AvgAmount:        decimal.RequireFromString("50"),
+					MedianAmount:     decimal.RequireFromString("50"),
+				},
+			},
+		},
+		{
+			name:    "invalid interval",
+			query:   model.DelegationStatsQuery{Interval: "day'); DROP TABLE delegation; --"},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := repo.DelegationStats(ctx, tt.filter, tt.query)
+				if !tt.wantErr(t, err, fmt.Sprintf("DelegationStats(%v, %v)", tt.filter, tt.query)) {
+					return
+				}
+
+				assert.Equalf(t, len(tt.want), len(got), "DelegationStats(%v, %v)", tt.filter, tt.query)
+				for i := range got {
+					assert.True(t, tt.want[i].Bucket.Equal(got[i].Bucket), "bucket %d: got %v", i, got[i].Bucket)
+					assert.Equal(t, tt.want[i].Baker, got[i].Baker)
+					assert.Equal(t, tt.want[i].Count, got[i].Count)
+					assert.Equal(t, tt.want[i].UniqueDelegators, got[i].UniqueDelegators)
+					assert.True(t, tt.want[i].SumAmount.Equal(got[i].SumAmount), "sum %d: got %v", i, got[i].SumAmount)
+					assert.True(t, tt.want[i].AvgAmount.Equal(got[i].AvgAmount), "avg %d: got %v", i, got[i].AvgAmount)
+					assert.True(t, tt.want[i].MedianAmount.Equal(got[i].MedianAmount), "median %d: got %v", i, got[i].MedianAmount)
+				}
+			},
+		)
+	}
+}
+
+func TestStreamDelegations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The repository batch size is 1, so that every delegation is fetched from the cursor separately.
+	h, repo := initDelegationDeps(ctx, t)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("9856354"), "height": 2338100, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+					{
+						"datetime":  time.Date(2023, 5, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("1"), "height": 1461334, "tx_hash": "tx_hash_3", "operation_id": 3,
+					},
+				},
+			},
+		},
+	)
+
+	var hashes []string
+	err := repo.StreamDelegations(
+		ctx, model.DelegationFilter{Year: 2024}, model.Sort{Field: "level", Order: "asc"}, func(d model.Delegation) error {
+			hashes = append(hashes, d.TxHash)
+			return nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx_hash_1", "tx_hash_2"}, hashes)
+
+	wantErr := fmt.Errorf("stop")
+	err = repo.StreamDelegations(
+		ctx, model.DelegationFilter{}, model.Sort{}, func(model.Delegation) error {
+			return wantErr
+		},
+	)
+	assert.ErrorIs(t, err, wantErr)
+}
+
+func TestListDelegationsAfterID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	id, err := repo.GetLastDelegationID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, id)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 7, 14, 48, 7, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("9856354"), "height": 2338100, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 8, 14, 48, 7, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("1"), "height": 2338200, "tx_hash": "tx_hash_3", "operation_id": 3,
+					},
+				},
+			},
+		},
+	)
+
+	id, err = repo.GetLastDelegationID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	got, err := repo.ListDelegationsAfterID(
+		ctx, model.DelegationFilter{Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"}}, 0, 10,
+	)
+	assert.NoError(t, err)
+
+	var hashes []string
+	for _, d := range got {
+		hashes = append(hashes, d.TxHash)
+	}
+	assert.Equal(t, []string{"tx_hash_1", "tx_hash_3"}, hashes)
+
+	got, err = repo.ListDelegationsAfterID(ctx, model.DelegationFilter{}, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "tx_hash_2", got[0].TxHash)
+}
+
+func TestGetLatestDelegation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	_, err := repo.GetLatestDelegation(ctx)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	h.MustInject(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "delegation",
+				Records: []pgtest.Record{
+					{
+						"datetime":  time.Date(2024, 5, 8, 14, 48, 7, 0, time.UTC),
+						"delegator": "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						"amount":    decimal.RequireFromString("1"), "height": 2338200, "tx_hash": "tx_hash_1", "operation_id": 1,
+					},
+					{
+						"datetime":  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						"delegator": "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						"amount":    decimal.RequireFromString("125896"), "height": 2338084, "tx_hash": "tx_hash_2", "operation_id": 2,
+					},
+				},
+			},
+		},
+	)
+
+	got, err := repo.GetLatestDelegation(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tx_hash_1", got.TxHash)
+	assert.Equal(t, 2338200, got.Height)
+}
+
+func TestGetDelegatorSummaries(t *testing.T) {
+	t.Parallel()
+
+	const (
+		delegatorA = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+		delegatorB = "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"
+		bakerA     = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+		bakerB     = "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd"
+	)
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	// Delegator A: baker A, undelegation, baker A again, then baker B.
+	bakers := []any{bakerA, nil, bakerA, bakerB}
+
+	records := make([]pgtest.Record, 0, len(bakers)+1)
+	for i, baker := range bakers {
+		records = append(
+			records, pgtest.Record{
+				"datetime": time.Date(2024, 5, 1+i, 0, 0, 0, 0, time.UTC), "delegator": delegatorA, "baker": baker,
+				"amount": decimal.RequireFromString("1"), "height": 2338000 + i, "tx_hash": fmt.Sprintf("tx_hash_%d", i),
+				"operation_id": i + 1,
+			},
+		)
+	}
+
+	records = append(
+		records, pgtest.Record{
+			"datetime": time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), "delegator": delegatorB, "baker": bakerB,
+			"amount": decimal.RequireFromString("1"), "height": 2338002, "tx_hash": "tx_hash_b", "operation_id": 10,
+		},
+	)
+
+	h.MustInject(ctx, t, []pgtest.RecordSet{{Table: "delegation", Records: records}})
+
+	got, err := repo.GetDelegatorSummaries(ctx, []string{delegatorA, delegatorB, "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(
+		t, []model.DelegatorSummary{
+			{
+				Delegator: delegatorA, FirstDelegationAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				DelegationCount: 4, BakerSwitches: 1,
+			},
+			{Delegator: delegatorB, FirstDelegationAt: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), DelegationCount: 1},
+		}, got,
+	)
+}
+
+func TestListLatestDelegations(t *testing.T) {
+	t.Parallel()
+
+	const (
+		delegatorA = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+		delegatorB = "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"
+	)
+
+	ctx := context.Background()
+
+	h, repo := initDelegationDeps(ctx, t)
+
+	var records []pgtest.Record
+	for i, delegator := range []string{delegatorA, delegatorA, delegatorA, delegatorB} {
+		records = append(
+			records, pgtest.Record{
+				"datetime": time.Date(2024, 5, 1+i, 0, 0, 0, 0, time.UTC), "delegator": delegator,
+				"amount": decimal.RequireFromString("1"), "height": 2338000 + i, "tx_hash": fmt.Sprintf("tx_hash_%d", i),
+				"operation_id": i + 1,
+			},
+		)
+	}
+
+	h.MustInject(ctx, t, []pgtest.RecordSet{{Table: "delegation", Records: records}})
+
+	got, err := repo.ListLatestDelegations(ctx, []string{delegatorA, delegatorB}, 2)
+	assert.NoError(t, err)
+
+	hashes := make([]string, len(got))
+	for i, d := range got {
+		hashes[i] = d.TxHash
+	}
+
+	// Grouped by delegator, KT1 addresses sorting before tz1 ones.
+	assert.Equal(t, []string{"tx_hash_3", "tx_hash_2", "tx_hash_1"}, hashes)
+}
@@ -0,0 +1,30 @@
+package pg
+
+import (
+	"kiln-exercice/pkg/cache"
+)
+
+// Option configures a repository.
+type Option func(*options)
+
+type options struct {
+	cache *cache.Cache // Nil when aggregate queries are not cached.
+}
+
+// WithCache caches the aggregate queries of a repository: counts, statistics and leaderboards.
+// The cache must be invalidated whenever delegations are inserted.
+func WithCache(c *cache.Cache) Option {
+	return func(o *options) {
+		o.cache = c
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
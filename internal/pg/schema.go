@@ -0,0 +1,37 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SchemaVersion is the version of the schema the binaries are built for, the last one recorded by scripts/init.sql.
+const SchemaVersion = 7
+
+type SchemaRepository struct {
+	db *sqlx.DB
+}
+
+func NewSchemaRepository(db *sqlx.DB) *SchemaRepository {
+	return &SchemaRepository{
+		db: db,
+	}
+}
+
+// Ping checks that the database is reachable.
+func (r *SchemaRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// GetSchemaVersion returns the latest version of the schema applied to the database, 0 when none is recorded.
+func (r *SchemaRepository) GetSchemaVersion(ctx context.Context) (int, error) {
+	const query = `SELECT COALESCE(MAX(version), 0) FROM schema_version`
+
+	var version int
+	if err := r.db.GetContext(ctx, &version, query); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
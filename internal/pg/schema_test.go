@@ -0,0 +1,25 @@
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kiln-exercice/pkg/pgtest"
+)
+
+func TestSchemaRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c := pgtest.NewPostgresContainer(ctx, t)
+	repo := NewSchemaRepository(c.GetDB())
+
+	assert.NoError(t, repo.Ping(ctx))
+
+	version, err := repo.GetSchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion, version, "init.sql and SchemaVersion disagree")
+}
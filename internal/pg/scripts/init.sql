@@ -1,14 +1,158 @@
+-- Versions of the schema, checked by the readiness of the API. Every change of this script is a new version, recorded
+-- once its statements are applied, and SchemaVersion in schema.go is bumped along.
+CREATE TABLE IF NOT EXISTS schema_version (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 1: delegations and polls.
 CREATE TABLE IF NOT EXISTS delegation (
     id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    operation_id BIGINT NOT NULL,
     tx_hash VARCHAR(255) NOT NULL,
     amount DECIMAL NOT NULL,
     delegator VARCHAR(255) NOT NULL,
+    baker VARCHAR(255), -- NULL for undelegations.
     height BIGINT NOT NULL,
     datetime TIMESTAMPTZ NOT NULL,
-    CONSTRAINT uq_tx_hash UNIQUE (tx_hash)
+    quote JSONB NOT NULL DEFAULT '{}', -- Price of a tez per currency at the time of the delegation.
+    -- An operation group (tx_hash) can hold several delegations, each with its own TzKT operation id.
+    CONSTRAINT uq_operation_id UNIQUE (operation_id)
 );
 
+CREATE INDEX IF NOT EXISTS idx_delegation_tx_hash ON delegation (tx_hash);
+CREATE INDEX IF NOT EXISTS idx_delegation_datetime ON delegation (datetime, id);
+CREATE INDEX IF NOT EXISTS idx_delegation_amount ON delegation (amount, id);
+CREATE INDEX IF NOT EXISTS idx_delegation_height ON delegation (height, id);
+CREATE INDEX IF NOT EXISTS idx_delegation_delegator ON delegation (delegator, datetime DESC);
+
 CREATE TABLE IF NOT EXISTS polling (
     id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
     last_polled_at TIMESTAMPTZ NOT NULL
-);
\ No newline at end of file
+);
+
+INSERT INTO schema_version (version) VALUES (1) ON CONFLICT DO NOTHING;
+
+-- 2: delegations identified by their operation id.
+-- Delegations stored before operation ids were one per tx_hash: they get the opposite of their id, which TzKT never
+-- issues, since they are not polled again.
+ALTER TABLE delegation ADD COLUMN IF NOT EXISTS operation_id BIGINT;
+UPDATE delegation SET operation_id = -id WHERE operation_id IS NULL;
+ALTER TABLE delegation ALTER COLUMN operation_id SET NOT NULL;
+ALTER TABLE delegation DROP CONSTRAINT IF EXISTS uq_tx_hash;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'uq_operation_id') THEN
+        ALTER TABLE delegation ADD CONSTRAINT uq_operation_id UNIQUE (operation_id);
+    END IF;
+END
+$$;
+
+INSERT INTO schema_version (version) VALUES (2) ON CONFLICT DO NOTHING;
+
+-- 3: bakers of the delegations.
+-- Delegations stored before bakers were have none, so they read as undelegations until version 7.
+ALTER TABLE delegation ADD COLUMN IF NOT EXISTS baker VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_delegation_baker ON delegation (baker);
+
+INSERT INTO schema_version (version) VALUES (3) ON CONFLICT DO NOTHING;
+
+-- 4: webhooks.
+CREATE TABLE IF NOT EXISTS webhook (
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    -- NULL filters match every delegation.
+    delegator VARCHAR(255),
+    baker VARCHAR(255),
+    min_amount DECIMAL NOT NULL DEFAULT 0,
+    kind VARCHAR(16), -- 'delegation' or 'undelegation'.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Outbox of the deliveries to send, written in the transaction inserting the delegations.
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    webhook_id INT NOT NULL REFERENCES webhook (id) ON DELETE CASCADE,
+    delegation_id INT NOT NULL REFERENCES delegation (id),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending', -- 'pending', 'delivered' or 'dead'.
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_webhook_delivery UNIQUE (webhook_id, delegation_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_pending ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+-- Delivery log: one row per sending attempt.
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempt (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_delivery (id) ON DELETE CASCADE,
+    attempted_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(16) NOT NULL, -- Status of the delivery after the attempt.
+    status_code INT, -- NULL when no response was received.
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempt_delivery ON webhook_delivery_attempt (delivery_id, attempted_at DESC);
+
+INSERT INTO schema_version (version) VALUES (4) ON CONFLICT DO NOTHING;
+
+-- 5: quotes of the delegations.
+-- Delegations stored before quotes were have none: their value in fiat is unknown.
+ALTER TABLE delegation ADD COLUMN IF NOT EXISTS quote JSONB NOT NULL DEFAULT '{}';
+
+INSERT INTO schema_version (version) VALUES (5) ON CONFLICT DO NOTHING;
+
+-- 6: API keys.
+-- API keys, stored as the SHA-256 of their secret, which is only shown when created or rotated.
+CREATE TABLE IF NOT EXISTS api_key (
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name TEXT NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    hash CHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL, -- Among 'read', 'export', 'webhooks' and 'admin'.
+    daily_quota INT NOT NULL DEFAULT 0, -- Requests per UTC day, 0 for no quota.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ, -- NULL while the key is valid.
+    CONSTRAINT uq_api_key_hash UNIQUE (hash)
+);
+
+-- Requests authenticated by an API key, per UTC day.
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id INT NOT NULL REFERENCES api_key (id),
+    day DATE NOT NULL,
+    requests BIGINT NOT NULL,
+    PRIMARY KEY (api_key_id, day)
+);
+
+-- API key that created a webhook, the only one managing it along with admin keys. Webhooks created before keys owned
+-- them have none, so that only admin keys manage them.
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS api_key_id INT REFERENCES api_key (id);
+
+INSERT INTO schema_version (version) VALUES (6) ON CONFLICT DO NOTHING;
+
+-- 7: delegations of an unknown baker.
+-- Delegations stored before bakers were are marked as of an unknown baker, rather than undelegations, and polled again
+-- from the earliest of them, which fills their baker. Undelegations of the time are marked too, until polled again.
+ALTER TABLE delegation ADD COLUMN IF NOT EXISTS baker_unknown BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_delegation_baker_unknown ON delegation (tx_hash) WHERE baker_unknown;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM schema_version WHERE version = 7) THEN
+        UPDATE delegation SET baker_unknown = true
+        WHERE baker IS NULL AND datetime < (SELECT applied_at FROM schema_version WHERE version = 3);
+
+        UPDATE polling SET last_polled_at = earliest.datetime
+        FROM (SELECT MIN(datetime) AS datetime FROM delegation WHERE baker_unknown) AS earliest
+        WHERE polling.last_polled_at > earliest.datetime;
+    END IF;
+END
+$$;
+
+INSERT INTO schema_version (version) VALUES (7) ON CONFLICT DO NOTHING;
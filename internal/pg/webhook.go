@@ -0,0 +1,173 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/pg"
+)
+
+// webhookColumns lists the columns selected to build a model.Webhook.
+const webhookColumns = `id, url, secret, COALESCE(delegator, '') AS delegator, COALESCE(baker, '') AS baker,
+	min_amount, COALESCE(kind, '') AS kind, COALESCE(api_key_id, 0) AS api_key_id, created_at`
+
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// CreateWebhook inserts a webhook and returns it with its id and creation time.
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook model.Webhook) (model.Webhook, error) {
+	const query = `
+	INSERT INTO webhook (url, secret, delegator, baker, min_amount, kind, api_key_id)
+	VALUES (
+		:url, :secret, NULLIF(:delegator, ''), NULLIF(:baker, ''), :min_amount, NULLIF(:kind, ''), NULLIF(:api_key_id, 0)
+	)
+	RETURNING ` + webhookColumns
+
+	rows, err := r.db.NamedQueryContext(ctx, query, webhook)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return model.Webhook{}, fmt.Errorf("no webhook returned: %w", rows.Err())
+	}
+
+	var created model.Webhook
+	if err = rows.StructScan(&created); err != nil {
+		return model.Webhook{}, err
+	}
+
+	return created, nil
+}
+
+// GetWebhook returns a webhook, or sql.ErrNoRows when it does not exist.
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id int) (model.Webhook, error) {
+	const query = `SELECT ` + webhookColumns + ` FROM webhook WHERE id = $1`
+
+	var webhook model.Webhook
+	if err := r.db.GetContext(ctx, &webhook, query, id); err != nil {
+		return model.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook along with its deliveries, or returns sql.ErrNoRows when it does not exist.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id int) error {
+	const query = `DELETE FROM webhook WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListDeliveryAttempts returns the last delivery attempts of a webhook, most recent first.
+func (r *WebhookRepository) ListDeliveryAttempts(ctx context.Context, webhookID, limit int) ([]model.WebhookDeliveryAttempt, error) {
+	const query = `
+	SELECT a.id, a.delivery_id, d.delegation_id, a.status, a.attempted_at,
+		COALESCE(a.status_code, 0) AS status_code, a.error, a.duration_ms
+	FROM webhook_delivery_attempt a
+	JOIN webhook_delivery d ON d.id = a.delivery_id
+	WHERE d.webhook_id = $1
+	ORDER BY a.attempted_at DESC, a.id DESC
+	LIMIT $2`
+
+	var attempts []model.WebhookDeliveryAttempt
+	err := r.db.SelectContext(ctx, &attempts, query, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// ClaimDeliveries returns up to limit pending deliveries that are due, oldest first.
+// The claimed deliveries are leased: they are not due again before the lease expires, so that concurrent
+// dispatchers do not send them twice, and a dispatcher dying mid-delivery only delays them.
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]model.PendingDelivery, error) {
+	const query = `
+	WITH claimed AS (
+		UPDATE webhook_delivery
+		SET next_attempt_at = now() + make_interval(secs => $1)
+		WHERE id IN (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = '` + model.DeliveryPending + `' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	)
+	SELECT c.id, c.webhook_id, c.delegation_id, c.status, c.attempts, c.next_attempt_at, c.created_at,
+		w.url, w.secret,
+		d.id AS "delegation.id", d.operation_id AS "delegation.operation_id", d.datetime AS "delegation.datetime",
+		d.amount AS "delegation.amount", d.delegator AS "delegation.delegator",
+		COALESCE(d.baker, '') AS "delegation.baker", d.height AS "delegation.height", d.tx_hash AS "delegation.tx_hash"
+	FROM claimed c
+	JOIN webhook w ON w.id = c.webhook_id
+	JOIN delegation d ON d.id = c.delegation_id
+	ORDER BY c.id`
+
+	var deliveries []model.PendingDelivery
+	err := r.db.SelectContext(ctx, &deliveries, query, lease.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt logs a delivery attempt and updates the delivery with its outcome.
+func (r *WebhookRepository) RecordDeliveryAttempt(
+	ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt,
+) error {
+	const (
+		insertQuery = `
+		INSERT INTO webhook_delivery_attempt (delivery_id, attempted_at, status, status_code, error, duration_ms)
+		VALUES (:delivery_id, :attempted_at, :status, NULLIF(:status_code, 0), :error, :duration_ms)`
+		updateQuery = `
+		UPDATE webhook_delivery
+		SET status = :status, attempts = :attempts, next_attempt_at = :next_attempt_at
+		WHERE id = :id`
+	)
+
+	return pg.Tx(
+		ctx, r.db, func(tx *sqlx.Tx) error {
+			if _, err := tx.NamedExecContext(ctx, insertQuery, attempt); err != nil {
+				return fmt.Errorf("insert attempt: %w", err)
+			}
+
+			if _, err := tx.NamedExecContext(ctx, updateQuery, delivery); err != nil {
+				return fmt.Errorf("update delivery: %w", err)
+			}
+
+			return nil
+		},
+	)
+}
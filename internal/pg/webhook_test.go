@@ -0,0 +1,157 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/pgtest"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c := pgtest.NewPostgresContainer(ctx, t)
+	h := pgtest.NewHelper(c.GetDB())
+
+	webhookRepo := NewWebhookRepository(c.GetDB())
+	delegationRepo := NewDelegationRepository(c.GetDB(), 1)
+
+	const delegator = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+
+	all, err := webhookRepo.CreateWebhook(ctx, model.Webhook{URL: "https://a.example.com", Secret: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "", all.Delegator)
+	assert.Zero(t, all.APIKeyID)
+	assert.False(t, all.CreatedAt.IsZero())
+
+	bigDelegations, err := webhookRepo.CreateWebhook(
+		ctx, model.Webhook{
+			URL: "https://b.example.com", Secret: "b", Baker: bakerA, MinAmount: decimal.RequireFromString("1000"), Kind: model.KindDelegation,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, bakerA, bigDelegations.Baker)
+
+	undelegations, err := webhookRepo.CreateWebhook(
+		ctx, model.Webhook{URL: "https://c.example.com", Secret: "c", Delegator: delegator, Kind: model.KindUndelegation},
+	)
+	require.NoError(t, err)
+
+	// Batches of one delegation check that deliveries are queued for every batch of the transaction.
+	err = delegationRepo.InsertDelegations(
+		ctx, []model.Delegation{
+			{
+				Datetime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("5000"),
+				Delegator: delegator, Baker: bakerA, Height: 1, TxHash: "tx_hash_1", OperationID: 1,
+			},
+			{
+				Datetime: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("10"),
+				Delegator: delegator, Baker: bakerA, Height: 2, TxHash: "tx_hash_2", OperationID: 2,
+			},
+			{
+				Datetime: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("10"),
+				Delegator: delegator, Height: 3, TxHash: "tx_hash_3", OperationID: 3,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	h.MustCheck(
+		ctx, t, []pgtest.RecordSet{
+			{
+				Table: "webhook_delivery",
+				Records: []pgtest.Record{
+					{"webhook_id": all.ID, "delegation_id": 1, "status": model.DeliveryPending},
+					{"webhook_id": all.ID, "delegation_id": 2, "status": model.DeliveryPending},
+					{"webhook_id": all.ID, "delegation_id": 3, "status": model.DeliveryPending},
+					{"webhook_id": bigDelegations.ID, "delegation_id": 1, "status": model.DeliveryPending},
+					{"webhook_id": undelegations.ID, "delegation_id": 3, "status": model.DeliveryPending},
+				},
+			},
+			{
+				Table:     "webhook_delivery",
+				IsDeleted: true,
+				Records: []pgtest.Record{
+					{"webhook_id": bigDelegations.ID, "delegation_id": 2},
+					{"webhook_id": undelegations.ID, "delegation_id": 1},
+				},
+			},
+		},
+	)
+
+	claimed, err := webhookRepo.ClaimDeliveries(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 5)
+
+	claimedHashes := make(map[string][]string)
+	for _, d := range claimed {
+		claimedHashes[d.URL] = append(claimedHashes[d.URL], d.Delegation.TxHash)
+	}
+
+	assert.Equal(
+		t, map[string][]string{
+			"https://a.example.com": {"tx_hash_1", "tx_hash_2", "tx_hash_3"},
+			"https://b.example.com": {"tx_hash_1"},
+			"https://c.example.com": {"tx_hash_3"},
+		}, claimedHashes,
+	)
+
+	// Leased deliveries are not claimed again.
+	again, err := webhookRepo.ClaimDeliveries(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	var delivery model.WebhookDelivery
+	for _, d := range claimed {
+		if d.WebhookID == all.ID && d.DelegationID == 1 {
+			delivery = d.WebhookDelivery
+		}
+	}
+
+	delivery.Status, delivery.Attempts = model.DeliveryDelivered, 1
+
+	err = webhookRepo.RecordDeliveryAttempt(
+		ctx, delivery, model.WebhookDeliveryAttempt{
+			DeliveryID: delivery.ID, Status: model.DeliveryDelivered, AttemptedAt: time.Now(), StatusCode: 200, DurationMS: 12,
+		},
+	)
+	require.NoError(t, err)
+
+	attempts, err := webhookRepo.ListDeliveryAttempts(ctx, all.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, 1, attempts[0].DelegationID)
+	assert.Equal(t, 200, attempts[0].StatusCode)
+	assert.Equal(t, model.DeliveryDelivered, attempts[0].Status)
+
+	require.NoError(t, webhookRepo.DeleteWebhook(ctx, all.ID))
+	assert.ErrorIs(t, webhookRepo.DeleteWebhook(ctx, all.ID), sql.ErrNoRows)
+
+	_, err = webhookRepo.GetWebhook(ctx, all.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	key, err := NewAPIKeyRepository(c.GetDB()).CreateAPIKey(
+		ctx, model.APIKey{
+			Name: "crm", Prefix: "kiln_aaaaaaaa", Hash: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			Scopes: []string{model.ScopeWebhooks},
+		},
+	)
+	require.NoError(t, err)
+
+	owned, err := webhookRepo.CreateWebhook(ctx, model.Webhook{URL: "https://d.example.com", Secret: "d", APIKeyID: key.ID})
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, owned.APIKeyID)
+
+	got, err := webhookRepo.GetWebhook(ctx, owned.ID)
+	require.NoError(t, err)
+	assert.Equal(t, owned, got)
+}
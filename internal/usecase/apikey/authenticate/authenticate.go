@@ -0,0 +1,61 @@
+package authenticate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/pkg/api"
+)
+
+type APIKeyRepository interface {
+	GetActiveAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error)
+	IncrementUsage(ctx context.Context, id int, day time.Time, quota int) (int64, error)
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+	TimeNow    func() time.Time
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository, timeNow func() time.Time) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+		TimeNow:    timeNow,
+	}
+}
+
+// Authenticate checks that an API key is valid and granted the input scope, and counts the request in its usage
+// of the day, unless the key exhausted its daily quota. Admin keys are told apart, as they manage the resources of
+// every key.
+func (uc *UseCase) Authenticate(ctx context.Context, input Input) (Output, error) {
+	key, err := uc.APIKeyRepo.GetActiveAPIKeyByHash(ctx, apikey.Hash(input.Secret))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Output{}, api.NewError(api.Unauthenticated, "invalid API key", err)
+	}
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting API key", err)
+	}
+
+	if !key.HasScope(input.Scope) {
+		return Output{}, api.NewError(
+			api.PermissionDenied, fmt.Sprintf("API key %s is not granted the %s scope", key.Prefix, input.Scope), nil,
+		)
+	}
+
+	_, err = uc.APIKeyRepo.IncrementUsage(ctx, key.ID, uc.TimeNow().UTC(), key.DailyQuota)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Output{}, api.NewError(
+			api.ResourceExhausted, fmt.Sprintf("daily quota of %d requests exceeded, retry tomorrow (UTC)", key.DailyQuota), err,
+		)
+	}
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error counting API key usage", err)
+	}
+
+	return Output{ID: key.ID, Name: key.Name, Admin: key.HasScope(model.ScopeAdmin)}, nil
+}
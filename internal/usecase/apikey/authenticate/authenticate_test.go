@@ -0,0 +1,134 @@
+//go:generate mockery
+package authenticate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/internal/usecase/apikey/authenticate/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+	}
+
+	const secret = "kiln_0123456789abcdef"
+
+	var (
+		now = time.Date(2024, 1, 1, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+		day = time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)
+		key = model.APIKey{ID: 7, Name: "crm", Prefix: "kiln_01234567", Scopes: []string{model.ScopeRead}, DailyQuota: 100}
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeRead},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).Return(key, nil)
+				e.APIKeyRepo.EXPECT().IncrementUsage(context.Background(), 7, day, 100).Return(42, nil)
+			},
+			want: Output{ID: 7, Name: "crm"},
+		},
+		{
+			name:  "admin granted every scope",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeExport},
+			init: func(e *env) {
+				admin := key
+				admin.Scopes = []string{model.ScopeAdmin}
+
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).Return(admin, nil)
+				e.APIKeyRepo.EXPECT().IncrementUsage(context.Background(), 7, day, 100).Return(1, nil)
+			},
+			want: Output{ID: 7, Name: "crm", Admin: true},
+		},
+		{
+			name:  "invalid key",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeRead},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).
+					Return(model.APIKey{}, sql.ErrNoRows)
+			},
+			wantCode: api.Unauthenticated,
+		},
+		{
+			name:  "missing scope",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeWebhooks},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).Return(key, nil)
+			},
+			wantCode: api.PermissionDenied,
+		},
+		{
+			name:  "quota exceeded",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeRead},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).Return(key, nil)
+				e.APIKeyRepo.EXPECT().IncrementUsage(context.Background(), 7, day, 100).Return(0, sql.ErrNoRows)
+			},
+			wantCode: api.ResourceExhausted,
+		},
+		{
+			name:  "repository error",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Secret: secret, Scope: model.ScopeRead},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetActiveAPIKeyByHash(context.Background(), apikey.Hash(secret)).
+					Return(model.APIKey{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo, func() time.Time { return now })
+
+				got, err := uc.Authenticate(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("Authenticate() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("Authenticate() unexpected error = %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Authenticate() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
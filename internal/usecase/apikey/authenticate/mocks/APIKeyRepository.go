@@ -0,0 +1,155 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "kiln-exercice/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetActiveAPIKeyByHash provides a mock function with given fields: ctx, hash
+func (_m *APIKeyRepository) GetActiveAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveAPIKeyByHash")
+	}
+
+	var r0 model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.APIKey, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.APIKey); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(model.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetActiveAPIKeyByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveAPIKeyByHash'
+type APIKeyRepository_GetActiveAPIKeyByHash_Call struct {
+	*mock.Call
+}
+
+// GetActiveAPIKeyByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *APIKeyRepository_Expecter) GetActiveAPIKeyByHash(ctx interface{}, hash interface{}) *APIKeyRepository_GetActiveAPIKeyByHash_Call {
+	return &APIKeyRepository_GetActiveAPIKeyByHash_Call{Call: _e.mock.On("GetActiveAPIKeyByHash", ctx, hash)}
+}
+
+func (_c *APIKeyRepository_GetActiveAPIKeyByHash_Call) Run(run func(ctx context.Context, hash string)) *APIKeyRepository_GetActiveAPIKeyByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetActiveAPIKeyByHash_Call) Return(_a0 model.APIKey, _a1 error) *APIKeyRepository_GetActiveAPIKeyByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetActiveAPIKeyByHash_Call) RunAndReturn(run func(context.Context, string) (model.APIKey, error)) *APIKeyRepository_GetActiveAPIKeyByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementUsage provides a mock function with given fields: ctx, id, day, quota
+func (_m *APIKeyRepository) IncrementUsage(ctx context.Context, id int, day time.Time, quota int) (int64, error) {
+	ret := _m.Called(ctx, id, day, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementUsage")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int) (int64, error)); ok {
+		return rf(ctx, id, day, quota)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int) int64); ok {
+		r0 = rf(ctx, id, day, quota)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, int) error); ok {
+		r1 = rf(ctx, id, day, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_IncrementUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementUsage'
+type APIKeyRepository_IncrementUsage_Call struct {
+	*mock.Call
+}
+
+// IncrementUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - day time.Time
+//   - quota int
+func (_e *APIKeyRepository_Expecter) IncrementUsage(ctx interface{}, id interface{}, day interface{}, quota interface{}) *APIKeyRepository_IncrementUsage_Call {
+	return &APIKeyRepository_IncrementUsage_Call{Call: _e.mock.On("IncrementUsage", ctx, id, day, quota)}
+}
+
+func (_c *APIKeyRepository_IncrementUsage_Call) Run(run func(ctx context.Context, id int, day time.Time, quota int)) *APIKeyRepository_IncrementUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_IncrementUsage_Call) Return(_a0 int64, _a1 error) *APIKeyRepository_IncrementUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_IncrementUsage_Call) RunAndReturn(run func(context.Context, int, time.Time, int) (int64, error)) *APIKeyRepository_IncrementUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
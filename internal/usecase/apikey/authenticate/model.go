@@ -0,0 +1,12 @@
+package authenticate
+
+type Input struct {
+	Secret string
+	Scope  string
+}
+
+type Output struct {
+	ID    int
+	Name  string
+	Admin bool
+}
@@ -0,0 +1,80 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/pkg/api"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateAPIKey creates an API key granted the input scopes. The returned secret authenticates the requests of the
+// client, it cannot be retrieved afterward.
+func (uc *UseCase) CreateAPIKey(ctx context.Context, input Input) (Output, error) {
+	if err := validateInput(input); err != nil {
+		return Output{}, err
+	}
+
+	secret, err := apikey.NewSecret()
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error generating API key secret", err)
+	}
+
+	key, err := uc.APIKeyRepo.CreateAPIKey(
+		ctx, model.APIKey{
+			Name:       input.Name,
+			Prefix:     secret.Prefix,
+			Hash:       secret.Hash,
+			Scopes:     input.Scopes,
+			DailyQuota: input.DailyQuota,
+		},
+	)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error creating API key", err)
+	}
+
+	return buildOutput(key, secret.Secret), nil
+}
+
+func validateInput(input Input) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return api.NewError(api.InvalidArgument, "invalid name: must not be empty", nil)
+	}
+
+	if len(input.Scopes) == 0 {
+		return api.NewError(api.InvalidArgument, "invalid scopes: at least one scope is required", nil)
+	}
+
+	for _, scope := range input.Scopes {
+		if !slices.Contains(model.Scopes, scope) {
+			return api.NewError(
+				api.InvalidArgument,
+				fmt.Sprintf("invalid scope: %q, must be among %s", scope, strings.Join(model.Scopes, ", ")),
+				nil,
+			)
+		}
+	}
+
+	if input.DailyQuota < 0 {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid daily quota: %d must not be negative", input.DailyQuota), nil)
+	}
+
+	return nil
+}
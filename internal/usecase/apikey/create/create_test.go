@@ -0,0 +1,130 @@
+//go:generate mockery
+package create
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/internal/usecase/apikey/create/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_CreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output // Without its secret, checked against the stored hash.
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Name: "crm", Scopes: []string{model.ScopeRead, model.ScopeExport}, DailyQuota: 1000},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().CreateAPIKey(
+					context.Background(), mock.MatchedBy(
+						func(got model.APIKey) bool {
+							return got.Name == "crm" && len(got.Scopes) == 2 && got.DailyQuota == 1000 &&
+								strings.HasPrefix(got.Prefix, "kiln_") && len(got.Hash) == 64
+						},
+					),
+				).RunAndReturn(
+					func(_ context.Context, key model.APIKey) (model.APIKey, error) {
+						key.ID, key.CreatedAt = 7, createdAt
+						return key, nil
+					},
+				)
+			},
+			want: Output{
+				ID: 7, Name: "crm", Scopes: []string{model.ScopeRead, model.ScopeExport}, DailyQuota: 1000, CreatedAt: createdAt,
+			},
+		},
+		{
+			name:     "empty name",
+			input:    Input{Name: " ", Scopes: []string{model.ScopeRead}},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "no scope",
+			input:    Input{Name: "crm"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "unknown scope",
+			input:    Input{Name: "crm", Scopes: []string{"write"}},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "negative quota",
+			input:    Input{Name: "crm", Scopes: []string{model.ScopeRead}, DailyQuota: -1},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "repository error",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{Name: "crm", Scopes: []string{model.ScopeRead}},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().CreateAPIKey(context.Background(), mock.Anything).Return(model.APIKey{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo)
+
+				got, err := uc.CreateAPIKey(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("CreateAPIKey() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("CreateAPIKey() unexpected error = %v", err)
+					return
+				}
+
+				if !strings.HasPrefix(got.Secret, got.Prefix) || len(got.Prefix) != apikey.PrefixLen {
+					t.Errorf("CreateAPIKey() secret = %q, want it to start with the prefix %q", got.Secret, got.Prefix)
+				}
+
+				got.Secret, got.Prefix = "", ""
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CreateAPIKey() got = %+v, want %+v", got, tt.want)
+				}
+			},
+		)
+	}
+}
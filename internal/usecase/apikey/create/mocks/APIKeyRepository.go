@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, key
+func (_m *APIKeyRepository) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.APIKey) (model.APIKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.APIKey) model.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(model.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.APIKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type APIKeyRepository_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key model.APIKey
+func (_e *APIKeyRepository_Expecter) CreateAPIKey(ctx interface{}, key interface{}) *APIKeyRepository_CreateAPIKey_Call {
+	return &APIKeyRepository_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", ctx, key)}
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) Run(run func(ctx context.Context, key model.APIKey)) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.APIKey))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) Return(_a0 model.APIKey, _a1 error) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) RunAndReturn(run func(context.Context, model.APIKey) (model.APIKey, error)) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
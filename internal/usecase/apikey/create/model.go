@@ -0,0 +1,35 @@
+package create
+
+import (
+	"time"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	Name       string
+	Scopes     []string
+	DailyQuota int // Zero for no quota.
+}
+
+type Output struct {
+	ID         int
+	Name       string
+	Prefix     string
+	Secret     string // Only returned on creation and rotation.
+	Scopes     []string
+	DailyQuota int
+	CreatedAt  time.Time
+}
+
+func buildOutput(key model.APIKey, secret string) Output {
+	return Output{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Secret:     secret,
+		Scopes:     key.Scopes,
+		DailyQuota: key.DailyQuota,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package list
+
+import (
+	"context"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+type APIKeyRepository interface {
+	ListAPIKeys(ctx context.Context) ([]model.APIKey, error)
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
+
+// ListAPIKeys returns every API key, revoked or not, without their secrets.
+func (uc *UseCase) ListAPIKeys(ctx context.Context) (Output, error) {
+	keys, err := uc.APIKeyRepo.ListAPIKeys(ctx)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error listing API keys", err)
+	}
+
+	return buildOutput(keys), nil
+}
@@ -0,0 +1,92 @@
+//go:generate mockery
+package list
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey/list/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_ListAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+	}
+
+	var (
+		createdAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		revokedAt = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name: "happy path",
+			env:  env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().ListAPIKeys(context.Background()).Return(
+					[]model.APIKey{
+						{ID: 1, Name: "crm", Prefix: "kiln_01234567", Hash: "hash", Scopes: []string{model.ScopeRead}, CreatedAt: createdAt},
+						{ID: 2, Name: "ops", Prefix: "kiln_89abcdef", Hash: "hash", Scopes: []string{model.ScopeAdmin}, DailyQuota: 10, CreatedAt: createdAt, RevokedAt: &revokedAt},
+					}, nil,
+				)
+			},
+			want: Output{
+				Keys: []KeyData{
+					{ID: 1, Name: "crm", Prefix: "kiln_01234567", Scopes: []string{model.ScopeRead}, CreatedAt: createdAt},
+					{ID: 2, Name: "ops", Prefix: "kiln_89abcdef", Scopes: []string{model.ScopeAdmin}, DailyQuota: 10, CreatedAt: createdAt, RevokedAt: &revokedAt},
+				},
+			},
+		},
+		{
+			name: "repository error",
+			env:  env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().ListAPIKeys(context.Background()).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo)
+
+				got, err := uc.ListAPIKeys(context.Background())
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("ListAPIKeys() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("ListAPIKeys() unexpected error = %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListAPIKeys() got = %+v, want %+v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,39 @@
+package list
+
+import (
+	"time"
+
+	"kiln-exercice/internal/model"
+)
+
+type Output struct {
+	Keys []KeyData
+}
+
+type KeyData struct {
+	ID         int
+	Name       string
+	Prefix     string
+	Scopes     []string
+	DailyQuota int
+	CreatedAt  time.Time
+	RevokedAt  *time.Time // Nil while the key is valid.
+}
+
+func buildOutput(keys []model.APIKey) Output {
+	out := Output{Keys: make([]KeyData, len(keys))}
+
+	for i, k := range keys {
+		out.Keys[i] = KeyData{
+			ID:         k.ID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scopes:     k.Scopes,
+			DailyQuota: k.DailyQuota,
+			CreatedAt:  k.CreatedAt,
+			RevokedAt:  k.RevokedAt,
+		}
+	}
+
+	return out
+}
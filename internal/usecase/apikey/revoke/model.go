@@ -0,0 +1,5 @@
+package revoke
+
+type Input struct {
+	ID int
+}
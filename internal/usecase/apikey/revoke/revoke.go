@@ -0,0 +1,37 @@
+package revoke
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"kiln-exercice/pkg/api"
+)
+
+type APIKeyRepository interface {
+	RevokeAPIKey(ctx context.Context, id int) error
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
+
+// RevokeAPIKey invalidates an API key for good. The key is kept, along with its usage.
+func (uc *UseCase) RevokeAPIKey(ctx context.Context, input Input) error {
+	err := uc.APIKeyRepo.RevokeAPIKey(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return api.NewError(api.NotFound, fmt.Sprintf("no active API key found for id %d", input.ID), err)
+	}
+	if err != nil {
+		return api.NewError(api.Unknown, "error revoking API key", err)
+	}
+
+	return nil
+}
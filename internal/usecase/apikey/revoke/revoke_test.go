@@ -0,0 +1,80 @@
+//go:generate mockery
+package revoke
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"kiln-exercice/internal/usecase/apikey/revoke/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_RevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RevokeAPIKey(context.Background(), 7).Return(nil)
+			},
+		},
+		{
+			name:  "not found or already revoked",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RevokeAPIKey(context.Background(), 7).Return(sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RevokeAPIKey(context.Background(), 7).Return(errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo)
+
+				err := uc.RevokeAPIKey(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("RevokeAPIKey() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("RevokeAPIKey() unexpected error = %v", err)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "kiln-exercice/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// RotateAPIKey provides a mock function with given fields: ctx, id, prefix, hash
+func (_m *APIKeyRepository) RotateAPIKey(ctx context.Context, id int, prefix string, hash string) (model.APIKey, error) {
+	ret := _m.Called(ctx, id, prefix, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateAPIKey")
+	}
+
+	var r0 model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (model.APIKey, error)); ok {
+		return rf(ctx, id, prefix, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) model.APIKey); ok {
+		r0 = rf(ctx, id, prefix, hash)
+	} else {
+		r0 = ret.Get(0).(model.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, id, prefix, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_RotateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAPIKey'
+type APIKeyRepository_RotateAPIKey_Call struct {
+	*mock.Call
+}
+
+// RotateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - prefix string
+//   - hash string
+func (_e *APIKeyRepository_Expecter) RotateAPIKey(ctx interface{}, id interface{}, prefix interface{}, hash interface{}) *APIKeyRepository_RotateAPIKey_Call {
+	return &APIKeyRepository_RotateAPIKey_Call{Call: _e.mock.On("RotateAPIKey", ctx, id, prefix, hash)}
+}
+
+func (_c *APIKeyRepository_RotateAPIKey_Call) Run(run func(ctx context.Context, id int, prefix string, hash string)) *APIKeyRepository_RotateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_RotateAPIKey_Call) Return(_a0 model.APIKey, _a1 error) *APIKeyRepository_RotateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_RotateAPIKey_Call) RunAndReturn(run func(context.Context, int, string, string) (model.APIKey, error)) *APIKeyRepository_RotateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,12 @@
+package rotate
+
+type Input struct {
+	ID int
+}
+
+type Output struct {
+	ID     int
+	Name   string
+	Prefix string
+	Secret string // Only returned on creation and rotation.
+}
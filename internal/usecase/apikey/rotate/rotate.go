@@ -0,0 +1,45 @@
+package rotate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/pkg/api"
+)
+
+type APIKeyRepository interface {
+	RotateAPIKey(ctx context.Context, id int, prefix, hash string) (model.APIKey, error)
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
+
+// RotateAPIKey replaces the secret of an API key, keeping its scopes, quota and usage. The previous secret is
+// invalid at once, and the returned one cannot be retrieved afterward.
+func (uc *UseCase) RotateAPIKey(ctx context.Context, input Input) (Output, error) {
+	secret, err := apikey.NewSecret()
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error generating API key secret", err)
+	}
+
+	key, err := uc.APIKeyRepo.RotateAPIKey(ctx, input.ID, secret.Prefix, secret.Hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no active API key found for id %d", input.ID), err)
+	}
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error rotating API key", err)
+	}
+
+	return Output{ID: key.ID, Name: key.Name, Prefix: key.Prefix, Secret: secret.Secret}, nil
+}
@@ -0,0 +1,97 @@
+//go:generate mockery
+package rotate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey"
+	"kiln-exercice/internal/usecase/apikey/rotate/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_RotateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+		hash       string // Stored by the rotation.
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RotateAPIKey(context.Background(), 7, mock.Anything, mock.Anything).RunAndReturn(
+					func(_ context.Context, id int, prefix, hash string) (model.APIKey, error) {
+						e.hash = hash
+						return model.APIKey{ID: id, Name: "crm", Prefix: prefix, Hash: hash}, nil
+					},
+				)
+			},
+		},
+		{
+			name:  "not found or revoked",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RotateAPIKey(context.Background(), 7, mock.Anything, mock.Anything).
+					Return(model.APIKey{}, sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().RotateAPIKey(context.Background(), 7, mock.Anything, mock.Anything).
+					Return(model.APIKey{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo)
+
+				got, err := uc.RotateAPIKey(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("RotateAPIKey() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("RotateAPIKey() unexpected error = %v", err)
+					return
+				}
+
+				if got.ID != 7 || got.Name != "crm" || apikey.Hash(got.Secret) != tt.env.hash || got.Secret[:apikey.PrefixLen] != got.Prefix {
+					t.Errorf("RotateAPIKey() got = %+v, want the secret stored with hash %s", got, tt.env.hash)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// secretPrefix starts every secret, so that leaked keys are easy to spot.
+	secretPrefix = "kiln_"
+	// secretSize is the number of random bytes of a secret.
+	secretSize = 32
+	// PrefixLen is the number of leading characters of a secret kept in clear, to tell the keys apart.
+	PrefixLen = len(secretPrefix) + 8
+)
+
+// Secret is a new API key secret, along with what is stored of it.
+type Secret struct {
+	Secret string
+	Prefix string
+	Hash   string
+}
+
+// NewSecret generates a random secret.
+func NewSecret() (Secret, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return Secret{}, err
+	}
+
+	secret := secretPrefix + hex.EncodeToString(b)
+
+	return Secret{
+		Secret: secret,
+		Prefix: secret[:PrefixLen],
+		Hash:   Hash(secret),
+	}, nil
+}
+
+// Hash returns the hash of a secret, as stored. Secrets being random, a fast hash is enough to make them unguessable.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
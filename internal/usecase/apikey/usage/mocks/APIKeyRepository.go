@@ -0,0 +1,157 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "kiln-exercice/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetAPIKey provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepository) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKey")
+	}
+
+	var r0 model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (model.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKey'
+type APIKeyRepository_GetAPIKey_Call struct {
+	*mock.Call
+}
+
+// GetAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *APIKeyRepository_Expecter) GetAPIKey(ctx interface{}, id interface{}) *APIKeyRepository_GetAPIKey_Call {
+	return &APIKeyRepository_GetAPIKey_Call{Call: _e.mock.On("GetAPIKey", ctx, id)}
+}
+
+func (_c *APIKeyRepository_GetAPIKey_Call) Run(run func(ctx context.Context, id int)) *APIKeyRepository_GetAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetAPIKey_Call) Return(_a0 model.APIKey, _a1 error) *APIKeyRepository_GetAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetAPIKey_Call) RunAndReturn(run func(context.Context, int) (model.APIKey, error)) *APIKeyRepository_GetAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsage provides a mock function with given fields: ctx, id, from, to
+func (_m *APIKeyRepository) ListUsage(ctx context.Context, id int, from time.Time, to time.Time) ([]model.APIKeyUsage, error) {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsage")
+	}
+
+	var r0 []model.APIKeyUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) ([]model.APIKeyUsage, error)); ok {
+		return rf(ctx, id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) []model.APIKeyUsage); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.APIKeyUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_ListUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsage'
+type APIKeyRepository_ListUsage_Call struct {
+	*mock.Call
+}
+
+// ListUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - from time.Time
+//   - to time.Time
+func (_e *APIKeyRepository_Expecter) ListUsage(ctx interface{}, id interface{}, from interface{}, to interface{}) *APIKeyRepository_ListUsage_Call {
+	return &APIKeyRepository_ListUsage_Call{Call: _e.mock.On("ListUsage", ctx, id, from, to)}
+}
+
+func (_c *APIKeyRepository_ListUsage_Call) Run(run func(ctx context.Context, id int, from time.Time, to time.Time)) *APIKeyRepository_ListUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_ListUsage_Call) Return(_a0 []model.APIKeyUsage, _a1 error) *APIKeyRepository_ListUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_ListUsage_Call) RunAndReturn(run func(context.Context, int, time.Time, time.Time) ([]model.APIKeyUsage, error)) *APIKeyRepository_ListUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+package usage
+
+import (
+	"time"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	ID   int
+	From time.Time // Zero means DefaultDays before To.
+	To   time.Time // Zero means today.
+}
+
+type Output struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	DailyQuota int       `json:"daily_quota,omitempty"`
+	Days       []DayData `json:"days"`
+}
+
+type DayData struct {
+	Day      string `json:"day"` // UTC day, as YYYY-MM-DD.
+	Requests int64  `json:"requests"`
+}
+
+func buildOutput(key model.APIKey, usage []model.APIKeyUsage) Output {
+	out := Output{
+		ID:         key.ID,
+		Name:       key.Name,
+		DailyQuota: key.DailyQuota,
+		Days:       make([]DayData, len(usage)),
+	}
+
+	for i, u := range usage {
+		out.Days[i] = DayData{
+			Day:      u.Day.Format(time.DateOnly),
+			Requests: u.Requests,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+const (
+	DefaultDays = 30
+	MaxDays     = 366
+)
+
+type APIKeyRepository interface {
+	GetAPIKey(ctx context.Context, id int) (model.APIKey, error)
+	ListUsage(ctx context.Context, id int, from, to time.Time) ([]model.APIKeyUsage, error)
+}
+
+type UseCase struct {
+	APIKeyRepo APIKeyRepository
+	TimeNow    func() time.Time
+}
+
+func NewUseCase(apiKeyRepo APIKeyRepository, timeNow func() time.Time) *UseCase {
+	return &UseCase{
+		APIKeyRepo: apiKeyRepo,
+		TimeNow:    timeNow,
+	}
+}
+
+// GetUsage returns the daily requests of an API key, revoked or not, between two UTC days, inclusive, oldest first.
+// Days without requests are missing.
+func (uc *UseCase) GetUsage(ctx context.Context, input Input) (Output, error) {
+	to := input.To
+	if to.IsZero() {
+		to = uc.TimeNow()
+	}
+
+	to = truncateToDay(to)
+
+	from := input.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, 1-DefaultDays)
+	}
+
+	from = truncateToDay(from)
+
+	if from.After(to) {
+		return Output{}, api.NewError(api.InvalidArgument, "invalid range: from must not be after to", nil)
+	}
+
+	if days := int(to.Sub(from).Hours()/24) + 1; days > MaxDays {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid range: %d days, at most %d", days, MaxDays), nil)
+	}
+
+	key, err := uc.APIKeyRepo.GetAPIKey(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no API key found for id %d", input.ID), err)
+	}
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting API key", err)
+	}
+
+	usage, err := uc.APIKeyRepo.ListUsage(ctx, input.ID, from, to)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error listing API key usage", err)
+	}
+
+	return buildOutput(key, usage), nil
+}
+
+// truncateToDay returns the start of the UTC day of t.
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,133 @@
+//go:generate mockery
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/apikey/usage/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_GetUsage(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		APIKeyRepo *mocks.APIKeyRepository
+	}
+
+	var (
+		now   = time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC)
+		today = time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+		key   = model.APIKey{ID: 7, Name: "crm", DailyQuota: 1000}
+		usage = []model.APIKeyUsage{
+			{Day: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Requests: 12},
+			{Day: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Requests: 3},
+		}
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "last days by default",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetAPIKey(context.Background(), 7).Return(key, nil)
+				e.APIKeyRepo.EXPECT().ListUsage(context.Background(), 7, today.AddDate(0, 0, -29), today).Return(usage, nil)
+			},
+			want: Output{
+				ID: 7, Name: "crm", DailyQuota: 1000,
+				Days: []DayData{{Day: "2024-03-02", Requests: 12}, {Day: "2024-03-31", Requests: 3}},
+			},
+		},
+		{
+			name: "range truncated to UTC days",
+			env:  env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{
+				ID:   7,
+				From: time.Date(2024, 3, 1, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*60*60)),
+				To:   time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC),
+			},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetAPIKey(context.Background(), 7).Return(key, nil)
+				e.APIKeyRepo.EXPECT().ListUsage(
+					context.Background(), 7, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+				).Return(usage[:1], nil)
+			},
+			want: Output{ID: 7, Name: "crm", DailyQuota: 1000, Days: []DayData{{Day: "2024-03-02", Requests: 12}}},
+		},
+		{
+			name:     "from after to",
+			input:    Input{ID: 7, From: today.AddDate(0, 0, 1), To: today},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "range too long",
+			input:    Input{ID: 7, From: today.AddDate(-2, 0, 0)},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "not found",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetAPIKey(context.Background(), 7).Return(model.APIKey{}, sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{APIKeyRepo: mocks.NewAPIKeyRepository(t)},
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.APIKeyRepo.EXPECT().GetAPIKey(context.Background(), 7).Return(key, nil)
+				e.APIKeyRepo.EXPECT().ListUsage(context.Background(), 7, today.AddDate(0, 0, -29), today).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.APIKeyRepo, func() time.Time { return now })
+
+				got, err := uc.GetUsage(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetUsage() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetUsage() unexpected error = %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetUsage() got = %+v, want %+v", got, tt.want)
+				}
+			},
+		)
+	}
+}
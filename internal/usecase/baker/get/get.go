@@ -0,0 +1,83 @@
+package get
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tezos"
+)
+
+type BakerRepository interface {
+	GetBaker(ctx context.Context, address string) (model.Baker, error)
+	ListBakersByAddress(ctx context.Context, addresses []string) ([]model.Baker, error)
+	GetBakerFlows(ctx context.Context, address string, filter model.DelegationFilter) (model.BakerFlows, error)
+}
+
+type UseCase struct {
+	BakerRepo BakerRepository
+}
+
+func NewUseCase(bakerRepo BakerRepository) *UseCase {
+	return &UseCase{
+		BakerRepo: bakerRepo,
+	}
+}
+
+// GetBaker returns the current delegators of a baker and its delegation flows over the input period.
+func (uc *UseCase) GetBaker(ctx context.Context, input Input) (Output, error) {
+	if err := validateInput(input); err != nil {
+		return Output{}, err
+	}
+
+	baker, err := uc.BakerRepo.GetBaker(ctx, input.Address)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no delegation found for baker %s", input.Address), err)
+	}
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting baker", err)
+	}
+
+	flows, err := uc.BakerRepo.GetBakerFlows(ctx, input.Address, model.DelegationFilter{From: input.From, To: input.To})
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting baker flows", err)
+	}
+
+	return buildOutput(input, baker, flows), nil
+}
+
+// GetBakers returns the current delegators of several bakers, keyed by address. Unknown bakers, as well as invalid
+// addresses, are left out: callers validate the addresses, so that one of them cannot fail a whole batch.
+func (uc *UseCase) GetBakers(ctx context.Context, addresses []string) (map[string]Summary, error) {
+	bakers, err := uc.BakerRepo.ListBakersByAddress(ctx, addresses)
+	if err != nil {
+		return nil, api.NewError(api.Unknown, "error listing bakers", err)
+	}
+
+	out := make(map[string]Summary, len(bakers))
+	for _, b := range bakers {
+		out[b.Address] = Summary{Address: b.Address, DelegatorCount: b.DelegatorCount, DelegatedAmount: b.DelegatedAmount}
+	}
+
+	return out, nil
+}
+
+func validateInput(input Input) error {
+	if err := tezos.ValidateAddress(input.Address); err != nil {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid address: %s", err.Error()), err)
+	}
+
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid time range: from %s must be before to %s", input.From.Format(time.RFC3339), input.To.Format(time.RFC3339)),
+			nil,
+		)
+	}
+
+	return nil
+}
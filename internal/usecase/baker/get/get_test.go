@@ -0,0 +1,183 @@
+//go:generate mockery
+package get
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/baker/get/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+const address = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+
+func TestUseCase_GetBaker(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		BakerRepo *mocks.BakerRepository
+	}
+
+	var (
+		from = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to   = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{BakerRepo: mocks.NewBakerRepository(t)},
+			input: Input{Address: address, From: from, To: to},
+			init: func(e *env) {
+				e.BakerRepo.EXPECT().GetBaker(context.Background(), address).Return(
+					model.Baker{Address: address, DelegatorCount: 12, DelegatedAmount: decimal.RequireFromString("1000")}, nil,
+				)
+				e.BakerRepo.EXPECT().GetBakerFlows(context.Background(), address, model.DelegationFilter{From: from, To: to}).Return(
+					model.BakerFlows{
+						InflowCount:   3,
+						InflowAmount:  decimal.RequireFromString("300"),
+						OutflowCount:  5,
+						OutflowAmount: decimal.RequireFromString("100"),
+					}, nil,
+				)
+			},
+			want: Output{
+				Address:         address,
+				DelegatorCount:  12,
+				DelegatedAmount: decimal.RequireFromString("1000"),
+				From:            &from,
+				To:              &to,
+				Inflows:         FlowData{Count: 3, Amount: decimal.RequireFromString("300")},
+				Outflows:        FlowData{Count: 5, Amount: decimal.RequireFromString("100")},
+				NetChange:       FlowData{Count: -2, Amount: decimal.RequireFromString("200")},
+			},
+		},
+		{
+			name:     "invalid address",
+			input:    Input{Address: "tz1abc"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "invalid time range",
+			input:    Input{Address: address, From: to, To: from},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "not found",
+			env:   env{BakerRepo: mocks.NewBakerRepository(t)},
+			input: Input{Address: address},
+			init: func(e *env) {
+				e.BakerRepo.EXPECT().GetBaker(context.Background(), address).Return(model.Baker{}, sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.BakerRepo)
+
+				got, err := uc.GetBaker(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetBaker() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetBaker() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetBaker() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestUseCase_GetBakers(t *testing.T) {
+	t.Parallel()
+
+	const other = "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd"
+
+	tests := []struct {
+		name      string
+		addresses []string
+		init      func(*mocks.BakerRepository)
+		want      map[string]Summary
+		wantCode  api.Code
+	}{
+		{
+			name:      "happy path",
+			addresses: []string{address, other},
+			init: func(repo *mocks.BakerRepository) {
+				repo.EXPECT().ListBakersByAddress(context.Background(), []string{address, other}).Return(
+					[]model.Baker{{Address: address, DelegatorCount: 12, DelegatedAmount: decimal.RequireFromString("1000")}}, nil,
+				)
+			},
+			want: map[string]Summary{
+				address: {Address: address, DelegatorCount: 12, DelegatedAmount: decimal.RequireFromString("1000")},
+			},
+		},
+		{
+			name:      "repository error",
+			addresses: []string{address},
+			init: func(repo *mocks.BakerRepository) {
+				repo.EXPECT().ListBakersByAddress(context.Background(), []string{address}).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				repo := mocks.NewBakerRepository(t)
+				tt.init(repo)
+
+				got, err := NewUseCase(repo).GetBakers(context.Background(), tt.addresses)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetBakers() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetBakers() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetBakers() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// BakerRepository is an autogenerated mock type for the BakerRepository type
+type BakerRepository struct {
+	mock.Mock
+}
+
+type BakerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BakerRepository) EXPECT() *BakerRepository_Expecter {
+	return &BakerRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetBaker provides a mock function with given fields: ctx, address
+func (_m *BakerRepository) GetBaker(ctx context.Context, address string) (model.Baker, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBaker")
+	}
+
+	var r0 model.Baker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Baker, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Baker); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(model.Baker)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerRepository_GetBaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBaker'
+type BakerRepository_GetBaker_Call struct {
+	*mock.Call
+}
+
+// GetBaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address string
+func (_e *BakerRepository_Expecter) GetBaker(ctx interface{}, address interface{}) *BakerRepository_GetBaker_Call {
+	return &BakerRepository_GetBaker_Call{Call: _e.mock.On("GetBaker", ctx, address)}
+}
+
+func (_c *BakerRepository_GetBaker_Call) Run(run func(ctx context.Context, address string)) *BakerRepository_GetBaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BakerRepository_GetBaker_Call) Return(_a0 model.Baker, _a1 error) *BakerRepository_GetBaker_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerRepository_GetBaker_Call) RunAndReturn(run func(context.Context, string) (model.Baker, error)) *BakerRepository_GetBaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBakerFlows provides a mock function with given fields: ctx, address, filter
+func (_m *BakerRepository) GetBakerFlows(ctx context.Context, address string, filter model.DelegationFilter) (model.BakerFlows, error) {
+	ret := _m.Called(ctx, address, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBakerFlows")
+	}
+
+	var r0 model.BakerFlows
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DelegationFilter) (model.BakerFlows, error)); ok {
+		return rf(ctx, address, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DelegationFilter) model.BakerFlows); ok {
+		r0 = rf(ctx, address, filter)
+	} else {
+		r0 = ret.Get(0).(model.BakerFlows)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.DelegationFilter) error); ok {
+		r1 = rf(ctx, address, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerRepository_GetBakerFlows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBakerFlows'
+type BakerRepository_GetBakerFlows_Call struct {
+	*mock.Call
+}
+
+// GetBakerFlows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address string
+//   - filter model.DelegationFilter
+func (_e *BakerRepository_Expecter) GetBakerFlows(ctx interface{}, address interface{}, filter interface{}) *BakerRepository_GetBakerFlows_Call {
+	return &BakerRepository_GetBakerFlows_Call{Call: _e.mock.On("GetBakerFlows", ctx, address, filter)}
+}
+
+func (_c *BakerRepository_GetBakerFlows_Call) Run(run func(ctx context.Context, address string, filter model.DelegationFilter)) *BakerRepository_GetBakerFlows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.DelegationFilter))
+	})
+	return _c
+}
+
+func (_c *BakerRepository_GetBakerFlows_Call) Return(_a0 model.BakerFlows, _a1 error) *BakerRepository_GetBakerFlows_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerRepository_GetBakerFlows_Call) RunAndReturn(run func(context.Context, string, model.DelegationFilter) (model.BakerFlows, error)) *BakerRepository_GetBakerFlows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBakersByAddress provides a mock function with given fields: ctx, addresses
+func (_m *BakerRepository) ListBakersByAddress(ctx context.Context, addresses []string) ([]model.Baker, error) {
+	ret := _m.Called(ctx, addresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBakersByAddress")
+	}
+
+	var r0 []model.Baker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.Baker, error)); ok {
+		return rf(ctx, addresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.Baker); ok {
+		r0 = rf(ctx, addresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Baker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerRepository_ListBakersByAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBakersByAddress'
+type BakerRepository_ListBakersByAddress_Call struct {
+	*mock.Call
+}
+
+// ListBakersByAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - addresses []string
+func (_e *BakerRepository_Expecter) ListBakersByAddress(ctx interface{}, addresses interface{}) *BakerRepository_ListBakersByAddress_Call {
+	return &BakerRepository_ListBakersByAddress_Call{Call: _e.mock.On("ListBakersByAddress", ctx, addresses)}
+}
+
+func (_c *BakerRepository_ListBakersByAddress_Call) Run(run func(ctx context.Context, addresses []string)) *BakerRepository_ListBakersByAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *BakerRepository_ListBakersByAddress_Call) Return(_a0 []model.Baker, _a1 error) *BakerRepository_ListBakersByAddress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerRepository_ListBakersByAddress_Call) RunAndReturn(run func(context.Context, []string) ([]model.Baker, error)) *BakerRepository_ListBakersByAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBakerRepository creates a new instance of BakerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBakerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BakerRepository {
+	mock := &BakerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
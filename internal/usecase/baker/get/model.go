@@ -0,0 +1,62 @@
+package get
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	Address string
+	From    time.Time // Zero means since the first delegation.
+	To      time.Time // Zero means up to now.
+}
+
+type Output struct {
+	Address         string          `json:"address"`
+	DelegatorCount  int64           `json:"delegator_count"`
+	DelegatedAmount decimal.Decimal `json:"delegated_amount"`
+	From            *time.Time      `json:"from,omitempty"`
+	To              *time.Time      `json:"to,omitempty"`
+	Inflows         FlowData        `json:"inflows"`
+	Outflows        FlowData        `json:"outflows"`
+	NetChange       FlowData        `json:"net_change"`
+}
+
+// Summary is the current delegation state of a baker, without its flows.
+type Summary struct {
+	Address         string
+	DelegatorCount  int64
+	DelegatedAmount decimal.Decimal
+}
+
+type FlowData struct {
+	Count  int64           `json:"count"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+func buildOutput(input Input, baker model.Baker, flows model.BakerFlows) Output {
+	out := Output{
+		Address:         baker.Address,
+		DelegatorCount:  baker.DelegatorCount,
+		DelegatedAmount: baker.DelegatedAmount,
+		Inflows:         FlowData{Count: flows.InflowCount, Amount: flows.InflowAmount},
+		Outflows:        FlowData{Count: flows.OutflowCount, Amount: flows.OutflowAmount},
+		NetChange: FlowData{
+			Count:  flows.InflowCount - flows.OutflowCount,
+			Amount: flows.InflowAmount.Sub(flows.OutflowAmount),
+		},
+	}
+
+	if !input.From.IsZero() {
+		out.From = &input.From
+	}
+
+	if !input.To.IsZero() {
+		out.To = &input.To
+	}
+
+	return out
+}
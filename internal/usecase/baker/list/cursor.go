@@ -0,0 +1,69 @@
+package list
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// cursor holds the sort key of the last baker of a page.
+// It is bound to the sort it was produced with.
+type cursor struct {
+	Sort    string `json:"s"`
+	Order   string `json:"o"`
+	Value   string `json:"v"`
+	Address string `json:"a"`
+}
+
+// encodeCursor returns the cursor pointing after the baker b.
+func encodeCursor(sort model.Sort, b model.Baker) (string, error) {
+	c := cursor{
+		Sort:    sort.Field,
+		Order:   sort.Order,
+		Address: b.Address,
+	}
+
+	switch sort.Field {
+	case "delegators":
+		c.Value = strconv.FormatInt(b.DelegatorCount, 10)
+	case "amount":
+		c.Value = b.DelegatedAmount.String()
+	default:
+		return "", fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+
+	return api.EncodeCursor(c)
+}
+
+// decodeCursor returns the baker a cursor points after, checking it was produced with the same sort.
+func decodeCursor(raw string, sort model.Sort) (*model.Baker, error) {
+	var c cursor
+	if err := api.DecodeCursor(raw, &c); err != nil {
+		return nil, err
+	}
+
+	if c.Sort != sort.Field || c.Order != sort.Order {
+		return nil, fmt.Errorf("cursor was produced for sort %s %s", c.Sort, c.Order)
+	}
+
+	var (
+		b   = model.Baker{Address: c.Address}
+		err error
+	)
+
+	switch c.Sort {
+	case "delegators":
+		b.DelegatorCount, err = strconv.ParseInt(c.Value, 10, 64)
+	case "amount":
+		b.DelegatedAmount, err = decimal.NewFromString(c.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cursor value: %w", err)
+	}
+
+	return &b, nil
+}
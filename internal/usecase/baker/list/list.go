@@ -0,0 +1,62 @@
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// SortFields lists the fields bakers can be sorted by, the first one being the default.
+var SortFields = []string{"delegators", "amount"}
+
+type BakerRepository interface {
+	ListBakers(ctx context.Context, page model.BakerPage) ([]model.Baker, error)
+	CountBakers(ctx context.Context) (int64, error)
+}
+
+type UseCase struct {
+	BakerRepo BakerRepository
+}
+
+func NewUseCase(bakerRepo BakerRepository) *UseCase {
+	return &UseCase{
+		BakerRepo: bakerRepo,
+	}
+}
+
+// ListBakers returns a page of the baker leaderboard.
+// Pages are selected either by offset or, when the input holds a cursor, by keyset.
+func (uc *UseCase) ListBakers(ctx context.Context, input Input) (Output, error) {
+	page, err := input.page()
+	if err != nil {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid cursor: %s", err.Error()), err)
+	}
+
+	bakers, err := uc.BakerRepo.ListBakers(ctx, page)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error listing bakers", err)
+	}
+
+	var nextCursor string
+	if input.Limit() != 0 && len(bakers) > input.Limit() {
+		bakers = bakers[:input.Limit()]
+
+		nextCursor, err = encodeCursor(page.Sort, bakers[len(bakers)-1])
+		if err != nil {
+			return Output{}, api.NewError(api.Unknown, "error encoding cursor", err)
+		}
+	}
+
+	count, err := uc.BakerRepo.CountBakers(ctx)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error counting bakers", err)
+	}
+
+	return Output{
+		Bakers:     buildBakersData(bakers),
+		NextCursor: nextCursor,
+		TotalCount: count,
+	}, nil
+}
@@ -0,0 +1,143 @@
+//go:generate mockery
+package list
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/baker/list/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_ListBakers(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		BakerRepo *mocks.BakerRepository
+	}
+
+	var (
+		first = model.Baker{
+			Address:         "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+			DelegatorCount:  12,
+			DelegatedAmount: decimal.RequireFromString("1000"),
+		}
+		second = model.Baker{
+			Address:         "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+			DelegatorCount:  3,
+			DelegatedAmount: decimal.RequireFromString("5000"),
+		}
+		defaultSort = model.Sort{Field: "delegators", Order: "desc"}
+	)
+
+	wantCursor, err := encodeCursor(defaultSort, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "first page",
+			env:   env{BakerRepo: mocks.NewBakerRepository(t)},
+			input: Input{Pagination: api.Pagination{PageNumber: 1, PageSize: 1}},
+			init: func(e *env) {
+				e.BakerRepo.EXPECT().ListBakers(context.Background(), model.BakerPage{Sort: defaultSort, Limit: 2}).
+					Return([]model.Baker{first, second}, nil)
+				e.BakerRepo.EXPECT().CountBakers(context.Background()).Return(2, nil)
+			},
+			want: Output{
+				Bakers: []BakerData{
+					{
+						Address:         "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						DelegatorCount:  12,
+						DelegatedAmount: decimal.RequireFromString("1000"),
+					},
+				},
+				NextCursor: wantCursor,
+				TotalCount: 2,
+			},
+		},
+		{
+			name:  "next page by cursor",
+			env:   env{BakerRepo: mocks.NewBakerRepository(t)},
+			input: Input{Pagination: api.Pagination{PageNumber: 1, PageSize: 1, Cursor: wantCursor}},
+			init: func(e *env) {
+				after := model.Baker{Address: first.Address, DelegatorCount: first.DelegatorCount}
+				e.BakerRepo.EXPECT().ListBakers(
+					context.Background(), model.BakerPage{Sort: defaultSort, Limit: 2, After: &after},
+				).Return([]model.Baker{second}, nil)
+				e.BakerRepo.EXPECT().CountBakers(context.Background()).Return(2, nil)
+			},
+			want: Output{
+				Bakers: []BakerData{
+					{
+						Address:         "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+						DelegatorCount:  3,
+						DelegatedAmount: decimal.RequireFromString("5000"),
+					},
+				},
+				TotalCount: 2,
+			},
+		},
+		{
+			name: "cursor of another sort",
+			input: Input{
+				Sort:       api.Sort{Field: "amount", Order: "desc"},
+				Pagination: api.Pagination{PageNumber: 1, PageSize: 1, Cursor: wantCursor},
+			},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "repository error",
+			env:   env{BakerRepo: mocks.NewBakerRepository(t)},
+			input: Input{Pagination: api.Pagination{PageNumber: 1, PageSize: 1}},
+			init: func(e *env) {
+				e.BakerRepo.EXPECT().ListBakers(context.Background(), model.BakerPage{Sort: defaultSort, Limit: 2}).
+					Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.BakerRepo)
+
+				got, err := uc.ListBakers(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("ListBakers() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("ListBakers() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListBakers() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// BakerRepository is an autogenerated mock type for the BakerRepository type
+type BakerRepository struct {
+	mock.Mock
+}
+
+type BakerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BakerRepository) EXPECT() *BakerRepository_Expecter {
+	return &BakerRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountBakers provides a mock function with given fields: ctx
+func (_m *BakerRepository) CountBakers(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBakers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerRepository_CountBakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBakers'
+type BakerRepository_CountBakers_Call struct {
+	*mock.Call
+}
+
+// CountBakers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BakerRepository_Expecter) CountBakers(ctx interface{}) *BakerRepository_CountBakers_Call {
+	return &BakerRepository_CountBakers_Call{Call: _e.mock.On("CountBakers", ctx)}
+}
+
+func (_c *BakerRepository_CountBakers_Call) Run(run func(ctx context.Context)) *BakerRepository_CountBakers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BakerRepository_CountBakers_Call) Return(_a0 int64, _a1 error) *BakerRepository_CountBakers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerRepository_CountBakers_Call) RunAndReturn(run func(context.Context) (int64, error)) *BakerRepository_CountBakers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBakers provides a mock function with given fields: ctx, page
+func (_m *BakerRepository) ListBakers(ctx context.Context, page model.BakerPage) ([]model.Baker, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBakers")
+	}
+
+	var r0 []model.Baker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.BakerPage) ([]model.Baker, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.BakerPage) []model.Baker); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Baker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.BakerPage) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BakerRepository_ListBakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBakers'
+type BakerRepository_ListBakers_Call struct {
+	*mock.Call
+}
+
+// ListBakers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - page model.BakerPage
+func (_e *BakerRepository_Expecter) ListBakers(ctx interface{}, page interface{}) *BakerRepository_ListBakers_Call {
+	return &BakerRepository_ListBakers_Call{Call: _e.mock.On("ListBakers", ctx, page)}
+}
+
+func (_c *BakerRepository_ListBakers_Call) Run(run func(ctx context.Context, page model.BakerPage)) *BakerRepository_ListBakers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.BakerPage))
+	})
+	return _c
+}
+
+func (_c *BakerRepository_ListBakers_Call) Return(_a0 []model.Baker, _a1 error) *BakerRepository_ListBakers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BakerRepository_ListBakers_Call) RunAndReturn(run func(context.Context, model.BakerPage) ([]model.Baker, error)) *BakerRepository_ListBakers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBakerRepository creates a new instance of BakerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBakerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BakerRepository {
+	mock := &BakerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+package list
+
+import (
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+type Input struct {
+	Sort api.Sort
+	api.Pagination
+}
+
+// sort returns the sort of the input, defaulting to the first sort field in descending order.
+func (in Input) sort() model.Sort {
+	sort := model.Sort{
+		Field: in.Sort.Field,
+		Order: in.Sort.Order,
+	}
+
+	if sort.Field == "" {
+		sort.Field = SortFields[0]
+	}
+
+	if sort.Order == "" {
+		sort.Order = api.OrderDesc
+	}
+
+	return sort
+}
+
+// page returns the page of bakers to fetch.
+// One more baker than requested is fetched to know whether there is a next page.
+func (in Input) page() (model.BakerPage, error) {
+	page := model.BakerPage{
+		Sort:   in.sort(),
+		Offset: in.Offset(),
+	}
+
+	if in.Limit() != 0 {
+		page.Limit = in.Limit() + 1
+	}
+
+	if in.Cursor != "" {
+		after, err := decodeCursor(in.Cursor, page.Sort)
+		if err != nil {
+			return model.BakerPage{}, err
+		}
+
+		page.After = after
+	}
+
+	return page, nil
+}
+
+type Output struct {
+	Bakers     []BakerData
+	NextCursor string // Empty on the last page.
+	TotalCount int64
+}
+
+type BakerData struct {
+	Address         string          `json:"address"`
+	DelegatorCount  int64           `json:"delegator_count"`
+	DelegatedAmount decimal.Decimal `json:"delegated_amount"`
+}
+
+func buildBakersData(bakers []model.Baker) []BakerData {
+	out := make([]BakerData, len(bakers))
+
+	for i, b := range bakers {
+		out[i] = BakerData{
+			Address:         b.Address,
+			DelegatorCount:  b.DelegatorCount,
+			DelegatedAmount: b.DelegatedAmount,
+		}
+	}
+
+	return out
+}
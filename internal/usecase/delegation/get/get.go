@@ -0,0 +1,42 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tezos"
+)
+
+type DelegationRepository interface {
+	ListDelegationsByHash(ctx context.Context, hash string) ([]model.Delegation, error)
+}
+
+type UseCase struct {
+	DelegationRepo DelegationRepository
+}
+
+func NewUseCase(delegationRepo DelegationRepository) *UseCase {
+	return &UseCase{
+		DelegationRepo: delegationRepo,
+	}
+}
+
+// GetDelegations returns every delegation of an operation group.
+func (uc *UseCase) GetDelegations(ctx context.Context, input Input) (Output, error) {
+	if err := tezos.ValidateOperationHash(input.Hash); err != nil {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid hash: %s", err.Error()), err)
+	}
+
+	delegations, err := uc.DelegationRepo.ListDelegationsByHash(ctx, input.Hash)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting delegations", err)
+	}
+
+	if len(delegations) == 0 {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no delegation found for hash %s", input.Hash), nil)
+	}
+
+	return buildOutput(delegations), nil
+}
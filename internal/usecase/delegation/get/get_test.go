@@ -0,0 +1,124 @@
+//go:generate mockery
+package get
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/delegation/get/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+const hash = "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b"
+
+func TestUseCase_GetDelegations(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		DelegationRepo *mocks.DelegationRepository
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Hash: hash},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegationsByHash(context.Background(), hash).Return(
+					[]model.Delegation{
+						{
+							ID:          12,
+							OperationID: 1098907648,
+							Datetime:    time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+							Amount:      decimal.RequireFromString("25079312620"),
+							Delegator:   "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Baker:       "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Height:      109,
+							TxHash:      hash,
+						},
+					}, nil,
+				)
+			},
+			want: Output{
+				{
+					ID:          12,
+					OperationID: 1098907648,
+					Hash:        hash,
+					Timestamp:   time.Date(2018, 6, 30, 19, 30, 27, 0, time.UTC),
+					Amount:      decimal.RequireFromString("25079312620"),
+					Delegator:   "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					Baker:       "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+					Level:       "109",
+					Height:      109,
+				},
+			},
+		},
+		{
+			name:     "invalid hash",
+			input:    Input{Hash: "abc"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "not found",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Hash: hash},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegationsByHash(context.Background(), hash).Return(nil, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Hash: hash},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegationsByHash(context.Background(), hash).
+					Return(nil, errors.New("db error"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.DelegationRepo)
+
+				got, err := uc.GetDelegations(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetDelegations() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetDelegations() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetDelegations() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
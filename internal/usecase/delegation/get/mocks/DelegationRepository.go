@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// DelegationRepository is an autogenerated mock type for the DelegationRepository type
+type DelegationRepository struct {
+	mock.Mock
+}
+
+type DelegationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationRepository) EXPECT() *DelegationRepository_Expecter {
+	return &DelegationRepository_Expecter{mock: &_m.Mock}
+}
+
+// ListDelegationsByHash provides a mock function with given fields: ctx, hash
+func (_m *DelegationRepository) ListDelegationsByHash(ctx context.Context, hash string) ([]model.Delegation, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDelegationsByHash")
+	}
+
+	var r0 []model.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Delegation, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Delegation); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationRepository_ListDelegationsByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDelegationsByHash'
+type DelegationRepository_ListDelegationsByHash_Call struct {
+	*mock.Call
+}
+
+// ListDelegationsByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *DelegationRepository_Expecter) ListDelegationsByHash(ctx interface{}, hash interface{}) *DelegationRepository_ListDelegationsByHash_Call {
+	return &DelegationRepository_ListDelegationsByHash_Call{Call: _e.mock.On("ListDelegationsByHash", ctx, hash)}
+}
+
+func (_c *DelegationRepository_ListDelegationsByHash_Call) Run(run func(ctx context.Context, hash string)) *DelegationRepository_ListDelegationsByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_ListDelegationsByHash_Call) Return(_a0 []model.Delegation, _a1 error) *DelegationRepository_ListDelegationsByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationRepository_ListDelegationsByHash_Call) RunAndReturn(run func(context.Context, string) ([]model.Delegation, error)) *DelegationRepository_ListDelegationsByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationRepository creates a new instance of DelegationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationRepository {
+	mock := &DelegationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+package get
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	Hash string
+}
+
+type Output = []DelegationData
+
+type DelegationData struct {
+	ID           int             `json:"id"`
+	OperationID  int64           `json:"operation_id"`
+	Hash         string          `json:"hash"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Amount       decimal.Decimal `json:"amount"`
+	Delegator    string          `json:"delegator"`
+	Baker        string          `json:"baker"`
+	BakerUnknown bool            `json:"-"` // Only represented from v2.
+	Level        string          `json:"level"`
+	Height       int             `json:"-"` // Level as represented from v2.
+	Quote        model.Quote     `json:"-"` // Only represented from v2.
+}
+
+func buildOutput(delegations []model.Delegation) Output {
+	out := make([]DelegationData, len(delegations))
+
+	for i, d := range delegations {
+		out[i] = DelegationData{
+			ID:           d.ID,
+			OperationID:  d.OperationID,
+			Hash:         d.TxHash,
+			Timestamp:    d.Datetime,
+			Amount:       d.Amount,
+			Delegator:    d.Delegator,
+			Baker:        d.Baker,
+			BakerUnknown: d.BakerUnknown,
+			Level:        strconv.Itoa(d.Height),
+			Height:       d.Height,
+			Quote:        d.Quote,
+		}
+	}
+
+	return out
+}
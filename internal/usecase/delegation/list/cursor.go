@@ -0,0 +1,74 @@
+package list
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// cursor holds the sort key of the last delegation of a page.
+// It is bound to the sort it was produced with.
+type cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int    `json:"i"`
+}
+
+// encodeCursor returns the cursor pointing after the delegation d.
+func encodeCursor(sort model.Sort, d model.Delegation) (string, error) {
+	c := cursor{
+		Sort:  sort.Field,
+		Order: sort.Order,
+		ID:    d.ID,
+	}
+
+	switch sort.Field {
+	case "timestamp":
+		c.Value = d.Datetime.Format(time.RFC3339Nano)
+	case "amount":
+		c.Value = d.Amount.String()
+	case "level":
+		c.Value = strconv.Itoa(d.Height)
+	default:
+		return "", fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+
+	return api.EncodeCursor(c)
+}
+
+// decodeCursor returns the delegation a cursor points after, checking it was produced with the same sort.
+func decodeCursor(raw string, sort model.Sort) (*model.Delegation, error) {
+	var c cursor
+	if err := api.DecodeCursor(raw, &c); err != nil {
+		return nil, err
+	}
+
+	if c.Sort != sort.Field || c.Order != sort.Order {
+		return nil, fmt.Errorf("cursor was produced for sort %s %s", c.Sort, c.Order)
+	}
+
+	var (
+		d   = model.Delegation{ID: c.ID}
+		err error
+	)
+
+	switch c.Sort {
+	case "timestamp":
+		d.Datetime, err = time.Parse(time.RFC3339Nano, c.Value)
+	case "amount":
+		d.Amount, err = decimal.NewFromString(c.Value)
+	case "level":
+		d.Height, err = strconv.Atoi(c.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cursor value: %w", err)
+	}
+
+	return &d, nil
+}
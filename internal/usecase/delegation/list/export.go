@@ -0,0 +1,48 @@
+package list
+
+import (
+	"context"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+type DelegationStreamer interface {
+	StreamDelegations(ctx context.Context, filter model.DelegationFilter, sort model.Sort, fn func(model.Delegation) error) error
+}
+
+type ExportUseCase struct {
+	DelegationStreamer DelegationStreamer
+}
+
+func NewExportUseCase(delegationStreamer DelegationStreamer) *ExportUseCase {
+	return &ExportUseCase{
+		DelegationStreamer: delegationStreamer,
+	}
+}
+
+// ExportDelegations calls fn on every delegation matching the input filters, in the order of the input sort.
+// The pagination of the input is ignored. Delegations are passed as they are read, without being accumulated.
+func (uc *ExportUseCase) ExportDelegations(ctx context.Context, input Input, fn func(DelegationData) error) error {
+	if err := validateInput(input); err != nil {
+		return err
+	}
+
+	var fnErr error
+
+	err := uc.DelegationStreamer.StreamDelegations(
+		ctx, input.filter(), input.sort(), func(d model.Delegation) error {
+			fnErr = fn(buildDelegationData(d))
+			return fnErr
+		},
+	)
+	if err != nil {
+		if fnErr != nil {
+			return fnErr // Already meaningful to the caller, e.g. a client disconnection.
+		}
+
+		return api.NewError(api.Unknown, "error exporting delegations", err)
+	}
+
+	return nil
+}
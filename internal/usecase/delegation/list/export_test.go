@@ -0,0 +1,110 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/delegation/list/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestExportUseCase_ExportDelegations(t *testing.T) {
+	t.Parallel()
+
+	delegations := []model.Delegation{
+		{
+			Datetime:  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+			Amount:    decimal.RequireFromString("9856354"),
+			Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+			Height:    1461334,
+			TxHash:    "hash_1",
+		},
+		{
+			Datetime:  time.Date(2021, 6, 5, 6, 29, 14, 0, time.UTC),
+			Amount:    decimal.RequireFromString("125896"),
+			Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+			Height:    1500000,
+			TxHash:    "hash_2",
+		},
+	}
+
+	stream := func(_ context.Context, _ model.DelegationFilter, _ model.Sort, fn func(model.Delegation) error) error {
+		for _, d := range delegations {
+			if err := fn(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run(
+		"every delegation", func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewDelegationStreamer(t)
+			repo.EXPECT().StreamDelegations(
+				context.Background(), model.DelegationFilter{Year: 2021}, model.Sort{Field: "level", Order: "asc"}, mock.Anything,
+			).RunAndReturn(stream)
+
+			var got []DelegationData
+			err := NewExportUseCase(repo).ExportDelegations(
+				context.Background(),
+				Input{Year: 2021, Sort: api.Sort{Field: "level", Order: "asc"}, Pagination: api.Pagination{PageSize: 1}},
+				func(d DelegationData) error {
+					got = append(got, d)
+					return nil
+				},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, buildDelegationsData(delegations), got)
+		},
+	)
+
+	t.Run(
+		"consumer error", func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewDelegationStreamer(t)
+			repo.EXPECT().StreamDelegations(
+				context.Background(), model.DelegationFilter{}, model.Sort{Field: "timestamp", Order: "desc"}, mock.Anything,
+			).RunAndReturn(stream)
+
+			var (
+				calls   int
+				wantErr = errors.New("client gone")
+			)
+
+			err := NewExportUseCase(repo).ExportDelegations(
+				context.Background(), Input{}, func(DelegationData) error {
+					calls++
+					return wantErr
+				},
+			)
+
+			assert.ErrorIs(t, err, wantErr)
+			assert.Equal(t, 1, calls)
+		},
+	)
+
+	t.Run(
+		"invalid input", func(t *testing.T) {
+			t.Parallel()
+
+			err := NewExportUseCase(nil).ExportDelegations(
+				context.Background(), Input{Year: 1990}, func(DelegationData) error { return nil },
+			)
+
+			var apiErr *api.Error
+			assert.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, api.InvalidArgument, apiErr.Code)
+		},
+	)
+}
@@ -7,10 +7,20 @@ import (
 
 	"kiln-exercice/internal/model"
 	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tezos"
 )
 
+const (
+	maxDelegators = 50
+	minYear       = 2018
+)
+
+// SortFields lists the fields delegations can be sorted by, the first one being the default.
+var SortFields = []string{"timestamp", "amount", "level"}
+
 type DelegationRepository interface {
-	ListDelegations(ctx context.Context, year, offset, limit int) ([]model.Delegation, error)
+	ListDelegations(ctx context.Context, filter model.DelegationFilter, page model.DelegationPage) ([]model.Delegation, error)
+	CountDelegations(ctx context.Context, filter model.DelegationFilter) (count int64, estimated bool, err error)
 }
 
 type UseCase struct {
@@ -23,18 +33,107 @@ func NewUseCase(delegationRepo DelegationRepository) *UseCase {
 	}
 }
 
-// ListDelegations returns a list of delegations from the repository.
+// ListDelegations returns a page of delegations from the repository.
+// Pages are selected either by offset or, when the input holds a cursor, by keyset. Only the pages without cursor
+// are counted, unless the input skips it.
 func (uc *UseCase) ListDelegations(ctx context.Context, input Input) (Output, error) {
-	// This could be checked in an OpenAPI spec.
-	// Arbitrary year range.
-	if input.Year != 0 && (input.Year > time.Now().Year() || input.Year < 2018) {
-		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid year: %d", input.Year), nil)
+	if err := validateInput(input); err != nil {
+		return Output{}, err
+	}
+
+	page, err := input.page()
+	if err != nil {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid cursor: %s", err.Error()), err)
 	}
 
-	delegations, err := uc.DelegationRepo.ListDelegations(ctx, input.Year, input.Offset(), input.Limit())
+	delegations, err := uc.DelegationRepo.ListDelegations(ctx, input.filter(), page)
 	if err != nil {
 		return Output{}, api.NewError(api.Unknown, "error listing delegations", err)
 	}
 
-	return buildOutput(delegations), nil
+	var nextCursor string
+	if input.Limit() != 0 && len(delegations) > input.Limit() {
+		delegations = delegations[:input.Limit()]
+
+		nextCursor, err = encodeCursor(page.Sort, delegations[len(delegations)-1])
+		if err != nil {
+			return Output{}, api.NewError(api.Unknown, "error encoding cursor", err)
+		}
+	}
+
+	out := Output{
+		Delegations: buildDelegationsData(delegations),
+		NextCursor:  nextCursor,
+	}
+
+	// Pages fetched with a cursor follow a first page, which was counted.
+	if input.Cursor != "" || input.SkipCount {
+		return out, nil
+	}
+
+	out.TotalCount, out.TotalCountEstimated, err = uc.count(ctx, input)
+
+	return out, err
+}
+
+// CountDelegations returns the number of delegations matching the filters of the input, and whether it is an
+// estimate, for the clients counting them apart from their pages.
+func (uc *UseCase) CountDelegations(ctx context.Context, input Input) (int64, bool, error) {
+	if err := validateInput(input); err != nil {
+		return 0, false, err
+	}
+
+	return uc.count(ctx, input)
+}
+
+func (uc *UseCase) count(ctx context.Context, input Input) (int64, bool, error) {
+	count, estimated, err := uc.DelegationRepo.CountDelegations(ctx, input.filter())
+	if err != nil {
+		return 0, false, api.NewError(api.Unknown, "error counting delegations", err)
+	}
+
+	return count, estimated, nil
+}
+
+// validateInput checks the filters of the input.
+// The OpenAPI document of the HTTP API checks most of them too, but not the gRPC and GraphQL APIs.
+func validateInput(input Input) error {
+	// Arbitrary year range.
+	if input.Year != 0 && (input.Year > time.Now().Year() || input.Year < minYear) {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid year: %d", input.Year), nil)
+	}
+
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid time range: from %s must be before to %s", input.From.Format(time.RFC3339), input.To.Format(time.RFC3339)),
+			nil,
+		)
+	}
+
+	if input.MinLevel < 0 || input.MaxLevel < 0 {
+		return api.NewError(api.InvalidArgument, "invalid level range: levels must be positive", nil)
+	}
+
+	if input.MinLevel != 0 && input.MaxLevel != 0 && input.MinLevel > input.MaxLevel {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid level range: min_level %d must not exceed max_level %d", input.MinLevel, input.MaxLevel),
+			nil,
+		)
+	}
+
+	if len(input.Delegators) > maxDelegators {
+		return api.NewError(
+			api.InvalidArgument, fmt.Sprintf("too many delegators: %d, maximum is %d", len(input.Delegators), maxDelegators), nil,
+		)
+	}
+
+	for _, delegator := range input.Delegators {
+		if err := tezos.ValidateAddress(delegator); err != nil {
+			return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid delegator: %s", err.Error()), err)
+		}
+	}
+
+	return nil
 }
@@ -3,6 +3,7 @@ package list
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -54,6 +55,7 @@ func TestUseCase_ListDelegations(t *testing.T) {
 				ctx: context.Background(),
 				input: Input{
 					Year: 2021,
+					Sort: api.Sort{Field: "amount", Order: "asc"},
 					Pagination: api.Pagination{
 						PageNumber: 1,
 						PageSize:   10,
@@ -62,7 +64,9 @@ func TestUseCase_ListDelegations(t *testing.T) {
 			},
 			init: func(e *env) {
 				e.DelegationRepo.EXPECT().ListDelegations(
-					context.Background(), 2021, 0, 10,
+					context.Background(),
+					model.DelegationFilter{Year: 2021},
+					model.DelegationPage{Sort: model.Sort{Field: "amount", Order: "asc"}, Offset: 0, Limit: 11},
 				).Return(
 					[]model.Delegation{
 						{
@@ -80,23 +84,252 @@ func TestUseCase_ListDelegations(t *testing.T) {
 					},
 					nil,
 				)
+				e.DelegationRepo.EXPECT().CountDelegations(context.Background(), model.DelegationFilter{Year: 2021}).
+					Return(2, false, nil)
 			},
 			want: Output{
-				{
-					Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
-					Amount:    decimal.RequireFromString("125896"),
-					Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
-					Level:     "2338084",
+				TotalCount: 2,
+				Delegations: []DelegationData{
+					{
+						Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+						Amount:    decimal.RequireFromString("125896"),
+						Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						Level:     "2338084",
+						Height:    2338084,
+					},
+					{
+						Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+						Amount:    decimal.RequireFromString("9856354"),
+						Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+						Level:     "1461334",
+						Height:    1461334,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "next page cursor",
+			env: env{
+				DelegationRepo: mocks.NewDelegationRepository(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Pagination: api.Pagination{
+						PageNumber: 1,
+						PageSize:   1,
+					},
+				},
+			},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegations(
+					context.Background(),
+					model.DelegationFilter{},
+					model.DelegationPage{Sort: model.Sort{Field: "timestamp", Order: "desc"}, Offset: 0, Limit: 2},
+				).Return(
+					[]model.Delegation{
+						{
+							ID:        2,
+							Datetime:  time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+							Amount:    decimal.RequireFromString("125896"),
+							Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+							Height:    2338084,
+						},
+						{
+							ID:        1,
+							Datetime:  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+							Amount:    decimal.RequireFromString("9856354"),
+							Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
+							Height:    1461334,
+						},
+					},
+					nil,
+				)
+				e.DelegationRepo.EXPECT().CountDelegations(context.Background(), model.DelegationFilter{}).
+					Return(1000, true, nil)
+			},
+			want: Output{
+				TotalCount:          1000,
+				TotalCountEstimated: true,
+				Delegations: []DelegationData{
+					{
+						Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
+						Amount:    decimal.RequireFromString("125896"),
+						Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+						Level:     "2338084",
+						Height:    2338084,
+					},
+				},
+				// {"s":"timestamp","o":"desc","v":"2022-05-05T06:29:14Z","i":2}
+				NextCursor: "eyJzIjoidGltZXN0YW1wIiwibyI6ImRlc2MiLCJ2IjoiMjAyMi0wNS0wNVQwNjoyOToxNFoiLCJpIjoyfQ",
+			},
+			wantErr: false,
+		},
+		{
+			name: "with cursor",
+			env: env{
+				DelegationRepo: mocks.NewDelegationRepository(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Pagination: api.Pagination{
+						PageNumber: 1,
+						PageSize:   1,
+						Cursor:     "eyJzIjoidGltZXN0YW1wIiwibyI6ImRlc2MiLCJ2IjoiMjAyMi0wNS0wNVQwNjoyOToxNFoiLCJpIjoyfQ",
+					},
+				},
+			},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegations(
+					context.Background(),
+					model.DelegationFilter{},
+					model.DelegationPage{
+						Sort:  model.Sort{Field: "timestamp", Order: "desc"},
+						Limit: 2,
+						After: &model.Delegation{ID: 2, Datetime: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC)},
+					},
+				).Return([]model.Delegation{}, nil)
+			},
+			want:    Output{Delegations: []DelegationData{}},
+			wantErr: false,
+		},
+		{
+			name: "count skipped",
+			env: env{
+				DelegationRepo: mocks.NewDelegationRepository(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				input: Input{SkipCount: true, Pagination: api.Pagination{PageNumber: 1, PageSize: 1}},
+			},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegations(
+					context.Background(),
+					model.DelegationFilter{},
+					model.DelegationPage{Sort: model.Sort{Field: "timestamp", Order: "desc"}, Limit: 2},
+				).Return([]model.Delegation{}, nil)
+			},
+			want:    Output{Delegations: []DelegationData{}},
+			wantErr: false,
+		},
+		{
+			name: "cursor from another sort",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Sort: api.Sort{Field: "amount", Order: "desc"},
+					Pagination: api.Pagination{
+						PageNumber: 1,
+						PageSize:   1,
+						Cursor:     "eyJzIjoidGltZXN0YW1wIiwibyI6ImRlc2MiLCJ2IjoiMjAyMi0wNS0wNVQwNjoyOToxNFoiLCJpIjoyfQ",
+					},
+				},
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
+		{
+			name: "invalid cursor",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Pagination: api.Pagination{
+						PageNumber: 1,
+						PageSize:   1,
+						Cursor:     "not a cursor",
+					},
 				},
-				{
-					Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
-					Amount:    decimal.RequireFromString("9856354"),
-					Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
-					Level:     "1461334",
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
+		{
+			name: "filter by delegators",
+			env: env{
+				DelegationRepo: mocks.NewDelegationRepository(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"},
+					Pagination: api.Pagination{
+						PageNumber: 2,
+						PageSize:   10,
+					},
 				},
 			},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().ListDelegations(
+					context.Background(),
+					model.DelegationFilter{
+						Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"},
+					},
+					model.DelegationPage{Sort: model.Sort{Field: "timestamp", Order: "desc"}, Offset: 10, Limit: 11},
+				).Return([]model.Delegation{}, nil)
+				e.DelegationRepo.EXPECT().CountDelegations(
+					context.Background(),
+					model.DelegationFilter{
+						Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"},
+					},
+				).Return(0, false, nil)
+			},
+			want:    Output{Delegations: []DelegationData{}},
 			wantErr: false,
 		},
+		{
+			name: "invalid delegator",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTM"},
+				},
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
+		{
+			name: "invalid time range",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					From: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
+		{
+			name: "invalid level range",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					MinLevel: 200,
+					MaxLevel: 100,
+				},
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
+		{
+			name: "negative level",
+			args: args{
+				ctx: context.Background(),
+				input: Input{
+					MinLevel: -1,
+				},
+			},
+			init:    func(e *env) {},
+			want:    Output{},
+			wantErr: true,
+		},
 		{
 			name: "invalid year",
 			args: args{
@@ -134,3 +367,67 @@ func TestUseCase_ListDelegations(t *testing.T) {
 		)
 	}
 }
+
+func TestUseCase_CountDelegations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         Input
+		init          func(*mocks.DelegationRepository)
+		wantCount     int64
+		wantEstimated bool
+		wantErr       bool
+	}{
+		{
+			name: "count",
+			input: Input{
+				Year:       2021,
+				Pagination: api.Pagination{Cursor: "eyJzIjoidGltZXN0YW1wIiwibyI6ImRlc2MiLCJ2IjoiMjAyMi0wNS0wNVQwNjoyOToxNFoiLCJpIjoyfQ"},
+			},
+			init: func(repo *mocks.DelegationRepository) {
+				repo.EXPECT().CountDelegations(context.Background(), model.DelegationFilter{Year: 2021}).
+					Return(1000, true, nil)
+			},
+			wantCount:     1000,
+			wantEstimated: true,
+		},
+		{
+			name: "repository error",
+			init: func(repo *mocks.DelegationRepository) {
+				repo.EXPECT().CountDelegations(context.Background(), model.DelegationFilter{}).
+					Return(0, false, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid year",
+			input:   Input{Year: 1900},
+			init:    func(*mocks.DelegationRepository) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				repo := mocks.NewDelegationRepository(t)
+				tt.init(repo)
+
+				count, estimated, err := NewUseCase(repo).CountDelegations(context.Background(), tt.input)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("CountDelegations() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				if count != tt.wantCount || estimated != tt.wantEstimated {
+					t.Errorf(
+						"CountDelegations() = %d, %v, want %d, %v", count, estimated, tt.wantCount, tt.wantEstimated,
+					)
+				}
+			},
+		)
+	}
+}
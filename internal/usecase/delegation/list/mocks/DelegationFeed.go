@@ -0,0 +1,155 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// DelegationFeed is an autogenerated mock type for the DelegationFeed type
+type DelegationFeed struct {
+	mock.Mock
+}
+
+type DelegationFeed_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationFeed) EXPECT() *DelegationFeed_Expecter {
+	return &DelegationFeed_Expecter{mock: &_m.Mock}
+}
+
+// GetLastDelegationID provides a mock function with given fields: ctx
+func (_m *DelegationFeed) GetLastDelegationID(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastDelegationID")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationFeed_GetLastDelegationID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastDelegationID'
+type DelegationFeed_GetLastDelegationID_Call struct {
+	*mock.Call
+}
+
+// GetLastDelegationID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DelegationFeed_Expecter) GetLastDelegationID(ctx interface{}) *DelegationFeed_GetLastDelegationID_Call {
+	return &DelegationFeed_GetLastDelegationID_Call{Call: _e.mock.On("GetLastDelegationID", ctx)}
+}
+
+func (_c *DelegationFeed_GetLastDelegationID_Call) Run(run func(ctx context.Context)) *DelegationFeed_GetLastDelegationID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DelegationFeed_GetLastDelegationID_Call) Return(_a0 int, _a1 error) *DelegationFeed_GetLastDelegationID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationFeed_GetLastDelegationID_Call) RunAndReturn(run func(context.Context) (int, error)) *DelegationFeed_GetLastDelegationID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDelegationsAfterID provides a mock function with given fields: ctx, filter, afterID, limit
+func (_m *DelegationFeed) ListDelegationsAfterID(ctx context.Context, filter model.DelegationFilter, afterID int, limit int) ([]model.Delegation, error) {
+	ret := _m.Called(ctx, filter, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDelegationsAfterID")
+	}
+
+	var r0 []model.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, int, int) ([]model.Delegation, error)); ok {
+		return rf(ctx, filter, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, int, int) []model.Delegation); ok {
+		r0 = rf(ctx, filter, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DelegationFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationFeed_ListDelegationsAfterID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDelegationsAfterID'
+type DelegationFeed_ListDelegationsAfterID_Call struct {
+	*mock.Call
+}
+
+// ListDelegationsAfterID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.DelegationFilter
+//   - afterID int
+//   - limit int
+func (_e *DelegationFeed_Expecter) ListDelegationsAfterID(ctx interface{}, filter interface{}, afterID interface{}, limit interface{}) *DelegationFeed_ListDelegationsAfterID_Call {
+	return &DelegationFeed_ListDelegationsAfterID_Call{Call: _e.mock.On("ListDelegationsAfterID", ctx, filter, afterID, limit)}
+}
+
+func (_c *DelegationFeed_ListDelegationsAfterID_Call) Run(run func(ctx context.Context, filter model.DelegationFilter, afterID int, limit int)) *DelegationFeed_ListDelegationsAfterID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.DelegationFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *DelegationFeed_ListDelegationsAfterID_Call) Return(_a0 []model.Delegation, _a1 error) *DelegationFeed_ListDelegationsAfterID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationFeed_ListDelegationsAfterID_Call) RunAndReturn(run func(context.Context, model.DelegationFilter, int, int) ([]model.Delegation, error)) *DelegationFeed_ListDelegationsAfterID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationFeed creates a new instance of DelegationFeed. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationFeed(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationFeed {
+	mock := &DelegationFeed{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -23,9 +23,73 @@ func (_m *DelegationRepository) EXPECT() *DelegationRepository_Expecter {
 	return &DelegationRepository_Expecter{mock: &_m.Mock}
 }
 
-// ListDelegations provides a mock function with given fields: ctx, year, offset, limit
-func (_m *DelegationRepository) ListDelegations(ctx context.Context, year int, offset int, limit int) ([]model.Delegation, error) {
-	ret := _m.Called(ctx, year, offset, limit)
+// CountDelegations provides a mock function with given fields: ctx, filter
+func (_m *DelegationRepository) CountDelegations(ctx context.Context, filter model.DelegationFilter) (int64, bool, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDelegations")
+	}
+
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter) (int64, bool, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DelegationFilter) bool); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.DelegationFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DelegationRepository_CountDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDelegations'
+type DelegationRepository_CountDelegations_Call struct {
+	*mock.Call
+}
+
+// CountDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.DelegationFilter
+func (_e *DelegationRepository_Expecter) CountDelegations(ctx interface{}, filter interface{}) *DelegationRepository_CountDelegations_Call {
+	return &DelegationRepository_CountDelegations_Call{Call: _e.mock.On("CountDelegations", ctx, filter)}
+}
+
+func (_c *DelegationRepository_CountDelegations_Call) Run(run func(ctx context.Context, filter model.DelegationFilter)) *DelegationRepository_CountDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.DelegationFilter))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_CountDelegations_Call) Return(count int64, estimated bool, err error) *DelegationRepository_CountDelegations_Call {
+	_c.Call.Return(count, estimated, err)
+	return _c
+}
+
+func (_c *DelegationRepository_CountDelegations_Call) RunAndReturn(run func(context.Context, model.DelegationFilter) (int64, bool, error)) *DelegationRepository_CountDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDelegations provides a mock function with given fields: ctx, filter, page
+func (_m *DelegationRepository) ListDelegations(ctx context.Context, filter model.DelegationFilter, page model.DelegationPage) ([]model.Delegation, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDelegations")
@@ -33,19 +97,19 @@ func (_m *DelegationRepository) ListDelegations(ctx context.Context, year int, o
 
 	var r0 []model.Delegation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]model.Delegation, error)); ok {
-		return rf(ctx, year, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, model.DelegationPage) ([]model.Delegation, error)); ok {
+		return rf(ctx, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []model.Delegation); ok {
-		r0 = rf(ctx, year, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, model.DelegationPage) []model.Delegation); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Delegation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
-		r1 = rf(ctx, year, offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, model.DelegationFilter, model.DelegationPage) error); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,16 +124,15 @@ type DelegationRepository_ListDelegations_Call struct {
 
 // ListDelegations is a helper method to define mock.On call
 //   - ctx context.Context
-//   - year int
-//   - offset int
-//   - limit int
-func (_e *DelegationRepository_Expecter) ListDelegations(ctx interface{}, year interface{}, offset interface{}, limit interface{}) *DelegationRepository_ListDelegations_Call {
-	return &DelegationRepository_ListDelegations_Call{Call: _e.mock.On("ListDelegations", ctx, year, offset, limit)}
+//   - filter model.DelegationFilter
+//   - page model.DelegationPage
+func (_e *DelegationRepository_Expecter) ListDelegations(ctx interface{}, filter interface{}, page interface{}) *DelegationRepository_ListDelegations_Call {
+	return &DelegationRepository_ListDelegations_Call{Call: _e.mock.On("ListDelegations", ctx, filter, page)}
 }
 
-func (_c *DelegationRepository_ListDelegations_Call) Run(run func(ctx context.Context, year int, offset int, limit int)) *DelegationRepository_ListDelegations_Call {
+func (_c *DelegationRepository_ListDelegations_Call) Run(run func(ctx context.Context, filter model.DelegationFilter, page model.DelegationPage)) *DelegationRepository_ListDelegations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(int))
+		run(args[0].(context.Context), args[1].(model.DelegationFilter), args[2].(model.DelegationPage))
 	})
 	return _c
 }
@@ -79,7 +142,7 @@ func (_c *DelegationRepository_ListDelegations_Call) Return(_a0 []model.Delegati
 	return _c
 }
 
-func (_c *DelegationRepository_ListDelegations_Call) RunAndReturn(run func(context.Context, int, int, int) ([]model.Delegation, error)) *DelegationRepository_ListDelegations_Call {
+func (_c *DelegationRepository_ListDelegations_Call) RunAndReturn(run func(context.Context, model.DelegationFilter, model.DelegationPage) ([]model.Delegation, error)) *DelegationRepository_ListDelegations_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// DelegationStreamer is an autogenerated mock type for the DelegationStreamer type
+type DelegationStreamer struct {
+	mock.Mock
+}
+
+type DelegationStreamer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationStreamer) EXPECT() *DelegationStreamer_Expecter {
+	return &DelegationStreamer_Expecter{mock: &_m.Mock}
+}
+
+// StreamDelegations provides a mock function with given fields: ctx, filter, sort, fn
+func (_m *DelegationStreamer) StreamDelegations(ctx context.Context, filter model.DelegationFilter, sort model.Sort, fn func(model.Delegation) error) error {
+	ret := _m.Called(ctx, filter, sort, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamDelegations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, model.Sort, func(model.Delegation) error) error); ok {
+		r0 = rf(ctx, filter, sort, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DelegationStreamer_StreamDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamDelegations'
+type DelegationStreamer_StreamDelegations_Call struct {
+	*mock.Call
+}
+
+// StreamDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.DelegationFilter
+//   - sort model.Sort
+//   - fn func(model.Delegation) error
+func (_e *DelegationStreamer_Expecter) StreamDelegations(ctx interface{}, filter interface{}, sort interface{}, fn interface{}) *DelegationStreamer_StreamDelegations_Call {
+	return &DelegationStreamer_StreamDelegations_Call{Call: _e.mock.On("StreamDelegations", ctx, filter, sort, fn)}
+}
+
+func (_c *DelegationStreamer_StreamDelegations_Call) Run(run func(ctx context.Context, filter model.DelegationFilter, sort model.Sort, fn func(model.Delegation) error)) *DelegationStreamer_StreamDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.DelegationFilter), args[2].(model.Sort), args[3].(func(model.Delegation) error))
+	})
+	return _c
+}
+
+func (_c *DelegationStreamer_StreamDelegations_Call) Return(_a0 error) *DelegationStreamer_StreamDelegations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DelegationStreamer_StreamDelegations_Call) RunAndReturn(run func(context.Context, model.DelegationFilter, model.Sort, func(model.Delegation) error) error) *DelegationStreamer_StreamDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationStreamer creates a new instance of DelegationStreamer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationStreamer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationStreamer {
+	mock := &DelegationStreamer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+type Notifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifier) EXPECT() *Notifier_Expecter {
+	return &Notifier_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with no fields
+func (_m *Notifier) Subscribe() (<-chan struct{}, func()) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan struct{}
+	var r1 func()
+	if rf, ok := ret.Get(0).(func() (<-chan struct{}, func())); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() <-chan struct{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() func()); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
+
+// Notifier_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type Notifier_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+func (_e *Notifier_Expecter) Subscribe() *Notifier_Subscribe_Call {
+	return &Notifier_Subscribe_Call{Call: _e.mock.On("Subscribe")}
+}
+
+func (_c *Notifier_Subscribe_Call) Run(run func()) *Notifier_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Notifier_Subscribe_Call) Return(notifications <-chan struct{}, unsubscribe func()) *Notifier_Subscribe_Call {
+	_c.Call.Return(notifications, unsubscribe)
+	return _c
+}
+
+func (_c *Notifier_Subscribe_Call) RunAndReturn(run func() (<-chan struct{}, func())) *Notifier_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -11,30 +11,110 @@ import (
 )
 
 type Input struct {
-	Year int
+	Year       int
+	From       time.Time
+	To         time.Time
+	MinLevel   int
+	MaxLevel   int
+	Delegators []string
+	Sort       api.Sort
+	SkipCount  bool // For the clients not returning the count, or counting apart with CountDelegations.
 	api.Pagination
 }
 
-type Output = []DelegationData
+func (in Input) filter() model.DelegationFilter {
+	return model.DelegationFilter{
+		Year:       in.Year,
+		From:       in.From,
+		To:         in.To,
+		MinLevel:   in.MinLevel,
+		MaxLevel:   in.MaxLevel,
+		Delegators: in.Delegators,
+	}
+}
+
+// sort returns the sort of the input, defaulting to the first sort field in descending order.
+func (in Input) sort() model.Sort {
+	sort := model.Sort{
+		Field: in.Sort.Field,
+		Order: in.Sort.Order,
+	}
+
+	if sort.Field == "" {
+		sort.Field = SortFields[0]
+	}
+
+	if sort.Order == "" {
+		sort.Order = api.OrderDesc
+	}
+
+	return sort
+}
+
+// page returns the page of delegations to fetch.
+// One more delegation than requested is fetched to know whether there is a next page.
+func (in Input) page() (model.DelegationPage, error) {
+	page := model.DelegationPage{
+		Sort:   in.sort(),
+		Offset: in.Offset(),
+	}
+
+	if in.Limit() != 0 {
+		page.Limit = in.Limit() + 1
+	}
+
+	if in.Cursor != "" {
+		after, err := decodeCursor(in.Cursor, page.Sort)
+		if err != nil {
+			return model.DelegationPage{}, err
+		}
+
+		page.After = after
+	}
+
+	return page, nil
+}
 
+type Output struct {
+	Delegations         []DelegationData
+	NextCursor          string // Empty on the last page.
+	TotalCount          int64  // Zero when not counted: on the pages fetched with a cursor, or when skipped.
+	TotalCountEstimated bool
+}
+
+// DelegationData is a delegation as represented by the v1 API, which is frozen: fields cannot be added to its JSON.
 type DelegationData struct {
-	Timestamp time.Time       `json:"timestamp"`
-	Amount    decimal.Decimal `json:"amount"`
-	Delegator string          `json:"delegator"`
-	Level     string          `json:"level"`
+	Hash         string          `json:"hash"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Amount       decimal.Decimal `json:"amount"` // In mutez.
+	Delegator    string          `json:"delegator"`
+	Level        string          `json:"level"`
+	Height       int             `json:"-"` // Level as represented from v2.
+	Baker        string          `json:"-"` // Only represented from v2, empty for undelegations.
+	BakerUnknown bool            `json:"-"` // Only represented from v2.
+	Quote        model.Quote     `json:"-"` // Only represented from v2.
 }
 
-func buildOutput(delegations []model.Delegation) Output {
+func buildDelegationsData(delegations []model.Delegation) []DelegationData {
 	out := make([]DelegationData, len(delegations))
 
 	for i, d := range delegations {
-		out[i] = DelegationData{
-			Timestamp: d.Datetime,
-			Amount:    d.Amount,
-			Delegator: d.Delegator,
-			Level:     strconv.Itoa(d.Height),
-		}
+		out[i] = buildDelegationData(d)
 	}
 
 	return out
 }
+
+func buildDelegationData(d model.Delegation) DelegationData {
+	return DelegationData{
+		Hash:         d.TxHash,
+		Timestamp:    d.Datetime,
+		Amount:       d.Amount,
+		Delegator:    d.Delegator,
+		Level:        strconv.Itoa(d.Height),
+		Height:       d.Height,
+		Baker:        d.Baker,
+		BakerUnknown: d.BakerUnknown,
+		Quote:        d.Quote,
+	}
+}
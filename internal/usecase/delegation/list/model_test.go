@@ -10,40 +10,46 @@ import (
 	"kiln-exercice/internal/model"
 )
 
-func TestBuildOutput(t *testing.T) {
+func TestBuildDelegationsData(t *testing.T) {
 	delegations := []model.Delegation{
 		{
 			Datetime:  time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
 			Amount:    decimal.RequireFromString("125896"),
 			Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
 			Height:    2338084,
+			TxHash:    "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
 		},
 		{
 			Datetime:  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
 			Amount:    decimal.RequireFromString("9856354"),
 			Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
 			Height:    1461334,
+			TxHash:    "ooedoJWn6fFXaiCkNDftRVCvEbJ855M7fD7gzHryL6x6FXdejP4",
 		},
 	}
 
-	expected := Output{
+	expected := []DelegationData{
 		{
+			Hash:      "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b",
 			Timestamp: time.Date(2022, 5, 5, 6, 29, 14, 0, time.UTC),
 			Amount:    decimal.RequireFromString("125896"),
 			Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
 			Level:     "2338084",
+			Height:    2338084,
 		},
 		{
+			Hash:      "ooedoJWn6fFXaiCkNDftRVCvEbJ855M7fD7gzHryL6x6FXdejP4",
 			Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
 			Amount:    decimal.RequireFromString("9856354"),
 			Delegator: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf",
 			Level:     "1461334",
+			Height:    1461334,
 		},
 	}
 
-	result := buildOutput(delegations)
+	result := buildDelegationsData(delegations)
 
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("buildOutput() = %v, want %v", result, expected)
+		t.Errorf("buildDelegationsData() = %v, want %v", result, expected)
 	}
 }
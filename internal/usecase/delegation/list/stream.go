@@ -0,0 +1,139 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// streamBatchSize is the maximum number of delegations read at once while catching up.
+const streamBatchSize = 1000
+
+type DelegationFeed interface {
+	ListDelegationsAfterID(ctx context.Context, filter model.DelegationFilter, afterID, limit int) ([]model.Delegation, error)
+	GetLastDelegationID(ctx context.Context) (int, error)
+}
+
+type Notifier interface {
+	Subscribe() (notifications <-chan struct{}, unsubscribe func())
+}
+
+type StreamUseCase struct {
+	DelegationFeed DelegationFeed
+	Notifier       Notifier
+	Heartbeat      time.Duration
+}
+
+func NewStreamUseCase(delegationFeed DelegationFeed, notifier Notifier, heartbeat time.Duration) *StreamUseCase {
+	return &StreamUseCase{
+		DelegationFeed: delegationFeed,
+		Notifier:       notifier,
+		Heartbeat:      heartbeat,
+	}
+}
+
+// StreamInput selects the delegations to stream with the filters of the list, and where to resume.
+type StreamInput struct {
+	Input
+	LastEventID *int // Resumes after this delegation. Only new delegations are streamed when nil.
+}
+
+// StreamEvent is a delegation sent to a stream, identified by its insertion order.
+type StreamEvent struct {
+	ID         int
+	Delegation DelegationData
+}
+
+// StreamDelegations calls fn with the delegations matching the input filters as soon as they are inserted,
+// until ctx is done or the notifier is closed. fn is called with no event once the stream is ready, then after
+// every heartbeat period without new delegation. The feed is also read on heartbeats, in case a notification
+// was missed.
+func (uc *StreamUseCase) StreamDelegations(ctx context.Context, input StreamInput, fn func([]StreamEvent) error) error {
+	if err := validateInput(input.Input); err != nil {
+		return err
+	}
+
+	// Subscribing before reading the last id, so that no insertion is missed in between.
+	notifications, unsubscribe := uc.Notifier.Subscribe()
+	defer unsubscribe()
+
+	var lastID int
+	if input.LastEventID != nil {
+		lastID = *input.LastEventID
+	} else {
+		id, err := uc.DelegationFeed.GetLastDelegationID(ctx)
+		if err != nil {
+			return api.NewError(api.Unknown, "error getting last delegation", err)
+		}
+
+		lastID = id
+	}
+
+	if err := fn(nil); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(uc.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		sent, err := uc.catchUp(ctx, input.filter(), &lastID, fn)
+		if err != nil {
+			return err
+		}
+
+		if sent {
+			heartbeat.Reset(uc.Heartbeat)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+		case <-heartbeat.C:
+			if err = fn(nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// catchUp sends every delegation inserted after lastID, moving it forward. It reports whether any was sent.
+func (uc *StreamUseCase) catchUp(
+	ctx context.Context, filter model.DelegationFilter, lastID *int, fn func([]StreamEvent) error,
+) (sent bool, err error) {
+	for {
+		delegations, err := uc.DelegationFeed.ListDelegationsAfterID(ctx, filter, *lastID, streamBatchSize)
+		if ctx.Err() != nil {
+			return sent, nil
+		}
+		if err != nil {
+			return sent, api.NewError(api.Unknown, "error listing new delegations", err)
+		}
+
+		if len(delegations) == 0 {
+			return sent, nil
+		}
+
+		events := make([]StreamEvent, len(delegations))
+		for i, d := range delegations {
+			events[i] = StreamEvent{ID: d.ID, Delegation: buildDelegationData(d)}
+		}
+
+		if err = fn(events); err != nil {
+			return sent, err
+		}
+
+		sent = true
+		*lastID = delegations[len(delegations)-1].ID
+
+		if len(delegations) < streamBatchSize {
+			return sent, nil
+		}
+	}
+}
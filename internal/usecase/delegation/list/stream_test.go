@@ -0,0 +1,148 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/delegation/list/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestStreamUseCase_StreamDelegations(t *testing.T) {
+	t.Parallel()
+
+	delegations := []model.Delegation{
+		{
+			ID:        6,
+			Datetime:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+			Amount:    decimal.RequireFromString("125896"),
+			Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+			Height:    2338084,
+			TxHash:    "hash_6",
+		},
+		{
+			ID:        9,
+			Datetime:  time.Date(2024, 5, 5, 6, 29, 44, 0, time.UTC),
+			Amount:    decimal.RequireFromString("1"),
+			Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+			Height:    2338085,
+			TxHash:    "hash_9",
+		},
+	}
+
+	filter := model.DelegationFilter{Delegators: []string{"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"}}
+
+	t.Run(
+		"resume until the notifier closes", func(t *testing.T) {
+			t.Parallel()
+
+			notifications := make(chan struct{}, 1)
+			notifications <- struct{}{}
+
+			feed := mocks.NewDelegationFeed(t)
+			feed.EXPECT().ListDelegationsAfterID(context.Background(), filter, 5, streamBatchSize).Return(delegations[:1], nil).Once()
+			feed.EXPECT().ListDelegationsAfterID(context.Background(), filter, 6, streamBatchSize).
+				RunAndReturn(
+					func(context.Context, model.DelegationFilter, int, int) ([]model.Delegation, error) {
+						close(notifications) // The server shuts down after this read.
+						return delegations[1:], nil
+					},
+				).Once()
+
+			notifier := mocks.NewNotifier(t)
+			notifier.EXPECT().Subscribe().Return(notifications, func() {})
+
+			var got [][]StreamEvent
+
+			lastEventID := 5
+			err := NewStreamUseCase(feed, notifier, time.Hour).StreamDelegations(
+				context.Background(),
+				StreamInput{Input: Input{Delegators: filter.Delegators}, LastEventID: &lastEventID},
+				func(events []StreamEvent) error {
+					got = append(got, events)
+					return nil
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(
+				t, [][]StreamEvent{
+					nil,
+					{{ID: 6, Delegation: buildDelegationData(delegations[0])}},
+					{{ID: 9, Delegation: buildDelegationData(delegations[1])}},
+				}, got,
+			)
+		},
+	)
+
+	t.Run(
+		"heartbeat on new stream", func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			feed := mocks.NewDelegationFeed(t)
+			feed.EXPECT().GetLastDelegationID(ctx).Return(9, nil)
+			feed.EXPECT().ListDelegationsAfterID(ctx, model.DelegationFilter{}, 9, streamBatchSize).Return(nil, nil)
+
+			notifier := mocks.NewNotifier(t)
+			notifier.EXPECT().Subscribe().Return(make(chan struct{}), func() {})
+
+			var heartbeats int
+
+			err := NewStreamUseCase(feed, notifier, time.Millisecond).StreamDelegations(
+				ctx, StreamInput{}, func(events []StreamEvent) error {
+					assert.Empty(t, events)
+
+					// The first call signals the stream is ready, the next ones are heartbeats.
+					if heartbeats++; heartbeats == 3 {
+						cancel()
+					}
+					return nil
+				},
+			)
+			require.NoError(t, err)
+		},
+	)
+
+	t.Run(
+		"consumer error", func(t *testing.T) {
+			t.Parallel()
+
+			feed := mocks.NewDelegationFeed(t)
+			feed.EXPECT().GetLastDelegationID(context.Background()).Return(0, nil)
+
+			notifier := mocks.NewNotifier(t)
+			notifier.EXPECT().Subscribe().Return(make(chan struct{}), func() {})
+
+			wantErr := errors.New("client gone")
+
+			err := NewStreamUseCase(feed, notifier, time.Hour).StreamDelegations(
+				context.Background(), StreamInput{}, func([]StreamEvent) error { return wantErr },
+			)
+			assert.ErrorIs(t, err, wantErr)
+		},
+	)
+
+	t.Run(
+		"invalid input", func(t *testing.T) {
+			t.Parallel()
+
+			err := NewStreamUseCase(nil, nil, time.Hour).StreamDelegations(
+				context.Background(), StreamInput{Input: Input{MinLevel: -1}}, func([]StreamEvent) error { return nil },
+			)
+
+			var apiErr *api.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, api.InvalidArgument, apiErr.Code)
+		},
+	)
+}
@@ -1,6 +1,8 @@
 package poll
 
 import (
+	"github.com/shopspring/decimal"
+
 	"kiln-exercice/internal/model"
 	"kiln-exercice/pkg/tzkt"
 )
@@ -9,12 +11,42 @@ func convertToModelDelegations(delegations []tzkt.Delegation) []model.Delegation
 	var modelDelegations []model.Delegation
 	for _, d := range delegations {
 		modelDelegations = append(modelDelegations, model.Delegation{
-			Datetime:  d.Timestamp,
-			Amount:    d.Amount,
-			Delegator: d.Sender.Address,
-			Height:    d.Level,
-			TxHash:    d.Hash,
+			OperationID: int64(d.ID),
+			Datetime:    d.Timestamp,
+			Amount:      d.Amount,
+			Delegator:   d.Sender.Address,
+			Baker:       d.NewDelegate.Address,
+			Height:      d.Level,
+			TxHash:      d.Hash,
+			Quote:       convertToModelQuote(d.Quote),
 		})
 	}
 	return modelDelegations
 }
+
+// convertToModelQuote keeps the currencies of a quote having a price.
+func convertToModelQuote(q tzkt.Quote) model.Quote {
+	prices := map[string]decimal.Decimal{
+		"btc": q.Btc,
+		"eur": q.Eur,
+		"usd": q.Usd,
+		"cny": q.Cny,
+		"jpy": q.Jpy,
+		"krw": q.Krw,
+		"eth": q.Eth,
+		"gbp": q.Gbp,
+	}
+
+	quote := model.Quote{}
+	for currency, price := range prices {
+		if price.IsPositive() {
+			quote[currency] = price
+		}
+	}
+
+	if len(quote) == 0 {
+		return nil
+	}
+
+	return quote
+}
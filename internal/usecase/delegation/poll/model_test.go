@@ -1,6 +1,7 @@
 package poll
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -13,13 +14,20 @@ import (
 func TestConvertToModelDelegations(t *testing.T) {
 	delegations := []tzkt.Delegation{
 		{
-			Timestamp: time.Now(),
-			Amount:    decimal.RequireFromString("1000"),
-			Sender:    tzkt.Sender{Address: "tz1SenderAddress"},
-			Level:     1,
-			Hash:      "txHash1",
+			ID:          1,
+			Timestamp:   time.Now(),
+			Amount:      decimal.RequireFromString("1000"),
+			Sender:      tzkt.Sender{Address: "tz1SenderAddress"},
+			NewDelegate: tzkt.Delegate{Address: "tz1BakerAddress"},
+			Level:       1,
+			Hash:        "txHash1",
+			Quote: tzkt.Quote{
+				Eur: decimal.RequireFromString("0.93"),
+				Usd: decimal.RequireFromString("1.05"),
+			},
 		},
 		{
+			ID:        2,
 			Timestamp: time.Now().Add(time.Hour),
 			Amount:    decimal.RequireFromString("2000"),
 			Sender:    tzkt.Sender{Address: "tz1SenderAddress2"},
@@ -30,18 +38,26 @@ func TestConvertToModelDelegations(t *testing.T) {
 
 	expected := []model.Delegation{
 		{
-			Datetime:  delegations[0].Timestamp,
-			Amount:    delegations[0].Amount,
-			Delegator: delegations[0].Sender.Address,
-			Height:    delegations[0].Level,
-			TxHash:    delegations[0].Hash,
+			OperationID: 1,
+			Datetime:    delegations[0].Timestamp,
+			Amount:      delegations[0].Amount,
+			Delegator:   delegations[0].Sender.Address,
+			Baker:       delegations[0].NewDelegate.Address,
+			Height:      delegations[0].Level,
+			TxHash:      delegations[0].Hash,
+			Quote: model.Quote{
+				"eur": decimal.RequireFromString("0.93"),
+				"usd": decimal.RequireFromString("1.05"),
+			},
 		},
 		{
-			Datetime:  delegations[1].Timestamp,
-			Amount:    delegations[1].Amount,
-			Delegator: delegations[1].Sender.Address,
-			Height:    delegations[1].Level,
-			TxHash:    delegations[1].Hash,
+			OperationID: 2,
+			Datetime:    delegations[1].Timestamp,
+			Amount:      delegations[1].Amount,
+			Delegator:   delegations[1].Sender.Address,
+			Baker:       delegations[1].NewDelegate.Address,
+			Height:      delegations[1].Level,
+			TxHash:      delegations[1].Hash,
 		},
 	}
 
@@ -52,7 +68,7 @@ func TestConvertToModelDelegations(t *testing.T) {
 	}
 
 	for i, r := range result {
-		if r != expected[i] {
+		if !reflect.DeepEqual(r, expected[i]) {
 			t.Errorf("expected %v, got %v", expected[i], r)
 		}
 	}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "kiln-exercice/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationRepository is an autogenerated mock type for the DelegationRepository type
+type DelegationRepository struct {
+	mock.Mock
+}
+
+type DelegationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationRepository) EXPECT() *DelegationRepository_Expecter {
+	return &DelegationRepository_Expecter{mock: &_m.Mock}
+}
+
+// DelegationStats provides a mock function with given fields: ctx, filter, query
+func (_m *DelegationRepository) DelegationStats(ctx context.Context, filter model.DelegationFilter, query model.DelegationStatsQuery) ([]model.DelegationStats, error) {
+	ret := _m.Called(ctx, filter, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelegationStats")
+	}
+
+	var r0 []model.DelegationStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, model.DelegationStatsQuery) ([]model.DelegationStats, error)); ok {
+		return rf(ctx, filter, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.DelegationFilter, model.DelegationStatsQuery) []model.DelegationStats); ok {
+		r0 = rf(ctx, filter, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.DelegationFilter, model.DelegationStatsQuery) error); ok {
+		r1 = rf(ctx, filter, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationRepository_DelegationStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DelegationStats'
+type DelegationRepository_DelegationStats_Call struct {
+	*mock.Call
+}
+
+// DelegationStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.DelegationFilter
+//   - query model.DelegationStatsQuery
+func (_e *DelegationRepository_Expecter) DelegationStats(ctx interface{}, filter interface{}, query interface{}) *DelegationRepository_DelegationStats_Call {
+	return &DelegationRepository_DelegationStats_Call{Call: _e.mock.On("DelegationStats", ctx, filter, query)}
+}
+
+func (_c *DelegationRepository_DelegationStats_Call) Run(run func(ctx context.Context, filter model.DelegationFilter, query model.DelegationStatsQuery)) *DelegationRepository_DelegationStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.DelegationFilter), args[2].(model.DelegationStatsQuery))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_DelegationStats_Call) Return(_a0 []model.DelegationStats, _a1 error) *DelegationRepository_DelegationStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationRepository_DelegationStats_Call) RunAndReturn(run func(context.Context, model.DelegationFilter, model.DelegationStatsQuery) ([]model.DelegationStats, error)) *DelegationRepository_DelegationStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationRepository creates a new instance of DelegationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationRepository {
+	mock := &DelegationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	Interval     string
+	From         time.Time
+	To           time.Time
+	GroupByBaker bool
+}
+
+func (in Input) filter() model.DelegationFilter {
+	return model.DelegationFilter{
+		From: in.From,
+		To:   in.To,
+	}
+}
+
+// query returns the aggregation of the input, defaulting to the first interval.
+func (in Input) query() model.DelegationStatsQuery {
+	query := model.DelegationStatsQuery{
+		Interval:     in.Interval,
+		GroupByBaker: in.GroupByBaker,
+	}
+
+	if query.Interval == "" {
+		query.Interval = Intervals[0]
+	}
+
+	return query
+}
+
+type Output = []BucketData
+
+type BucketData struct {
+	Start            time.Time       `json:"start"`
+	Baker            *string         `json:"baker,omitempty"` // Only set when grouped by baker, empty for undelegations.
+	Count            int64           `json:"count"`
+	UniqueDelegators int64           `json:"unique_delegators"`
+	SumAmount        decimal.Decimal `json:"sum_amount"`
+	AvgAmount        decimal.Decimal `json:"avg_amount"`
+	MedianAmount     decimal.Decimal `json:"median_amount"`
+}
+
+func buildOutput(stats []model.DelegationStats, groupByBaker bool) Output {
+	out := make([]BucketData, len(stats))
+
+	for i, s := range stats {
+		out[i] = BucketData{
+			Start:            s.Bucket.UTC(),
+			Count:            s.Count,
+			UniqueDelegators: s.UniqueDelegators,
+			SumAmount:        s.SumAmount,
+			AvgAmount:        s.AvgAmount,
+			MedianAmount:     s.MedianAmount,
+		}
+
+		if groupByBaker {
+			out[i].Baker = &s.Baker
+		}
+	}
+
+	return out
+}
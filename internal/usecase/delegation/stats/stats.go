@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// Intervals lists the supported bucket sizes, the first one being the default.
+var Intervals = []string{"day", "week", "month"}
+
+// maxBuckets bounds the number of time buckets spanned by a time range.
+const maxBuckets = 1000
+
+// minIntervals are the shortest durations of the intervals, so that the buckets of a time range are not undercounted.
+var minIntervals = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 28 * 24 * time.Hour,
+}
+
+type DelegationRepository interface {
+	DelegationStats(
+		ctx context.Context, filter model.DelegationFilter, query model.DelegationStatsQuery,
+	) ([]model.DelegationStats, error)
+}
+
+type UseCase struct {
+	DelegationRepo DelegationRepository
+}
+
+func NewUseCase(delegationRepo DelegationRepository) *UseCase {
+	return &UseCase{
+		DelegationRepo: delegationRepo,
+	}
+}
+
+// GetStats returns delegation statistics per time bucket.
+// Only non-empty buckets are returned.
+func (uc *UseCase) GetStats(ctx context.Context, input Input) (Output, error) {
+	if err := validateInput(input); err != nil {
+		return Output{}, err
+	}
+
+	stats, err := uc.DelegationRepo.DelegationStats(ctx, input.filter(), input.query())
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error computing delegation stats", err)
+	}
+
+	return buildOutput(stats, input.GroupByBaker), nil
+}
+
+// validateInput checks the interval and time range of the input, which must not span more than maxBuckets buckets.
+// Daily buckets and buckets grouped by baker need a time range, the whole history being too long for them.
+func validateInput(input Input) error {
+	if input.Interval != "" && !slices.Contains(Intervals, input.Interval) {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid interval: %s, must be one of %s", input.Interval, strings.Join(Intervals, ", ")),
+			nil,
+		)
+	}
+
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid time range: from %s must be before to %s", input.From.Format(time.RFC3339), input.To.Format(time.RFC3339)),
+			nil,
+		)
+	}
+
+	interval := input.query().Interval
+
+	if input.From.IsZero() || input.To.IsZero() {
+		if interval == Intervals[0] || input.GroupByBaker {
+			return api.NewError(
+				api.InvalidArgument, "from and to are required for daily buckets and buckets grouped by baker", nil,
+			)
+		}
+
+		return nil
+	}
+
+	if buckets := int(input.To.Sub(input.From)/minIntervals[interval]) + 1; buckets > maxBuckets {
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid time range: spans up to %d %s buckets, more than %d", buckets, interval, maxBuckets),
+			nil,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+//go:generate mockery
+package stats
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/delegation/stats/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_GetStats(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		DelegationRepo *mocks.DelegationRepository
+	}
+
+	var (
+		from  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to    = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		baker = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "default interval",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{From: from, To: to},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().DelegationStats(
+					context.Background(),
+					model.DelegationFilter{From: from, To: to},
+					model.DelegationStatsQuery{Interval: "day"},
+				).Return(
+					[]model.DelegationStats{
+						{
+							Bucket:           time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+							Count:            3,
+							UniqueDelegators: 2,
+							SumAmount:        decimal.RequireFromString("600"),
+							AvgAmount:        decimal.RequireFromString("200"),
+							MedianAmount:     decimal.RequireFromString("150"),
+						},
+					}, nil,
+				)
+			},
+			want: Output{
+				{
+					Start:            time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					Count:            3,
+					UniqueDelegators: 2,
+					SumAmount:        decimal.RequireFromString("600"),
+					AvgAmount:        decimal.RequireFromString("200"),
+					MedianAmount:     decimal.RequireFromString("150"),
+				},
+			},
+		},
+		{
+			name:  "group by baker",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Interval: "month", From: from, To: to, GroupByBaker: true},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().DelegationStats(
+					context.Background(),
+					model.DelegationFilter{From: from, To: to},
+					model.DelegationStatsQuery{Interval: "month", GroupByBaker: true},
+				).Return(
+					[]model.DelegationStats{
+						{
+							Bucket:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+							Baker:            baker,
+							Count:            1,
+							UniqueDelegators: 1,
+							SumAmount:        decimal.RequireFromString("10"),
+							AvgAmount:        decimal.RequireFromString("10"),
+							MedianAmount:     decimal.RequireFromString("10"),
+						},
+					}, nil,
+				)
+			},
+			want: Output{
+				{
+					Start:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					Baker:            &baker,
+					Count:            1,
+					UniqueDelegators: 1,
+					SumAmount:        decimal.RequireFromString("10"),
+					AvgAmount:        decimal.RequireFromString("10"),
+					MedianAmount:     decimal.RequireFromString("10"),
+				},
+			},
+		},
+		{
+			name:     "invalid interval",
+			input:    Input{Interval: "year"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "invalid time range",
+			input:    Input{From: to, To: from},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "daily buckets without time range",
+			input:    Input{From: from},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "buckets grouped by baker without time range",
+			input:    Input{Interval: "month", GroupByBaker: true},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "too many buckets",
+			input:    Input{From: from, To: from.AddDate(3, 0, 0)},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "repository error",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Interval: "week"},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().DelegationStats(
+					context.Background(), model.DelegationFilter{}, model.DelegationStatsQuery{Interval: "week"},
+				).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.DelegationRepo)
+
+				got, err := uc.GetStats(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetStats() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetStats() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetStats() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
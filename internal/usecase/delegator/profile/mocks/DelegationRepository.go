@@ -0,0 +1,156 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "kiln-exercice/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DelegationRepository is an autogenerated mock type for the DelegationRepository type
+type DelegationRepository struct {
+	mock.Mock
+}
+
+type DelegationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationRepository) EXPECT() *DelegationRepository_Expecter {
+	return &DelegationRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetDelegatorSummaries provides a mock function with given fields: ctx, delegators
+func (_m *DelegationRepository) GetDelegatorSummaries(ctx context.Context, delegators []string) ([]model.DelegatorSummary, error) {
+	ret := _m.Called(ctx, delegators)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegatorSummaries")
+	}
+
+	var r0 []model.DelegatorSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.DelegatorSummary, error)); ok {
+		return rf(ctx, delegators)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.DelegatorSummary); ok {
+		r0 = rf(ctx, delegators)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegatorSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, delegators)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationRepository_GetDelegatorSummaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelegatorSummaries'
+type DelegationRepository_GetDelegatorSummaries_Call struct {
+	*mock.Call
+}
+
+// GetDelegatorSummaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delegators []string
+func (_e *DelegationRepository_Expecter) GetDelegatorSummaries(ctx interface{}, delegators interface{}) *DelegationRepository_GetDelegatorSummaries_Call {
+	return &DelegationRepository_GetDelegatorSummaries_Call{Call: _e.mock.On("GetDelegatorSummaries", ctx, delegators)}
+}
+
+func (_c *DelegationRepository_GetDelegatorSummaries_Call) Run(run func(ctx context.Context, delegators []string)) *DelegationRepository_GetDelegatorSummaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_GetDelegatorSummaries_Call) Return(_a0 []model.DelegatorSummary, _a1 error) *DelegationRepository_GetDelegatorSummaries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationRepository_GetDelegatorSummaries_Call) RunAndReturn(run func(context.Context, []string) ([]model.DelegatorSummary, error)) *DelegationRepository_GetDelegatorSummaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLatestDelegations provides a mock function with given fields: ctx, delegators, limit
+func (_m *DelegationRepository) ListLatestDelegations(ctx context.Context, delegators []string, limit int) ([]model.Delegation, error) {
+	ret := _m.Called(ctx, delegators, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLatestDelegations")
+	}
+
+	var r0 []model.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int) ([]model.Delegation, error)); ok {
+		return rf(ctx, delegators, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int) []model.Delegation); ok {
+		r0 = rf(ctx, delegators, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = rf(ctx, delegators, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationRepository_ListLatestDelegations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLatestDelegations'
+type DelegationRepository_ListLatestDelegations_Call struct {
+	*mock.Call
+}
+
+// ListLatestDelegations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delegators []string
+//   - limit int
+func (_e *DelegationRepository_Expecter) ListLatestDelegations(ctx interface{}, delegators interface{}, limit interface{}) *DelegationRepository_ListLatestDelegations_Call {
+	return &DelegationRepository_ListLatestDelegations_Call{Call: _e.mock.On("ListLatestDelegations", ctx, delegators, limit)}
+}
+
+func (_c *DelegationRepository_ListLatestDelegations_Call) Run(run func(ctx context.Context, delegators []string, limit int)) *DelegationRepository_ListLatestDelegations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_ListLatestDelegations_Call) Return(_a0 []model.Delegation, _a1 error) *DelegationRepository_ListLatestDelegations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationRepository_ListLatestDelegations_Call) RunAndReturn(run func(context.Context, []string, int) ([]model.Delegation, error)) *DelegationRepository_ListLatestDelegations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationRepository creates a new instance of DelegationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationRepository {
+	mock := &DelegationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+package profile
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	Address string
+}
+
+type Output struct {
+	Address             string           `json:"address"`
+	CurrentBaker        string           `json:"current_baker"` // Empty when the delegator is undelegated, or unknown.
+	CurrentBakerUnknown bool             `json:"current_baker_unknown"`
+	FirstDelegationAt   time.Time        `json:"first_delegation_at"`
+	LastDelegationAt    time.Time        `json:"last_delegation_at"`
+	BakerSwitches       int              `json:"baker_switches"` // Moves from a baker to another, undelegations aside.
+	DelegatedBalance    decimal.Decimal  `json:"delegated_balance"`
+	DelegationCount     int              `json:"delegation_count"`
+	Delegations         []DelegationData `json:"delegations"`       // The latest HistorySize delegations.
+	HistoryTruncated    bool             `json:"history_truncated"` // Whether older delegations are left out.
+}
+
+type DelegationData struct {
+	Hash         string          `json:"hash"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Amount       decimal.Decimal `json:"amount"`
+	Baker        string          `json:"baker"` // Empty for undelegations, and when unknown.
+	BakerUnknown bool            `json:"baker_unknown"`
+	Level        string          `json:"level"`
+	Height       int             `json:"-"` // Level as represented by GraphQL.
+}
+
+// buildOutput builds the profile of a delegator from its summary and its latest delegations, sorted from the latest
+// to the oldest.
+func buildOutput(summary model.DelegatorSummary, delegations []model.Delegation) Output {
+	latest := delegations[0]
+
+	out := Output{
+		Address:             summary.Delegator,
+		CurrentBaker:        latest.Baker,
+		CurrentBakerUnknown: latest.BakerUnknown,
+		FirstDelegationAt:   summary.FirstDelegationAt,
+		LastDelegationAt:    latest.Datetime,
+		BakerSwitches:       summary.BakerSwitches,
+		DelegatedBalance:    latest.Amount,
+		DelegationCount:     summary.DelegationCount,
+		Delegations:         make([]DelegationData, len(delegations)),
+		HistoryTruncated:    summary.DelegationCount > len(delegations),
+	}
+
+	for i, d := range delegations {
+		out.Delegations[i] = DelegationData{
+			Hash:         d.TxHash,
+			Timestamp:    d.Datetime,
+			Amount:       d.Amount,
+			Baker:        d.Baker,
+			BakerUnknown: d.BakerUnknown,
+			Level:        strconv.Itoa(d.Height),
+			Height:       d.Height,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tezos"
+)
+
+// HistorySize is the number of latest delegations returned in the history of a delegator.
+const HistorySize = 100
+
+type DelegationRepository interface {
+	GetDelegatorSummaries(ctx context.Context, delegators []string) ([]model.DelegatorSummary, error)
+	ListLatestDelegations(ctx context.Context, delegators []string, limit int) ([]model.Delegation, error)
+}
+
+type UseCase struct {
+	DelegationRepo DelegationRepository
+}
+
+func NewUseCase(delegationRepo DelegationRepository) *UseCase {
+	return &UseCase{
+		DelegationRepo: delegationRepo,
+	}
+}
+
+// GetDelegator returns the profile of a delegator, summarizing its whole delegation history.
+func (uc *UseCase) GetDelegator(ctx context.Context, input Input) (Output, error) {
+	if err := tezos.ValidateAddress(input.Address); err != nil {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid address: %s", err.Error()), err)
+	}
+
+	profiles, err := uc.GetDelegators(ctx, []string{input.Address})
+	if err != nil {
+		return Output{}, err
+	}
+
+	out, ok := profiles[input.Address]
+	if !ok {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no delegation found for delegator %s", input.Address), nil)
+	}
+
+	return out, nil
+}
+
+// GetDelegators returns the profiles of several delegators, keyed by address, fetching their histories at once.
+// Delegators without delegation, as well as invalid addresses, are left out: callers validate the addresses, so that
+// one of them cannot fail a whole batch.
+func (uc *UseCase) GetDelegators(ctx context.Context, addresses []string) (map[string]Output, error) {
+	summaries, err := uc.DelegationRepo.GetDelegatorSummaries(ctx, addresses)
+	if err != nil {
+		return nil, api.NewError(api.Unknown, "error summarizing delegator delegations", err)
+	}
+
+	if len(summaries) == 0 {
+		return map[string]Output{}, nil
+	}
+
+	delegations, err := uc.DelegationRepo.ListLatestDelegations(ctx, addresses, HistorySize)
+	if err != nil {
+		return nil, api.NewError(api.Unknown, "error listing delegator delegations", err)
+	}
+
+	histories := make(map[string][]model.Delegation)
+	for _, d := range delegations {
+		histories[d.Delegator] = append(histories[d.Delegator], d)
+	}
+
+	out := make(map[string]Output, len(summaries))
+	for _, summary := range summaries {
+		// Delegations are never deleted, so a summarized delegator has a history.
+		if history := histories[summary.Delegator]; len(history) > 0 {
+			out[summary.Delegator] = buildOutput(summary, history)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,259 @@
+//go:generate mockery
+package profile
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/delegator/profile/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+const address = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+
+func TestUseCase_GetDelegator(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		DelegationRepo *mocks.DelegationRepository
+	}
+
+	addresses := []string{address}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Address: address},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().GetDelegatorSummaries(context.Background(), addresses).Return(
+					[]model.DelegatorSummary{
+						{
+							Delegator: address, FirstDelegationAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+							DelegationCount: 120, BakerSwitches: 4,
+						},
+					}, nil,
+				)
+				e.DelegationRepo.EXPECT().ListLatestDelegations(context.Background(), addresses, HistorySize).Return(
+					[]model.Delegation{
+						{
+							Datetime:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+							Amount:    decimal.RequireFromString("125896"),
+							Delegator: address,
+							Baker:     "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+							Height:    2338084,
+							TxHash:    "hash_2",
+						},
+						{
+							Datetime:  time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+							Amount:    decimal.RequireFromString("9856354"),
+							Delegator: address,
+							Baker:     "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+							Height:    1461334,
+							TxHash:    "hash_1",
+						},
+					}, nil,
+				)
+			},
+			want: Output{
+				Address:           address,
+				CurrentBaker:      "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+				FirstDelegationAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				LastDelegationAt:  time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+				BakerSwitches:     4,
+				DelegatedBalance:  decimal.RequireFromString("125896"),
+				DelegationCount:   120,
+				HistoryTruncated:  true, // 2 of 120 delegations.
+				Delegations: []DelegationData{
+					{
+						Hash:      "hash_2",
+						Timestamp: time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC),
+						Amount:    decimal.RequireFromString("125896"),
+						Baker:     "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+						Level:     "2338084",
+						Height:    2338084,
+					},
+					{
+						Hash:      "hash_1",
+						Timestamp: time.Date(2021, 5, 7, 14, 48, 7, 0, time.UTC),
+						Amount:    decimal.RequireFromString("9856354"),
+						Baker:     "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
+						Level:     "1461334",
+						Height:    1461334,
+					},
+				},
+			},
+		},
+		{
+			name:     "invalid address",
+			input:    Input{Address: "tz1abc"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "not found",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Address: address},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().GetDelegatorSummaries(context.Background(), addresses).Return(nil, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{DelegationRepo: mocks.NewDelegationRepository(t)},
+			input: Input{Address: address},
+			init: func(e *env) {
+				e.DelegationRepo.EXPECT().GetDelegatorSummaries(context.Background(), addresses).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.DelegationRepo)
+
+				got, err := uc.GetDelegator(context.Background(), tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetDelegator() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetDelegator() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetDelegator() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestUseCase_GetDelegators(t *testing.T) {
+	t.Parallel()
+
+	const other = "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"
+
+	var (
+		addresses = []string{address, other, "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"}
+		timestamp = time.Date(2024, 5, 5, 6, 29, 14, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name      string
+		addresses []string
+		init      func(*mocks.DelegationRepository)
+		want      map[string]Output
+		wantCode  api.Code
+	}{
+		{
+			name:      "happy path",
+			addresses: addresses,
+			init: func(repo *mocks.DelegationRepository) {
+				repo.EXPECT().GetDelegatorSummaries(context.Background(), addresses).Return(
+					[]model.DelegatorSummary{
+						{Delegator: address, FirstDelegationAt: timestamp.Add(-time.Hour), DelegationCount: 2},
+						{Delegator: other, FirstDelegationAt: timestamp, DelegationCount: 1},
+					}, nil,
+				)
+				repo.EXPECT().ListLatestDelegations(context.Background(), addresses, HistorySize).Return(
+					[]model.Delegation{
+						{
+							Datetime: timestamp, Amount: decimal.RequireFromString("20"), Delegator: other,
+							Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Height: 2, TxHash: "hash_3",
+						},
+						{Datetime: timestamp, Amount: decimal.RequireFromString("10"), Delegator: address, Height: 2, TxHash: "hash_2"},
+						{
+							Datetime: timestamp.Add(-time.Hour), Amount: decimal.RequireFromString("10"), Delegator: address,
+							Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Height: 1, TxHash: "hash_1",
+						},
+					}, nil,
+				)
+			},
+			want: map[string]Output{
+				address: {
+					Address: address, FirstDelegationAt: timestamp.Add(-time.Hour), LastDelegationAt: timestamp,
+					DelegatedBalance: decimal.RequireFromString("10"), DelegationCount: 2,
+					Delegations: []DelegationData{
+						{Hash: "hash_2", Timestamp: timestamp, Amount: decimal.RequireFromString("10"), Level: "2", Height: 2},
+						{
+							Hash: "hash_1", Timestamp: timestamp.Add(-time.Hour), Amount: decimal.RequireFromString("10"),
+							Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Level: "1", Height: 1,
+						},
+					},
+				},
+				other: {
+					Address: other, CurrentBaker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft",
+					FirstDelegationAt: timestamp, LastDelegationAt: timestamp, DelegationCount: 1,
+					DelegatedBalance: decimal.RequireFromString("20"),
+					Delegations: []DelegationData{
+						{
+							Hash: "hash_3", Timestamp: timestamp, Amount: decimal.RequireFromString("20"),
+							Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Level: "2", Height: 2,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "none found",
+			addresses: addresses,
+			init: func(repo *mocks.DelegationRepository) {
+				repo.EXPECT().GetDelegatorSummaries(context.Background(), addresses).Return(nil, nil)
+			},
+			want: map[string]Output{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				repo := mocks.NewDelegationRepository(t)
+				tt.init(repo)
+
+				got, err := NewUseCase(repo).GetDelegators(context.Background(), tt.addresses)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("GetDelegators() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("GetDelegators() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetDelegators() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,153 @@
+package freshness
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tzkt"
+)
+
+// headTimeout bounds the wait for the chain head, so that a slow TzKT does not hold up the requests reporting
+// their freshness.
+const headTimeout = 2 * time.Second
+
+type PollingRepository interface {
+	GetLastPolling(ctx context.Context) (model.Polling, error)
+}
+
+type DelegationRepository interface {
+	GetLatestDelegation(ctx context.Context) (model.Delegation, error)
+}
+
+type XTZSDK interface {
+	GetHead(ctx context.Context) (tzkt.Head, error)
+}
+
+type UseCase struct {
+	PollingRepo    PollingRepository
+	DelegationRepo DelegationRepository
+	XTZSDK         XTZSDK
+	Thresholds     Thresholds
+	TTL            time.Duration // How long a result is reused, sparing TzKT and the database frequent checks.
+	TimeNow        func() time.Time
+
+	group    singleflight.Group // Coalesces the concurrent checks.
+	mu       sync.Mutex         // Guards the cached result, never held while checking.
+	cached   result
+	cachedAt time.Time
+}
+
+// result is the outcome of a check.
+type result struct {
+	out Output
+	err error
+}
+
+func NewUseCase(
+	pollingRepo PollingRepository,
+	delegationRepo DelegationRepository,
+	xtzSDK XTZSDK,
+	thresholds Thresholds,
+	ttl time.Duration,
+	timeNow func() time.Time,
+) *UseCase {
+	return &UseCase{
+		PollingRepo:    pollingRepo,
+		DelegationRepo: delegationRepo,
+		XTZSDK:         xtzSDK,
+		Thresholds:     thresholds,
+		TTL:            ttl,
+		TimeNow:        timeNow,
+	}
+}
+
+// GetFreshness returns how far the last poll and the latest delegation lag behind the head of the chain, and whether
+// the data is stale. The head is only informative: when TzKT is unreachable, lags are measured from now instead.
+// Results, errors included, are reused for the TTL, and concurrent callers share a single check.
+func (uc *UseCase) GetFreshness(ctx context.Context) (Output, error) {
+	if res, ok := uc.cachedResult(uc.TimeNow()); ok {
+		return res.out, res.err
+	}
+
+	v, _, _ := uc.group.Do(
+		"", func() (any, error) {
+			now := uc.TimeNow()
+
+			// Another check may have completed since the cache was read.
+			if res, ok := uc.cachedResult(now); ok {
+				return res, nil
+			}
+
+			// The check is shared, so that it outlives the caller starting it.
+			out, err := uc.check(context.WithoutCancel(ctx), now)
+			res := result{out: out, err: err}
+
+			uc.mu.Lock()
+			uc.cached, uc.cachedAt = res, now
+			uc.mu.Unlock()
+
+			return res, nil
+		},
+	)
+
+	res := v.(result)
+
+	return res.out, res.err
+}
+
+// cachedResult returns the result of the last check, unless it is older than the TTL.
+func (uc *UseCase) cachedResult(now time.Time) (result, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.cachedAt.IsZero() || now.Sub(uc.cachedAt) >= uc.TTL {
+		return result{}, false
+	}
+
+	return uc.cached, true
+}
+
+// check measures the freshness of the data at now.
+func (uc *UseCase) check(ctx context.Context, now time.Time) (Output, error) {
+	var head *tzkt.Head
+
+	headCtx, cancel := context.WithTimeout(ctx, headTimeout)
+	defer cancel()
+
+	h, err := uc.XTZSDK.GetHead(headCtx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Chain head unavailable, freshness measured from now")
+	} else {
+		head = &h
+	}
+
+	var polling *model.Polling
+
+	p, err := uc.PollingRepo.GetLastPolling(ctx)
+	switch {
+	case err == nil:
+		polling = &p
+	case !errors.Is(err, sql.ErrNoRows):
+		return Output{}, api.NewError(api.Unknown, "error getting last polling", err)
+	}
+
+	var latest *model.Delegation
+
+	d, err := uc.DelegationRepo.GetLatestDelegation(ctx)
+	switch {
+	case err == nil:
+		latest = &d
+	case !errors.Is(err, sql.ErrNoRows):
+		return Output{}, api.NewError(api.Unknown, "error getting latest delegation", err)
+	}
+
+	return buildOutput(head, now, polling, latest, uc.Thresholds), nil
+}
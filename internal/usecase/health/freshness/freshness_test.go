@@ -0,0 +1,282 @@
+//go:generate mockery
+package freshness
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/health/freshness/mocks"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tzkt"
+)
+
+func TestUseCase_GetFreshness(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		PollingRepo    *mocks.PollingRepository
+		DelegationRepo *mocks.DelegationRepository
+		XTZSDK         *mocks.XTZSDK
+	}
+
+	var (
+		now        = time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+		head       = tzkt.Head{Level: 5000100, Timestamp: now.Add(-10 * time.Second)}
+		polledAt   = now.Add(-70 * time.Second)
+		delegation = model.Delegation{Height: 5000000, Datetime: now.Add(-2 * time.Hour)}
+		thresholds = Thresholds{MaxPollingLag: 5 * time.Minute, MaxDelegationLag: time.Hour}
+	)
+
+	ptr := func(v int64) *int64 { return &v }
+
+	newEnv := func(t *testing.T) env {
+		return env{
+			PollingRepo:    mocks.NewPollingRepository(t),
+			DelegationRepo: mocks.NewDelegationRepository(t),
+			XTZSDK:         mocks.NewXTZSDK(t),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		thresholds Thresholds
+		init       func(*env)
+		want       Output
+		wantCode   api.Code
+	}{
+		{
+			name:       "lags behind the head",
+			thresholds: thresholds,
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(head, nil)
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{LastPolledAt: polledAt}, nil)
+				e.DelegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(delegation, nil)
+			},
+			want: Output{
+				Head:                 &HeadData{Level: 5000100, Timestamp: head.Timestamp},
+				LastPolledAt:         &polledAt,
+				PollingLagSeconds:    ptr(60),
+				LatestDelegation:     &DelegationData{Level: 5000000, Timestamp: delegation.Datetime},
+				DelegationLagSeconds: ptr(7190),
+				DelegationLagLevels:  ptr(100),
+				Stale:                true,
+				Reasons:              []string{"latest delegation 1h59m50s behind, at most 1h0m0s"},
+			},
+		},
+		{
+			name:       "head unavailable",
+			thresholds: Thresholds{MaxPollingLag: time.Minute},
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(tzkt.Head{}, errors.New("circuit open"))
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{LastPolledAt: polledAt}, nil)
+				e.DelegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(delegation, nil)
+			},
+			want: Output{
+				LastPolledAt:         &polledAt,
+				PollingLagSeconds:    ptr(70),
+				LatestDelegation:     &DelegationData{Level: 5000000, Timestamp: delegation.Datetime},
+				DelegationLagSeconds: ptr(7200),
+				Stale:                true,
+				Reasons:              []string{"last poll 1m10s behind, at most 1m0s"},
+			},
+		},
+		{
+			name:       "never polled",
+			thresholds: thresholds,
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(head, nil)
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{}, sql.ErrNoRows)
+				e.DelegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(model.Delegation{}, sql.ErrNoRows)
+			},
+			want: Output{
+				Head:    &HeadData{Level: 5000100, Timestamp: head.Timestamp},
+				Stale:   true,
+				Reasons: []string{"never polled", "no delegation"},
+			},
+		},
+		{
+			name: "thresholds disabled",
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(head, nil)
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{}, sql.ErrNoRows)
+				e.DelegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(model.Delegation{}, sql.ErrNoRows)
+			},
+			want: Output{Head: &HeadData{Level: 5000100, Timestamp: head.Timestamp}},
+		},
+		{
+			name:       "polling error",
+			thresholds: thresholds,
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(head, nil)
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+		{
+			name:       "delegation error",
+			thresholds: thresholds,
+			init: func(e *env) {
+				e.XTZSDK.EXPECT().GetHead(mock.Anything).Return(head, nil)
+				e.PollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{LastPolledAt: polledAt}, nil)
+				e.DelegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(model.Delegation{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := newEnv(t)
+				tt.init(&e)
+
+				uc := NewUseCase(e.PollingRepo, e.DelegationRepo, e.XTZSDK, tt.thresholds, 0, func() time.Time { return now })
+
+				got, err := uc.GetFreshness(context.Background())
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Fatalf("GetFreshness() error = %v, want code %v", err, tt.wantCode)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("GetFreshness() unexpected error = %v", err)
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetFreshness() got = %+v, want %+v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestUseCase_GetFreshness_Cached(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pollingRepo    = mocks.NewPollingRepository(t)
+		delegationRepo = mocks.NewDelegationRepository(t)
+		xtzSDK         = mocks.NewXTZSDK(t)
+		now            = time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+	)
+
+	// Both calls within the TTL are answered by the same checks.
+	xtzSDK.EXPECT().GetHead(mock.Anything).Return(tzkt.Head{Timestamp: now}, nil).Once()
+	pollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{LastPolledAt: now}, nil).Once()
+	delegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(model.Delegation{Datetime: now}, nil).Once()
+
+	uc := NewUseCase(pollingRepo, delegationRepo, xtzSDK, Thresholds{}, 5*time.Second, func() time.Time { return now })
+
+	first, err := uc.GetFreshness(context.Background())
+	if err != nil {
+		t.Fatalf("GetFreshness() unexpected error = %v", err)
+	}
+
+	now = now.Add(4 * time.Second)
+
+	second, err := uc.GetFreshness(context.Background())
+	if err != nil {
+		t.Fatalf("GetFreshness() unexpected error = %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("GetFreshness() got = %+v, want the cached %+v", second, first)
+	}
+}
+
+func TestUseCase_GetFreshness_CachedError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pollingRepo    = mocks.NewPollingRepository(t)
+		delegationRepo = mocks.NewDelegationRepository(t)
+		xtzSDK         = mocks.NewXTZSDK(t)
+		now            = time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+	)
+
+	// A failing database is not checked again within the TTL.
+	xtzSDK.EXPECT().GetHead(mock.Anything).Return(tzkt.Head{Timestamp: now}, nil).Once()
+	pollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{}, errors.New("boom")).Once()
+
+	uc := NewUseCase(pollingRepo, delegationRepo, xtzSDK, Thresholds{}, 5*time.Second, func() time.Time { return now })
+
+	for range 2 {
+		var apiErr *api.Error
+		if _, err := uc.GetFreshness(context.Background()); !errors.As(err, &apiErr) || apiErr.Code != api.Unknown {
+			t.Fatalf("GetFreshness() error = %v, want code %v", err, api.Unknown)
+		}
+
+		now = now.Add(4 * time.Second)
+	}
+}
+
+func TestUseCase_GetFreshness_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pollingRepo    = mocks.NewPollingRepository(t)
+		delegationRepo = mocks.NewDelegationRepository(t)
+		xtzSDK         = mocks.NewXTZSDK(t)
+		now            = time.Date(2024, 5, 8, 15, 0, 0, 0, time.UTC)
+		started        = make(chan struct{})
+		release        = make(chan struct{})
+	)
+
+	// Callers arriving during a check share it, or its result once cached.
+	xtzSDK.EXPECT().GetHead(mock.Anything).RunAndReturn(
+		func(context.Context) (tzkt.Head, error) {
+			close(started)
+			<-release
+			return tzkt.Head{Timestamp: now}, nil
+		},
+	).Once()
+	pollingRepo.EXPECT().GetLastPolling(mock.Anything).Return(model.Polling{LastPolledAt: now}, nil).Once()
+	delegationRepo.EXPECT().GetLatestDelegation(mock.Anything).Return(model.Delegation{Datetime: now}, nil).Once()
+
+	uc := NewUseCase(pollingRepo, delegationRepo, xtzSDK, Thresholds{}, 5*time.Second, func() time.Time { return now })
+
+	// A canceled caller does not fail the others.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for i := range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			callerCtx := context.Background()
+			if i == 0 {
+				callerCtx = ctx
+			}
+
+			if _, err := uc.GetFreshness(callerCtx); err != nil {
+				t.Errorf("GetFreshness() unexpected error = %v", err)
+			}
+		}()
+
+		if i == 0 {
+			<-started
+			cancel()
+		}
+	}
+
+	close(release)
+	wg.Wait()
+}
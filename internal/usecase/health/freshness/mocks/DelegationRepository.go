@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// DelegationRepository is an autogenerated mock type for the DelegationRepository type
+type DelegationRepository struct {
+	mock.Mock
+}
+
+type DelegationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DelegationRepository) EXPECT() *DelegationRepository_Expecter {
+	return &DelegationRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetLatestDelegation provides a mock function with given fields: ctx
+func (_m *DelegationRepository) GetLatestDelegation(ctx context.Context) (model.Delegation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestDelegation")
+	}
+
+	var r0 model.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Delegation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Delegation); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(model.Delegation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationRepository_GetLatestDelegation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestDelegation'
+type DelegationRepository_GetLatestDelegation_Call struct {
+	*mock.Call
+}
+
+// GetLatestDelegation is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DelegationRepository_Expecter) GetLatestDelegation(ctx interface{}) *DelegationRepository_GetLatestDelegation_Call {
+	return &DelegationRepository_GetLatestDelegation_Call{Call: _e.mock.On("GetLatestDelegation", ctx)}
+}
+
+func (_c *DelegationRepository_GetLatestDelegation_Call) Run(run func(ctx context.Context)) *DelegationRepository_GetLatestDelegation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DelegationRepository_GetLatestDelegation_Call) Return(_a0 model.Delegation, _a1 error) *DelegationRepository_GetLatestDelegation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DelegationRepository_GetLatestDelegation_Call) RunAndReturn(run func(context.Context) (model.Delegation, error)) *DelegationRepository_GetLatestDelegation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDelegationRepository creates a new instance of DelegationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDelegationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DelegationRepository {
+	mock := &DelegationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	tzkt "kiln-exercice/pkg/tzkt"
+)
+
+// XTZSDK is an autogenerated mock type for the XTZSDK type
+type XTZSDK struct {
+	mock.Mock
+}
+
+type XTZSDK_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *XTZSDK) EXPECT() *XTZSDK_Expecter {
+	return &XTZSDK_Expecter{mock: &_m.Mock}
+}
+
+// GetHead provides a mock function with given fields: ctx
+func (_m *XTZSDK) GetHead(ctx context.Context) (tzkt.Head, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHead")
+	}
+
+	var r0 tzkt.Head
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (tzkt.Head, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) tzkt.Head); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(tzkt.Head)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// XTZSDK_GetHead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHead'
+type XTZSDK_GetHead_Call struct {
+	*mock.Call
+}
+
+// GetHead is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *XTZSDK_Expecter) GetHead(ctx interface{}) *XTZSDK_GetHead_Call {
+	return &XTZSDK_GetHead_Call{Call: _e.mock.On("GetHead", ctx)}
+}
+
+func (_c *XTZSDK_GetHead_Call) Run(run func(ctx context.Context)) *XTZSDK_GetHead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *XTZSDK_GetHead_Call) Return(_a0 tzkt.Head, _a1 error) *XTZSDK_GetHead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *XTZSDK_GetHead_Call) RunAndReturn(run func(context.Context) (tzkt.Head, error)) *XTZSDK_GetHead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewXTZSDK creates a new instance of XTZSDK. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewXTZSDK(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *XTZSDK {
+	mock := &XTZSDK{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package freshness
+
+import (
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/tzkt"
+)
+
+// Thresholds are the lags beyond which the stored data is stale. A zero threshold disables its check.
+type Thresholds struct {
+	MaxPollingLag    time.Duration
+	MaxDelegationLag time.Duration
+}
+
+type Output struct {
+	Head                 *HeadData       `json:"head"` // Nil when TzKT is unreachable: lags are then measured from now.
+	LastPolledAt         *time.Time      `json:"last_polled_at"`
+	PollingLagSeconds    *int64          `json:"polling_lag_seconds"`
+	LatestDelegation     *DelegationData `json:"latest_delegation"`
+	DelegationLagSeconds *int64          `json:"delegation_lag_seconds"`
+	DelegationLagLevels  *int64          `json:"delegation_lag_levels"` // Nil when the head is unknown.
+	Stale                bool            `json:"stale"`
+	Reasons              []string        `json:"reasons,omitempty"` // Why the data is stale.
+}
+
+type HeadData struct {
+	Level     int       `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type DelegationData struct {
+	Level     int       `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PollingLag returns the lag of the last poll, false before the first poll.
+func (o Output) PollingLag() (time.Duration, bool) {
+	if o.PollingLagSeconds == nil {
+		return 0, false
+	}
+
+	return time.Duration(*o.PollingLagSeconds) * time.Second, true
+}
+
+// buildOutput measures the lags of the last poll and latest delegation, nil when missing, behind the head, or behind
+// now when head is nil.
+func buildOutput(
+	head *tzkt.Head, now time.Time, polling *model.Polling, latest *model.Delegation, thresholds Thresholds,
+) Output {
+	var out Output
+
+	ref := now
+	if head != nil {
+		ref = head.Timestamp
+		out.Head = &HeadData{Level: head.Level, Timestamp: head.Timestamp}
+	}
+
+	if polling != nil {
+		lag := lagSeconds(ref, polling.LastPolledAt)
+		out.LastPolledAt, out.PollingLagSeconds = &polling.LastPolledAt, &lag
+	}
+
+	if latest != nil {
+		lag := lagSeconds(ref, latest.Datetime)
+		out.LatestDelegation = &DelegationData{Level: latest.Height, Timestamp: latest.Datetime}
+		out.DelegationLagSeconds = &lag
+
+		if head != nil {
+			levels := int64(max(head.Level-latest.Height, 0))
+			out.DelegationLagLevels = &levels
+		}
+	}
+
+	if limit := thresholds.MaxPollingLag; limit > 0 {
+		switch lag, ok := out.PollingLag(); {
+		case !ok:
+			out.Reasons = append(out.Reasons, "never polled")
+		case lag > limit:
+			out.Reasons = append(out.Reasons, "last poll "+lag.String()+" behind, at most "+limit.String())
+		}
+	}
+
+	if limit := thresholds.MaxDelegationLag; limit > 0 {
+		switch {
+		case out.DelegationLagSeconds == nil:
+			out.Reasons = append(out.Reasons, "no delegation")
+		case time.Duration(*out.DelegationLagSeconds)*time.Second > limit:
+			lag := time.Duration(*out.DelegationLagSeconds) * time.Second
+			out.Reasons = append(out.Reasons, "latest delegation "+lag.String()+" behind, at most "+limit.String())
+		}
+	}
+
+	out.Stale = len(out.Reasons) > 0
+
+	return out
+}
+
+// lagSeconds returns the whole seconds t lags behind ref, 0 when ahead.
+func lagSeconds(ref, t time.Time) int64 {
+	return int64(max(ref.Sub(t), 0) / time.Second)
+}
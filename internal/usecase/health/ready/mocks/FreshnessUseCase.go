@@ -0,0 +1,93 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	freshness "kiln-exercice/internal/usecase/health/freshness"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FreshnessUseCase is an autogenerated mock type for the FreshnessUseCase type
+type FreshnessUseCase struct {
+	mock.Mock
+}
+
+type FreshnessUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FreshnessUseCase) EXPECT() *FreshnessUseCase_Expecter {
+	return &FreshnessUseCase_Expecter{mock: &_m.Mock}
+}
+
+// GetFreshness provides a mock function with given fields: ctx
+func (_m *FreshnessUseCase) GetFreshness(ctx context.Context) (freshness.Output, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFreshness")
+	}
+
+	var r0 freshness.Output
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (freshness.Output, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) freshness.Output); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(freshness.Output)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FreshnessUseCase_GetFreshness_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFreshness'
+type FreshnessUseCase_GetFreshness_Call struct {
+	*mock.Call
+}
+
+// GetFreshness is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *FreshnessUseCase_Expecter) GetFreshness(ctx interface{}) *FreshnessUseCase_GetFreshness_Call {
+	return &FreshnessUseCase_GetFreshness_Call{Call: _e.mock.On("GetFreshness", ctx)}
+}
+
+func (_c *FreshnessUseCase_GetFreshness_Call) Run(run func(ctx context.Context)) *FreshnessUseCase_GetFreshness_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *FreshnessUseCase_GetFreshness_Call) Return(_a0 freshness.Output, _a1 error) *FreshnessUseCase_GetFreshness_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FreshnessUseCase_GetFreshness_Call) RunAndReturn(run func(context.Context) (freshness.Output, error)) *FreshnessUseCase_GetFreshness_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFreshnessUseCase creates a new instance of FreshnessUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFreshnessUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FreshnessUseCase {
+	mock := &FreshnessUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
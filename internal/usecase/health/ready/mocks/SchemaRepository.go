@@ -0,0 +1,138 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SchemaRepository is an autogenerated mock type for the SchemaRepository type
+type SchemaRepository struct {
+	mock.Mock
+}
+
+type SchemaRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SchemaRepository) EXPECT() *SchemaRepository_Expecter {
+	return &SchemaRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetSchemaVersion provides a mock function with given fields: ctx
+func (_m *SchemaRepository) GetSchemaVersion(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchemaVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchemaRepository_GetSchemaVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchemaVersion'
+type SchemaRepository_GetSchemaVersion_Call struct {
+	*mock.Call
+}
+
+// GetSchemaVersion is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SchemaRepository_Expecter) GetSchemaVersion(ctx interface{}) *SchemaRepository_GetSchemaVersion_Call {
+	return &SchemaRepository_GetSchemaVersion_Call{Call: _e.mock.On("GetSchemaVersion", ctx)}
+}
+
+func (_c *SchemaRepository_GetSchemaVersion_Call) Run(run func(ctx context.Context)) *SchemaRepository_GetSchemaVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SchemaRepository_GetSchemaVersion_Call) Return(_a0 int, _a1 error) *SchemaRepository_GetSchemaVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SchemaRepository_GetSchemaVersion_Call) RunAndReturn(run func(context.Context) (int, error)) *SchemaRepository_GetSchemaVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *SchemaRepository) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SchemaRepository_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type SchemaRepository_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SchemaRepository_Expecter) Ping(ctx interface{}) *SchemaRepository_Ping_Call {
+	return &SchemaRepository_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *SchemaRepository_Ping_Call) Run(run func(ctx context.Context)) *SchemaRepository_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SchemaRepository_Ping_Call) Return(_a0 error) *SchemaRepository_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SchemaRepository_Ping_Call) RunAndReturn(run func(context.Context) error) *SchemaRepository_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSchemaRepository creates a new instance of SchemaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSchemaRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SchemaRepository {
+	mock := &SchemaRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
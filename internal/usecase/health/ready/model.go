@@ -0,0 +1,29 @@
+package ready
+
+// Names of the readiness checks.
+const (
+	CheckDatabase  = "database"
+	CheckSchema    = "schema"
+	CheckFreshness = "freshness"
+)
+
+type Output struct {
+	Ready  bool        `json:"ready"`
+	Checks []CheckData `json:"checks"`
+}
+
+type CheckData struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"` // Why the check failed.
+}
+
+func buildOutput(checks []CheckData) Output {
+	out := Output{Ready: true, Checks: checks}
+
+	for _, c := range checks {
+		out.Ready = out.Ready && c.OK
+	}
+
+	return out
+}
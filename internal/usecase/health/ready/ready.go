@@ -0,0 +1,82 @@
+package ready
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"kiln-exercice/internal/usecase/health/freshness"
+)
+
+type SchemaRepository interface {
+	Ping(ctx context.Context) error
+	GetSchemaVersion(ctx context.Context) (int, error)
+}
+
+type FreshnessUseCase interface {
+	GetFreshness(ctx context.Context) (freshness.Output, error)
+}
+
+type UseCase struct {
+	SchemaRepo    SchemaRepository
+	Freshness     FreshnessUseCase
+	SchemaVersion int // The minimum version of the schema.
+}
+
+func NewUseCase(schemaRepo SchemaRepository, freshness FreshnessUseCase, schemaVersion int) *UseCase {
+	return &UseCase{
+		SchemaRepo:    schemaRepo,
+		Freshness:     freshness,
+		SchemaVersion: schemaVersion,
+	}
+}
+
+// CheckReadiness checks that the database is reachable, that its schema is at least the expected version, and that
+// the data is fresh enough. The API is ready when every check passes. Failures are logged, and only summed up in the
+// output, which is public.
+func (uc *UseCase) CheckReadiness(ctx context.Context) Output {
+	if err := uc.SchemaRepo.Ping(ctx); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Database unreachable")
+
+		// The other checks query the database too.
+		return buildOutput(
+			[]CheckData{
+				{Name: CheckDatabase, Message: "database unreachable"},
+				{Name: CheckSchema, Message: "database unreachable"},
+				{Name: CheckFreshness, Message: "database unreachable"},
+			},
+		)
+	}
+
+	return buildOutput([]CheckData{{Name: CheckDatabase, OK: true}, uc.checkSchema(ctx), uc.checkFreshness(ctx)})
+}
+
+func (uc *UseCase) checkSchema(ctx context.Context) CheckData {
+	version, err := uc.SchemaRepo.GetSchemaVersion(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Schema version unavailable")
+		return CheckData{Name: CheckSchema, Message: "error getting schema version"}
+	}
+
+	if version < uc.SchemaVersion {
+		return CheckData{Name: CheckSchema, Message: fmt.Sprintf("schema version %d, expected at least %d", version, uc.SchemaVersion)}
+	}
+
+	return CheckData{Name: CheckSchema, OK: true}
+}
+
+func (uc *UseCase) checkFreshness(ctx context.Context) CheckData {
+	out, err := uc.Freshness.GetFreshness(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Freshness unavailable")
+		return CheckData{Name: CheckFreshness, Message: "error getting freshness"}
+	}
+
+	if out.Stale {
+		return CheckData{Name: CheckFreshness, Message: strings.Join(out.Reasons, ", ")}
+	}
+
+	return CheckData{Name: CheckFreshness, OK: true}
+}
@@ -0,0 +1,106 @@
+//go:generate mockery
+package ready
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"kiln-exercice/internal/usecase/health/freshness"
+	"kiln-exercice/internal/usecase/health/ready/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_CheckReadiness(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		SchemaRepo *mocks.SchemaRepository
+		Freshness  *mocks.FreshnessUseCase
+	}
+
+	tests := []struct {
+		name string
+		init func(*env)
+		want Output
+	}{
+		{
+			name: "ready",
+			init: func(e *env) {
+				e.SchemaRepo.EXPECT().Ping(context.Background()).Return(nil)
+				e.SchemaRepo.EXPECT().GetSchemaVersion(context.Background()).Return(2, nil)
+				e.Freshness.EXPECT().GetFreshness(context.Background()).Return(freshness.Output{}, nil)
+			},
+			want: Output{
+				Ready: true,
+				Checks: []CheckData{
+					{Name: CheckDatabase, OK: true}, {Name: CheckSchema, OK: true}, {Name: CheckFreshness, OK: true},
+				},
+			},
+		},
+		{
+			name: "database unreachable",
+			init: func(e *env) {
+				e.SchemaRepo.EXPECT().Ping(context.Background()).Return(errors.New("connection refused"))
+			},
+			want: Output{
+				Checks: []CheckData{
+					{Name: CheckDatabase, Message: "database unreachable"},
+					{Name: CheckSchema, Message: "database unreachable"},
+					{Name: CheckFreshness, Message: "database unreachable"},
+				},
+			},
+		},
+		{
+			name: "outdated schema and stale data",
+			init: func(e *env) {
+				e.SchemaRepo.EXPECT().Ping(context.Background()).Return(nil)
+				e.SchemaRepo.EXPECT().GetSchemaVersion(context.Background()).Return(1, nil)
+				e.Freshness.EXPECT().GetFreshness(context.Background()).Return(
+					freshness.Output{Stale: true, Reasons: []string{"never polled", "no delegation"}}, nil,
+				)
+			},
+			want: Output{
+				Checks: []CheckData{
+					{Name: CheckDatabase, OK: true},
+					{Name: CheckSchema, Message: "schema version 1, expected at least 2"},
+					{Name: CheckFreshness, Message: "never polled, no delegation"},
+				},
+			},
+		},
+		{
+			name: "check errors",
+			init: func(e *env) {
+				e.SchemaRepo.EXPECT().Ping(context.Background()).Return(nil)
+				e.SchemaRepo.EXPECT().GetSchemaVersion(context.Background()).Return(0, errors.New("relation does not exist"))
+				e.Freshness.EXPECT().GetFreshness(context.Background()).Return(
+					freshness.Output{}, api.NewError(api.Unknown, "error getting last polling", nil),
+				)
+			},
+			want: Output{
+				Checks: []CheckData{
+					{Name: CheckDatabase, OK: true},
+					{Name: CheckSchema, Message: "error getting schema version"},
+					{Name: CheckFreshness, Message: "error getting freshness"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				e := env{SchemaRepo: mocks.NewSchemaRepository(t), Freshness: mocks.NewFreshnessUseCase(t)}
+				tt.init(&e)
+
+				got := NewUseCase(e.SchemaRepo, e.Freshness, 2).CheckReadiness(context.Background())
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CheckReadiness() got = %+v, want %+v", got, tt.want)
+				}
+			},
+		)
+	}
+}
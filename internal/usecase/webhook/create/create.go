@@ -0,0 +1,121 @@
+package create
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+	"kiln-exercice/pkg/tezos"
+	"kiln-exercice/pkg/webhook"
+)
+
+// secretSize is the number of random bytes of a webhook secret.
+const secretSize = 32
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook model.Webhook) (model.Webhook, error)
+}
+
+type UseCase struct {
+	WebhookRepo WebhookRepository
+}
+
+func NewUseCase(webhookRepo WebhookRepository) *UseCase {
+	return &UseCase{
+		WebhookRepo: webhookRepo,
+	}
+}
+
+// CreateWebhook subscribes a URL to the delegations matching the input filters.
+// The returned secret signs every payload sent to the URL, it cannot be retrieved afterward. The webhook is managed
+// by the API key of the client authenticated in ctx.
+func (uc *UseCase) CreateWebhook(ctx context.Context, input Input) (Output, error) {
+	if err := validateInput(input); err != nil {
+		return Output{}, err
+	}
+
+	client, _ := api.ClientFromContext(ctx)
+
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return Output{}, api.NewError(api.Unknown, "error generating webhook secret", err)
+	}
+
+	webhook, err := uc.WebhookRepo.CreateWebhook(
+		ctx, model.Webhook{
+			URL:       input.URL,
+			Secret:    hex.EncodeToString(secret),
+			Delegator: input.Delegator,
+			Baker:     input.Baker,
+			MinAmount: input.MinAmount,
+			Kind:      input.Kind,
+			APIKeyID:  client.ID,
+		},
+	)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error creating webhook", err)
+	}
+
+	return buildOutput(webhook), nil
+}
+
+func validateInput(input Input) error {
+	u, err := url.Parse(input.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid url: %q, expected an absolute http(s) url", input.URL), err)
+	}
+
+	// Obvious internal hosts are refused early, the dispatcher checking the address every host name resolves to.
+	if !publicHost(u.Hostname()) {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid url: %q, expected a public host", input.URL), nil)
+	}
+
+	if input.Delegator != "" {
+		if err = tezos.ValidateAddress(input.Delegator); err != nil {
+			return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid delegator: %s", err.Error()), err)
+		}
+	}
+
+	if input.Baker != "" {
+		if err = tezos.ValidateAddress(input.Baker); err != nil {
+			return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid baker: %s", err.Error()), err)
+		}
+	}
+
+	if input.MinAmount.IsNegative() {
+		return api.NewError(api.InvalidArgument, fmt.Sprintf("invalid min_amount: %s must not be negative", input.MinAmount), nil)
+	}
+
+	switch input.Kind {
+	case "", model.KindDelegation:
+	case model.KindUndelegation:
+		if input.Baker != "" {
+			return api.NewError(api.InvalidArgument, "invalid kind: undelegations have no baker to filter on", nil)
+		}
+	default:
+		return api.NewError(
+			api.InvalidArgument,
+			fmt.Sprintf("invalid kind: %q, expected %s or %s", input.Kind, model.KindDelegation, model.KindUndelegation),
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// publicHost reports whether a host may be public: a host name other than localhost, or a public IP address.
+func publicHost(host string) bool {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return webhook.PublicAddr(addr)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	return host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
@@ -0,0 +1,169 @@
+//go:generate mockery
+package create
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook/create/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+const (
+	hookURL   = "https://crm.example.com/hooks/delegations"
+	delegator = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+	baker     = "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+)
+
+func TestUseCase_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		WebhookRepo *mocks.WebhookRepository
+	}
+
+	var (
+		ctx       = api.ContextWithClient(context.Background(), api.Client{ID: 3, Name: "crm"})
+		createdAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	// withSecret matches a webhook holding a generated secret, and otherwise equal to want.
+	withSecret := func(want model.Webhook) any {
+		return mock.MatchedBy(
+			func(got model.Webhook) bool {
+				secret := got.Secret
+				got.Secret = ""
+				return len(secret) == 2*secretSize && reflect.DeepEqual(got, want)
+			},
+		)
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name: "happy path",
+			env:  env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			input: Input{
+				URL: hookURL, Delegator: delegator, Baker: baker, MinAmount: decimal.RequireFromString("100"), Kind: model.KindDelegation,
+			},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().CreateWebhook(
+					ctx, withSecret(
+						model.Webhook{
+							URL: hookURL, Delegator: delegator, Baker: baker, MinAmount: decimal.RequireFromString("100"), Kind: model.KindDelegation,
+							APIKeyID: 3,
+						},
+					),
+				).Return(
+					model.Webhook{
+						ID: 7, URL: hookURL, Secret: "s3cr3t", Delegator: delegator, Baker: baker,
+						MinAmount: decimal.RequireFromString("100"), Kind: model.KindDelegation, APIKeyID: 3, CreatedAt: createdAt,
+					}, nil,
+				)
+			},
+			want: Output{
+				ID: 7, URL: hookURL, Secret: "s3cr3t", Delegator: delegator, Baker: baker,
+				MinAmount: decimal.RequireFromString("100"), Kind: model.KindDelegation, CreatedAt: createdAt,
+			},
+		},
+		{
+			name:     "relative url",
+			input:    Input{URL: "/hooks"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "unsupported scheme",
+			input:    Input{URL: "ftp://crm.example.com/hooks"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "loopback host",
+			input:    Input{URL: "http://localhost:8080/hooks"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "metadata address",
+			input:    Input{URL: "http://169.254.169.254/latest/meta-data"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "invalid delegator",
+			input:    Input{URL: hookURL, Delegator: "tz1abc"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "negative min amount",
+			input:    Input{URL: hookURL, MinAmount: decimal.RequireFromString("-1")},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "unknown kind",
+			input:    Input{URL: hookURL, Kind: "transfer"},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:     "undelegations of a baker",
+			input:    Input{URL: hookURL, Baker: baker, Kind: model.KindUndelegation},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "repository error",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			input: Input{URL: hookURL},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().CreateWebhook(ctx, mock.Anything).Return(model.Webhook{}, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.WebhookRepo)
+
+				got, err := uc.CreateWebhook(ctx, tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("CreateWebhook() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("CreateWebhook() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CreateWebhook() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,41 @@
+package create
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	URL       string
+	Delegator string          // Empty matches every delegator.
+	Baker     string          // Empty matches every baker.
+	MinAmount decimal.Decimal // Zero matches every amount.
+	Kind      string          // "delegation", "undelegation" or empty for both.
+}
+
+type Output struct {
+	ID        int             `json:"id"`
+	URL       string          `json:"url"`
+	Secret    string          `json:"secret"` // Only returned on creation.
+	Delegator string          `json:"delegator,omitempty"`
+	Baker     string          `json:"baker,omitempty"`
+	MinAmount decimal.Decimal `json:"min_amount"`
+	Kind      string          `json:"kind,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func buildOutput(webhook model.Webhook) Output {
+	return Output{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Delegator: webhook.Delegator,
+		Baker:     webhook.Baker,
+		MinAmount: webhook.MinAmount,
+		Kind:      webhook.Kind,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
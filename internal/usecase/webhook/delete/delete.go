@@ -0,0 +1,49 @@
+package delete
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook"
+	"kiln-exercice/pkg/api"
+)
+
+type WebhookRepository interface {
+	GetWebhook(ctx context.Context, id int) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+}
+
+type UseCase struct {
+	WebhookRepo WebhookRepository
+}
+
+func NewUseCase(webhookRepo WebhookRepository) *UseCase {
+	return &UseCase{
+		WebhookRepo: webhookRepo,
+	}
+}
+
+// DeleteWebhook unsubscribes a webhook. Its pending deliveries are dropped along with its delivery log.
+// The webhooks the client authenticated in ctx does not manage are not found.
+func (uc *UseCase) DeleteWebhook(ctx context.Context, input Input) error {
+	hook, err := uc.WebhookRepo.GetWebhook(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !webhook.Manages(ctx, hook)) {
+		return api.NewError(api.NotFound, fmt.Sprintf("no webhook found for id %d", input.ID), err)
+	}
+	if err != nil {
+		return api.NewError(api.Unknown, "error getting webhook", err)
+	}
+
+	err = uc.WebhookRepo.DeleteWebhook(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return api.NewError(api.NotFound, fmt.Sprintf("no webhook found for id %d", input.ID), err)
+	}
+	if err != nil {
+		return api.NewError(api.Unknown, "error deleting webhook", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+//go:generate mockery
+package delete
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook/delete/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_DeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		WebhookRepo *mocks.WebhookRepository
+	}
+
+	var (
+		owner = api.ContextWithClient(context.Background(), api.Client{ID: 3, Name: "crm"})
+		other = api.ContextWithClient(context.Background(), api.Client{ID: 4, Name: "billing"})
+		admin = api.ContextWithClient(context.Background(), api.Client{ID: 5, Name: "ops", Admin: true})
+		hook  = model.Webhook{ID: 7, APIKeyID: 3}
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		ctx      context.Context
+		input    Input
+		init     func(*env)
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(hook, nil)
+				e.WebhookRepo.EXPECT().DeleteWebhook(owner, 7).Return(nil)
+			},
+		},
+		{
+			name:  "admin",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   admin,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(admin, 7).Return(hook, nil)
+				e.WebhookRepo.EXPECT().DeleteWebhook(admin, 7).Return(nil)
+			},
+		},
+		{
+			name:  "not found",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{}, sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "webhook of another key",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   other,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(other, 7).Return(hook, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "webhook without key",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{ID: 7}, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "deleted concurrently",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(hook, nil)
+				e.WebhookRepo.EXPECT().DeleteWebhook(owner, 7).Return(sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{ID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(hook, nil)
+				e.WebhookRepo.EXPECT().DeleteWebhook(owner, 7).Return(errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.WebhookRepo)
+
+				err := uc.DeleteWebhook(tt.ctx, tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("DeleteWebhook() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("DeleteWebhook() unexpected error = %v", err)
+				}
+			},
+		)
+	}
+}
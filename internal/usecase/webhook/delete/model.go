@@ -0,0 +1,5 @@
+package delete
+
+type Input struct {
+	ID int
+}
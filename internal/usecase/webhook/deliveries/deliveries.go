@@ -0,0 +1,59 @@
+package deliveries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook"
+	"kiln-exercice/pkg/api"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+type WebhookRepository interface {
+	GetWebhook(ctx context.Context, id int) (model.Webhook, error)
+	ListDeliveryAttempts(ctx context.Context, webhookID, limit int) ([]model.WebhookDeliveryAttempt, error)
+}
+
+type UseCase struct {
+	WebhookRepo WebhookRepository
+}
+
+func NewUseCase(webhookRepo WebhookRepository) *UseCase {
+	return &UseCase{
+		WebhookRepo: webhookRepo,
+	}
+}
+
+// ListDeliveries returns the delivery log of a webhook, most recent attempts first.
+// The webhooks the client authenticated in ctx does not manage are not found.
+func (uc *UseCase) ListDeliveries(ctx context.Context, input Input) (Output, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	if limit < 0 || limit > MaxLimit {
+		return Output{}, api.NewError(api.InvalidArgument, fmt.Sprintf("invalid limit: %d, expected between 1 and %d", limit, MaxLimit), nil)
+	}
+
+	if hook, err := uc.WebhookRepo.GetWebhook(ctx, input.WebhookID); errors.Is(err, sql.ErrNoRows) ||
+		(err == nil && !webhook.Manages(ctx, hook)) {
+		return Output{}, api.NewError(api.NotFound, fmt.Sprintf("no webhook found for id %d", input.WebhookID), err)
+	} else if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error getting webhook", err)
+	}
+
+	attempts, err := uc.WebhookRepo.ListDeliveryAttempts(ctx, input.WebhookID, limit)
+	if err != nil {
+		return Output{}, api.NewError(api.Unknown, "error listing delivery attempts", err)
+	}
+
+	return buildOutput(attempts), nil
+}
@@ -0,0 +1,162 @@
+//go:generate mockery
+package deliveries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook/deliveries/mocks"
+	"kiln-exercice/pkg/api"
+)
+
+func TestUseCase_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		WebhookRepo *mocks.WebhookRepository
+	}
+
+	var (
+		owner       = api.ContextWithClient(context.Background(), api.Client{ID: 3, Name: "crm"})
+		other       = api.ContextWithClient(context.Background(), api.Client{ID: 4, Name: "billing"})
+		admin       = api.ContextWithClient(context.Background(), api.Client{ID: 5, Name: "ops", Admin: true})
+		attemptedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name     string
+		env      env
+		ctx      context.Context
+		input    Input
+		init     func(*env)
+		want     Output
+		wantCode api.Code
+	}{
+		{
+			name:  "happy path",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{WebhookID: 7},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{ID: 7, APIKeyID: 3}, nil)
+				e.WebhookRepo.EXPECT().ListDeliveryAttempts(owner, 7, DefaultLimit).Return(
+					[]model.WebhookDeliveryAttempt{
+						{
+							ID: 2, DeliveryID: 1, DelegationID: 3, Status: model.DeliveryDelivered,
+							AttemptedAt: attemptedAt.Add(time.Minute), StatusCode: 200, DurationMS: 12,
+						},
+						{
+							ID: 1, DeliveryID: 1, DelegationID: 3, Status: model.DeliveryPending,
+							AttemptedAt: attemptedAt, Error: "connection refused", DurationMS: 3,
+						},
+					}, nil,
+				)
+			},
+			want: Output{
+				Attempts: []AttemptData{
+					{
+						ID: 2, DeliveryID: 1, DelegationID: 3, Status: model.DeliveryDelivered,
+						AttemptedAt: attemptedAt.Add(time.Minute), StatusCode: 200, DurationMS: 12,
+					},
+					{
+						ID: 1, DeliveryID: 1, DelegationID: 3, Status: model.DeliveryPending,
+						AttemptedAt: attemptedAt, Error: "connection refused", DurationMS: 3,
+					},
+				},
+			},
+		},
+		{
+			name:  "admin",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   admin,
+			input: Input{WebhookID: 7, Limit: 10},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(admin, 7).Return(model.Webhook{ID: 7, APIKeyID: 3}, nil)
+				e.WebhookRepo.EXPECT().ListDeliveryAttempts(admin, 7, 10).Return(nil, nil)
+			},
+			want: Output{Attempts: []AttemptData{}},
+		},
+		{
+			name:     "limit too large",
+			ctx:      owner,
+			input:    Input{WebhookID: 7, Limit: MaxLimit + 1},
+			init:     func(e *env) {},
+			wantCode: api.InvalidArgument,
+		},
+		{
+			name:  "not found",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{WebhookID: 7, Limit: 10},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{}, sql.ErrNoRows)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "webhook of another key",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   other,
+			input: Input{WebhookID: 7, Limit: 10},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(other, 7).Return(model.Webhook{ID: 7, APIKeyID: 3}, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "webhook without key",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{WebhookID: 7, Limit: 10},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{ID: 7}, nil)
+			},
+			wantCode: api.NotFound,
+		},
+		{
+			name:  "repository error",
+			env:   env{WebhookRepo: mocks.NewWebhookRepository(t)},
+			ctx:   owner,
+			input: Input{WebhookID: 7, Limit: 10},
+			init: func(e *env) {
+				e.WebhookRepo.EXPECT().GetWebhook(owner, 7).Return(model.Webhook{ID: 7, APIKeyID: 3}, nil)
+				e.WebhookRepo.EXPECT().ListDeliveryAttempts(owner, 7, 10).Return(nil, errors.New("boom"))
+			},
+			wantCode: api.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.WebhookRepo)
+
+				got, err := uc.ListDeliveries(tt.ctx, tt.input)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+						t.Errorf("ListDeliveries() error = %v, want code %v", err, tt.wantCode)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Errorf("ListDeliveries() unexpected error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListDeliveries() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+type WebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookRepository) EXPECT() *WebhookRepository_Expecter {
+	return &WebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhook provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) GetWebhook(ctx context.Context, id int) (model.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (model.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type WebhookRepository_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *WebhookRepository_Expecter) GetWebhook(ctx interface{}, id interface{}) *WebhookRepository_GetWebhook_Call {
+	return &WebhookRepository_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, id)}
+}
+
+func (_c *WebhookRepository_GetWebhook_Call) Run(run func(ctx context.Context, id int)) *WebhookRepository_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhook_Call) Return(_a0 model.Webhook, _a1 error) *WebhookRepository_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhook_Call) RunAndReturn(run func(context.Context, int) (model.Webhook, error)) *WebhookRepository_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveryAttempts provides a mock function with given fields: ctx, webhookID, limit
+func (_m *WebhookRepository) ListDeliveryAttempts(ctx context.Context, webhookID int, limit int) ([]model.WebhookDeliveryAttempt, error) {
+	ret := _m.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveryAttempts")
+	}
+
+	var r0 []model.WebhookDeliveryAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]model.WebhookDeliveryAttempt, error)); ok {
+		return rf(ctx, webhookID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []model.WebhookDeliveryAttempt); ok {
+		r0 = rf(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WebhookDeliveryAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_ListDeliveryAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveryAttempts'
+type WebhookRepository_ListDeliveryAttempts_Call struct {
+	*mock.Call
+}
+
+// ListDeliveryAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID int
+//   - limit int
+func (_e *WebhookRepository_Expecter) ListDeliveryAttempts(ctx interface{}, webhookID interface{}, limit interface{}) *WebhookRepository_ListDeliveryAttempts_Call {
+	return &WebhookRepository_ListDeliveryAttempts_Call{Call: _e.mock.On("ListDeliveryAttempts", ctx, webhookID, limit)}
+}
+
+func (_c *WebhookRepository_ListDeliveryAttempts_Call) Run(run func(ctx context.Context, webhookID int, limit int)) *WebhookRepository_ListDeliveryAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_ListDeliveryAttempts_Call) Return(_a0 []model.WebhookDeliveryAttempt, _a1 error) *WebhookRepository_ListDeliveryAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_ListDeliveryAttempts_Call) RunAndReturn(run func(context.Context, int, int) ([]model.WebhookDeliveryAttempt, error)) *WebhookRepository_ListDeliveryAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
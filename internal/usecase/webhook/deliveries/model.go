@@ -0,0 +1,46 @@
+package deliveries
+
+import (
+	"time"
+
+	"kiln-exercice/internal/model"
+)
+
+type Input struct {
+	WebhookID int
+	Limit     int // Zero means DefaultLimit.
+}
+
+type Output struct {
+	Attempts []AttemptData
+}
+
+type AttemptData struct {
+	ID           int64     `json:"id"`
+	DeliveryID   int64     `json:"delivery_id"`
+	DelegationID int       `json:"delegation_id"`
+	Status       string    `json:"status"`
+	AttemptedAt  time.Time `json:"attempted_at"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DurationMS   int64     `json:"duration_ms"`
+}
+
+func buildOutput(attempts []model.WebhookDeliveryAttempt) Output {
+	out := Output{Attempts: make([]AttemptData, len(attempts))}
+
+	for i, a := range attempts {
+		out.Attempts[i] = AttemptData{
+			ID:           a.ID,
+			DeliveryID:   a.DeliveryID,
+			DelegationID: a.DelegationID,
+			Status:       a.Status,
+			AttemptedAt:  a.AttemptedAt,
+			StatusCode:   a.StatusCode,
+			Error:        a.Error,
+			DurationMS:   a.DurationMS,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,119 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+
+	"kiln-exercice/internal/model"
+)
+
+type DeliveryRepository interface {
+	ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]model.PendingDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) error
+}
+
+type Sender interface {
+	Send(ctx context.Context, url, secret string, id int64, payload []byte) (int, error)
+}
+
+type UseCase struct {
+	DeliveryRepo DeliveryRepository
+	Sender       Sender
+	Config       Config
+	TimeNow      func() time.Time
+}
+
+func NewUseCase(deliveryRepo DeliveryRepository, sender Sender, config Config, timeNow func() time.Time) *UseCase {
+	return &UseCase{
+		DeliveryRepo: deliveryRepo,
+		Sender:       sender,
+		Config:       config,
+		TimeNow:      timeNow,
+	}
+}
+
+// DispatchDeliveries sends the due webhook deliveries, batch after batch until none is left.
+// Failed deliveries are retried with an exponential backoff, until they are dead after Config.MaxAttempts attempts.
+func (uc *UseCase) DispatchDeliveries(ctx context.Context) error {
+	for {
+		deliveries, err := uc.DeliveryRepo.ClaimDeliveries(ctx, uc.Config.Lease, uc.Config.BatchSize)
+		if err != nil {
+			return fmt.Errorf("claim deliveries: %w", err)
+		}
+
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(max(uc.Config.Concurrency, 1))
+
+		for _, delivery := range deliveries {
+			g.Go(
+				func() error {
+					return uc.deliver(gctx, delivery)
+				},
+			)
+		}
+
+		if err = g.Wait(); err != nil {
+			return err
+		}
+
+		if len(deliveries) < uc.Config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// deliver sends a delivery once and records the attempt. Only failing to record the attempt is an error:
+// the delivery is then sent again once its lease expires.
+func (uc *UseCase) deliver(ctx context.Context, delivery model.PendingDelivery) error {
+	payload, err := json.Marshal(buildEvent(delivery))
+	if err != nil {
+		return fmt.Errorf("marshal delivery %d: %w", delivery.ID, err)
+	}
+
+	start := uc.TimeNow()
+	statusCode, sendErr := uc.Sender.Send(ctx, delivery.URL, delivery.Secret, delivery.ID, payload)
+	end := uc.TimeNow()
+
+	if ctx.Err() != nil {
+		return ctx.Err() // The attempt was interrupted, it does not count.
+	}
+
+	result := delivery.WebhookDelivery
+	result.Attempts++
+
+	attempt := model.WebhookDeliveryAttempt{
+		DeliveryID:   delivery.ID,
+		DelegationID: delivery.DelegationID,
+		AttemptedAt:  start,
+		StatusCode:   statusCode,
+		DurationMS:   end.Sub(start).Milliseconds(),
+	}
+
+	switch {
+	case sendErr == nil:
+		result.Status = model.DeliveryDelivered
+	case result.Attempts >= uc.Config.MaxAttempts:
+		result.Status = model.DeliveryDead
+		log.Warn().Err(sendErr).Int64("delivery_id", delivery.ID).Int("webhook_id", delivery.WebhookID).
+			Int("attempts", result.Attempts).Msg("webhook delivery dead")
+	default:
+		result.NextAttemptAt = end.Add(uc.Config.Backoff.Delay(result.Attempts))
+	}
+
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+
+	attempt.Status = result.Status
+
+	if err = uc.DeliveryRepo.RecordDeliveryAttempt(ctx, result, attempt); err != nil {
+		return fmt.Errorf("record attempt of delivery %d: %w", delivery.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+//go:generate mockery
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/internal/usecase/webhook/dispatch/mocks"
+	"kiln-exercice/pkg/scheduler"
+)
+
+const hookURL = "https://crm.example.com/hooks/delegations"
+
+func TestUseCase_DispatchDeliveries(t *testing.T) {
+	t.Parallel()
+
+	type env struct {
+		DeliveryRepo *mocks.DeliveryRepository
+		Sender       *mocks.Sender
+	}
+
+	var (
+		now    = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		config = Config{
+			BatchSize:   2,
+			Concurrency: 2,
+			MaxAttempts: 3,
+			Backoff:     scheduler.Backoff{Initial: time.Minute, Max: time.Hour},
+			Lease:       time.Minute,
+		}
+		delegation = model.Delegation{
+			ID: 3, Datetime: now, Amount: decimal.RequireFromString("100"), Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL",
+			Baker: "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft", Height: 42, TxHash: "oo1",
+		}
+		pending = func(id int64, attempts int) model.PendingDelivery {
+			return model.PendingDelivery{
+				WebhookDelivery: model.WebhookDelivery{
+					ID: id, WebhookID: 7, DelegationID: 3, Status: model.DeliveryPending, Attempts: attempts, NextAttemptAt: now, CreatedAt: now,
+				},
+				URL: hookURL, Secret: "secret", Delegation: delegation,
+			}
+		}
+		payload = func(id int64) []byte {
+			b, err := json.Marshal(
+				Event{
+					ID: id, Type: model.KindDelegation, CreatedAt: now,
+					Data: EventData{
+						Hash: "oo1", Timestamp: now, Amount: decimal.RequireFromString("100"),
+						Delegator: delegation.Delegator, Baker: delegation.Baker, Level: "42",
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		}
+	)
+
+	// expectRecorded expects the outcome of an attempt of a delivery to be recorded.
+	expectRecorded := func(e *env, d model.PendingDelivery, status string, next time.Time, statusCode int, errMsg string) {
+		delivery := d.WebhookDelivery
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt = status, delivery.Attempts+1, next
+
+		e.DeliveryRepo.EXPECT().RecordDeliveryAttempt(
+			mock.Anything, delivery, model.WebhookDeliveryAttempt{
+				DeliveryID: d.ID, DelegationID: 3, Status: status, AttemptedAt: now, StatusCode: statusCode, Error: errMsg,
+			},
+		).Return(nil)
+	}
+
+	tests := []struct {
+		name    string
+		env     env
+		init    func(*env)
+		wantErr bool
+	}{
+		{
+			name: "delivered",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t), Sender: mocks.NewSender(t)},
+			init: func(e *env) {
+				d := pending(1, 0)
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d}, nil)
+				e.Sender.EXPECT().Send(mock.Anything, hookURL, "secret", int64(1), payload(1)).Return(http.StatusOK, nil)
+				expectRecorded(e, d, model.DeliveryDelivered, now, http.StatusOK, "")
+			},
+		},
+		{
+			name: "retried with backoff",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t), Sender: mocks.NewSender(t)},
+			init: func(e *env) {
+				d := pending(1, 1)
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d}, nil)
+				e.Sender.EXPECT().Send(mock.Anything, hookURL, "secret", int64(1), payload(1)).Return(
+					http.StatusServiceUnavailable, errors.New("unexpected status: 503 Service Unavailable"),
+				)
+				expectRecorded(
+					e, d, model.DeliveryPending, now.Add(2*time.Minute), http.StatusServiceUnavailable,
+					"unexpected status: 503 Service Unavailable",
+				)
+			},
+		},
+		{
+			name: "dead after max attempts",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t), Sender: mocks.NewSender(t)},
+			init: func(e *env) {
+				d := pending(1, 2)
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d}, nil)
+				e.Sender.EXPECT().Send(mock.Anything, hookURL, "secret", int64(1), payload(1)).Return(0, errors.New("connection refused"))
+				expectRecorded(e, d, model.DeliveryDead, now, 0, "connection refused")
+			},
+		},
+		{
+			name: "batch after batch",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t), Sender: mocks.NewSender(t)},
+			init: func(e *env) {
+				d1, d2, d3 := pending(1, 0), pending(2, 0), pending(3, 0)
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d1, d2}, nil).Once()
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d3}, nil).Once()
+
+				for _, d := range []model.PendingDelivery{d1, d2, d3} {
+					e.Sender.EXPECT().Send(mock.Anything, hookURL, "secret", d.ID, payload(d.ID)).Return(http.StatusOK, nil)
+					expectRecorded(e, d, model.DeliveryDelivered, now, http.StatusOK, "")
+				}
+			},
+		},
+		{
+			name: "claim error",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t)},
+			init: func(e *env) {
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return(nil, errors.New("boom"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "record error",
+			env:  env{DeliveryRepo: mocks.NewDeliveryRepository(t), Sender: mocks.NewSender(t)},
+			init: func(e *env) {
+				d := pending(1, 0)
+				e.DeliveryRepo.EXPECT().ClaimDeliveries(mock.Anything, time.Minute, 2).Return([]model.PendingDelivery{d}, nil)
+				e.Sender.EXPECT().Send(mock.Anything, hookURL, "secret", int64(1), payload(1)).Return(http.StatusOK, nil)
+				e.DeliveryRepo.EXPECT().RecordDeliveryAttempt(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				tt.init(&tt.env)
+
+				uc := NewUseCase(tt.env.DeliveryRepo, tt.env.Sender, config, func() time.Time { return now })
+
+				err := uc.DispatchDeliveries(context.Background())
+				assert.Equal(t, tt.wantErr, err != nil, "DispatchDeliveries() error = %v", err)
+			},
+		)
+	}
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "kiln-exercice/internal/model"
+
+	time "time"
+)
+
+// DeliveryRepository is an autogenerated mock type for the DeliveryRepository type
+type DeliveryRepository struct {
+	mock.Mock
+}
+
+type DeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeliveryRepository) EXPECT() *DeliveryRepository_Expecter {
+	return &DeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, lease, limit
+func (_m *DeliveryRepository) ClaimDeliveries(ctx context.Context, lease time.Duration, limit int) ([]model.PendingDelivery, error) {
+	ret := _m.Called(ctx, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeliveries")
+	}
+
+	var r0 []model.PendingDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) ([]model.PendingDelivery, error)); ok {
+		return rf(ctx, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) []model.PendingDelivery); ok {
+		r0 = rf(ctx, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PendingDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int) error); ok {
+		r1 = rf(ctx, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryRepository_ClaimDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeliveries'
+type DeliveryRepository_ClaimDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lease time.Duration
+//   - limit int
+func (_e *DeliveryRepository_Expecter) ClaimDeliveries(ctx interface{}, lease interface{}, limit interface{}) *DeliveryRepository_ClaimDeliveries_Call {
+	return &DeliveryRepository_ClaimDeliveries_Call{Call: _e.mock.On("ClaimDeliveries", ctx, lease, limit)}
+}
+
+func (_c *DeliveryRepository_ClaimDeliveries_Call) Run(run func(ctx context.Context, lease time.Duration, limit int)) *DeliveryRepository_ClaimDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepository_ClaimDeliveries_Call) Return(_a0 []model.PendingDelivery, _a1 error) *DeliveryRepository_ClaimDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryRepository_ClaimDeliveries_Call) RunAndReturn(run func(context.Context, time.Duration, int) ([]model.PendingDelivery, error)) *DeliveryRepository_ClaimDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDeliveryAttempt provides a mock function with given fields: ctx, delivery, attempt
+func (_m *DeliveryRepository) RecordDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) error {
+	ret := _m.Called(ctx, delivery, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeliveryAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.WebhookDelivery, model.WebhookDeliveryAttempt) error); ok {
+		r0 = rf(ctx, delivery, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepository_RecordDeliveryAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeliveryAttempt'
+type DeliveryRepository_RecordDeliveryAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordDeliveryAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery model.WebhookDelivery
+//   - attempt model.WebhookDeliveryAttempt
+func (_e *DeliveryRepository_Expecter) RecordDeliveryAttempt(ctx interface{}, delivery interface{}, attempt interface{}) *DeliveryRepository_RecordDeliveryAttempt_Call {
+	return &DeliveryRepository_RecordDeliveryAttempt_Call{Call: _e.mock.On("RecordDeliveryAttempt", ctx, delivery, attempt)}
+}
+
+func (_c *DeliveryRepository_RecordDeliveryAttempt_Call) Run(run func(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt)) *DeliveryRepository_RecordDeliveryAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.WebhookDelivery), args[2].(model.WebhookDeliveryAttempt))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepository_RecordDeliveryAttempt_Call) Return(_a0 error) *DeliveryRepository_RecordDeliveryAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepository_RecordDeliveryAttempt_Call) RunAndReturn(run func(context.Context, model.WebhookDelivery, model.WebhookDeliveryAttempt) error) *DeliveryRepository_RecordDeliveryAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeliveryRepository creates a new instance of DeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryRepository {
+	mock := &DeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sender is an autogenerated mock type for the Sender type
+type Sender struct {
+	mock.Mock
+}
+
+type Sender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Sender) EXPECT() *Sender_Expecter {
+	return &Sender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, url, secret, id, payload
+func (_m *Sender) Send(ctx context.Context, url string, secret string, id int64, payload []byte) (int, error) {
+	ret := _m.Called(ctx, url, secret, id, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, []byte) (int, error)); ok {
+		return rf(ctx, url, secret, id, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, []byte) int); ok {
+		r0 = rf(ctx, url, secret, id, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, []byte) error); ok {
+		r1 = rf(ctx, url, secret, id, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type Sender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - secret string
+//   - id int64
+//   - payload []byte
+func (_e *Sender_Expecter) Send(ctx interface{}, url interface{}, secret interface{}, id interface{}, payload interface{}) *Sender_Send_Call {
+	return &Sender_Send_Call{Call: _e.mock.On("Send", ctx, url, secret, id, payload)}
+}
+
+func (_c *Sender_Send_Call) Run(run func(ctx context.Context, url string, secret string, id int64, payload []byte)) *Sender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *Sender_Send_Call) Return(_a0 int, _a1 error) *Sender_Send_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Sender_Send_Call) RunAndReturn(run func(context.Context, string, string, int64, []byte) (int, error)) *Sender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSender creates a new instance of Sender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sender {
+	mock := &Sender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+package dispatch
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/scheduler"
+)
+
+// Config tunes the delivery of webhooks.
+type Config struct {
+	BatchSize   int               // Number of deliveries claimed at once.
+	Concurrency int               // Number of deliveries sent in parallel.
+	MaxAttempts int               // Attempts after which a delivery is dead.
+	Backoff     scheduler.Backoff // Delay before retrying a failed delivery.
+	Lease       time.Duration     // Time a claimed delivery is reserved to its dispatcher, must exceed the send timeout.
+}
+
+// Event is the payload posted to webhooks. Its id is the delivery id, identical across retries.
+type Event struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      EventData `json:"data"`
+}
+
+type EventData struct {
+	Hash      string          `json:"hash"`
+	Timestamp time.Time       `json:"timestamp"`
+	Amount    decimal.Decimal `json:"amount"`
+	Delegator string          `json:"delegator"`
+	Baker     string          `json:"baker,omitempty"`
+	Level     string          `json:"level"`
+}
+
+func buildEvent(delivery model.PendingDelivery) Event {
+	d := delivery.Delegation
+
+	return Event{
+		ID:        delivery.ID,
+		Type:      d.Kind(),
+		CreatedAt: delivery.CreatedAt,
+		Data: EventData{
+			Hash:      d.TxHash,
+			Timestamp: d.Datetime,
+			Amount:    d.Amount,
+			Delegator: d.Delegator,
+			Baker:     d.Baker,
+			Level:     strconv.Itoa(d.Height),
+		},
+	}
+}
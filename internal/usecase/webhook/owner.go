@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	"context"
+
+	"kiln-exercice/internal/model"
+	"kiln-exercice/pkg/api"
+)
+
+// Manages reports whether the client authenticated in ctx manages a webhook: admins manage every webhook, and other
+// clients the ones created with their API key.
+func Manages(ctx context.Context, webhook model.Webhook) bool {
+	client, ok := api.ClientFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	return client.Admin || (webhook.APIKeyID != 0 && webhook.APIKeyID == client.ID)
+}
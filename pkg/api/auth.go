@@ -0,0 +1,27 @@
+package api
+
+import "context"
+
+// Client is an authenticated client of the API.
+type Client struct {
+	ID    int
+	Name  string
+	Admin bool // Managing the resources of every client.
+}
+
+// Authenticator authenticates the API key of a client for a scope, returning an Error coded Unauthenticated for
+// an invalid key, PermissionDenied for a key without the scope, or ResourceExhausted for a key over its quota.
+type Authenticator func(ctx context.Context, key, scope string) (Client, error)
+
+type clientKey struct{}
+
+// ContextWithClient returns a copy of ctx holding the authenticated client.
+func ContextWithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext returns the authenticated client held by ctx, if any.
+func ClientFromContext(ctx context.Context) (Client, bool) {
+	client, ok := ctx.Value(clientKey{}).(Client)
+	return client, ok
+}
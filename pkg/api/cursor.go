@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const CursorKey = "cursor"
+
+// EncodeCursor encodes v into an opaque, URL safe, cursor.
+func EncodeCursor(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes a cursor produced by EncodeCursor into v.
+func DecodeCursor(cursor string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("decode cursor: %w", err)
+	}
+
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("unmarshal cursor: %w", err)
+	}
+
+	return nil
+}
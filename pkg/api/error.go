@@ -8,6 +8,10 @@ type Error struct {
 }
 
 func (e Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+
 	return e.Err.Error()
 }
 
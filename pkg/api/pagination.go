@@ -5,11 +5,13 @@ const (
 	PageSizeKey       = "page_size"
 	DefaultPageNumber = 1
 	DefaultPageSize   = 100
+	MaxPageSize       = 1000
 )
 
 type Pagination struct {
 	PageNumber int
 	PageSize   int
+	Cursor     string // Set for keyset pagination, in which case PageNumber is ignored.
 }
 
 func (p Pagination) Limit() int {
@@ -17,5 +19,36 @@ func (p Pagination) Limit() int {
 }
 
 func (p Pagination) Offset() int {
+	if p.Cursor != "" {
+		return 0
+	}
+
 	return p.Limit() * (p.PageNumber - 1)
 }
+
+// PageCost is the number of pages of the default size making up a page of size, rounded up. Sizes below 1 stand for
+// the default size.
+func PageCost(size int) int {
+	if size < 1 {
+		return 1
+	}
+
+	cost := size / DefaultPageSize
+	if size%DefaultPageSize != 0 {
+		cost++
+	}
+
+	return cost
+}
+
+const (
+	SortKey   = "sort"
+	OrderKey  = "order"
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type Sort struct {
+	Field string
+	Order string
+}
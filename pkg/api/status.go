@@ -19,5 +19,25 @@ const (
 	// HTTP Mapping: 400 Bad Request.
 	InvalidArgument
 
+	// NotFound indicates some requested entity was not found.
+	//
+	// HTTP Mapping: 404 Not Found.
+	NotFound
+
+	// ResourceExhausted indicates the client exhausted a quota, such as its rate limit.
+	//
+	// HTTP Mapping: 429 Too Many Requests.
+	ResourceExhausted
+
+	// Unauthenticated indicates the request does not have valid authentication credentials.
+	//
+	// HTTP Mapping: 401 Unauthorized.
+	Unauthenticated
+
+	// PermissionDenied indicates the caller is not allowed to perform the request.
+	//
+	// HTTP Mapping: 403 Forbidden.
+	PermissionDenied
+
 	// ...
 )
@@ -0,0 +1,119 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned when the circuit is open and the call has not been attempted.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a minimal circuit breaker.
+// It opens after threshold consecutive failures and lets a single trial call through once openTimeout has elapsed.
+type Breaker struct {
+	mu          sync.Mutex
+	state       State
+	failures    int
+	openedAt    time.Time
+	threshold   int
+	openTimeout time.Duration
+	timeNow     func() time.Time
+}
+
+func New(threshold int, openTimeout time.Duration, timeNow func() time.Time) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &Breaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		timeNow:     timeNow,
+	}
+}
+
+// Execute runs fn if the circuit allows it and records its outcome.
+func (b *Breaker) Execute(fn func() error) error {
+	if !b.allow() {
+		return ErrOpen
+	}
+
+	err := fn()
+	b.record(err)
+
+	return err
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if b.timeNow().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = HalfOpen
+		return true
+	case HalfOpen:
+		return false // A trial call is already in flight.
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = Closed
+		b.failures = 0
+		return
+	}
+
+	// A cancelled call says nothing about the upstream health.
+	if errors.Is(err, context.Canceled) {
+		if b.state == HalfOpen {
+			b.state = Open
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.threshold {
+		b.state = Open
+		b.openedAt = b.timeNow()
+	}
+}
@@ -0,0 +1,42 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		errCall = errors.New("upstream error")
+		fail    = func() error { return errCall }
+		succeed = func() error { return nil }
+	)
+
+	b := New(2, time.Minute, func() time.Time { return now })
+
+	assert.ErrorIs(t, b.Execute(fail), errCall)
+	assert.Equal(t, Closed, b.State())
+
+	assert.ErrorIs(t, b.Execute(fail), errCall)
+	assert.Equal(t, Open, b.State())
+
+	assert.ErrorIs(t, b.Execute(succeed), ErrOpen, "open circuit must not call fn")
+
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, b.Execute(fail), errCall, "trial call after the open timeout")
+	assert.Equal(t, Open, b.State())
+
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, b.Execute(func() error { return context.Canceled }), context.Canceled)
+	assert.Equal(t, Open, b.State(), "cancellation must not close nor count")
+
+	assert.NoError(t, b.Execute(succeed))
+	assert.Equal(t, Closed, b.State())
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrMiss is returned by backends for missing or expired keys.
+var ErrMiss = errors.New("cache miss")
+
+// Backend stores values with a time to live. It may be shared by several processes.
+type Backend interface {
+	// Get returns ErrMiss when the key is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX sets the key only when it is missing, and reports whether it did.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// Incr increments the integer stored at a key without expiration, a missing key being 0, and returns it.
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// Cache caches the results of expensive computations in a backend.
+//
+// Keys are namespaced by a generation, which Invalidate increments: the values of the previous generations are
+// never read again, and expire with their time to live. A value missing from the cache is computed once: by a
+// single goroutine per process, and by a single process when the backend is shared, the others waiting for it.
+type Cache struct {
+	backend   Backend
+	namespace string
+	ttl       time.Duration
+	lockTTL   time.Duration // Bounds the wait for a value computed by another process.
+	lockPoll  time.Duration
+	group     singleflight.Group
+}
+
+type Option func(*Cache)
+
+// WithLock sets how long a value computed by another process is waited for, and how often it is polled.
+func WithLock(ttl, poll time.Duration) Option {
+	return func(c *Cache) {
+		c.lockTTL = ttl
+		c.lockPoll = poll
+	}
+}
+
+// New returns a cache storing its values in the backend under the namespace, for ttl at most.
+func New(backend Backend, namespace string, ttl time.Duration, opts ...Option) *Cache {
+	c := &Cache{
+		backend:   backend,
+		namespace: namespace,
+		ttl:       ttl,
+		lockTTL:   30 * time.Second,
+		lockPoll:  50 * time.Millisecond,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Key builds a key from a name and the arguments of the computation, encoded as JSON.
+func Key(name string, args ...any) string {
+	b, err := json.Marshal(args)
+	if err != nil {
+		panic(fmt.Sprintf("cache: key arguments of %s: %v", name, err))
+	}
+
+	sum := sha256.Sum256(b)
+
+	return name + ":" + hex.EncodeToString(sum[:16])
+}
+
+// Invalidate expires every cached value.
+func (c *Cache) Invalidate(ctx context.Context) error {
+	_, err := c.backend.Incr(ctx, c.namespace+":generation")
+	return err
+}
+
+// Load returns the value cached at key, computing it with load when it is missing. Values are encoded as JSON.
+// A nil cache always computes the value. Backend errors are logged, and the value computed without the cache.
+func Load[T any](ctx context.Context, c *Cache, key string, load func(context.Context) (T, error)) (T, error) {
+	var zero T
+
+	if c == nil {
+		return load(ctx)
+	}
+
+	encode := func(ctx context.Context) ([]byte, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(v)
+	}
+
+	// The value is computed for every waiting caller, so it is not canceled with the first one.
+	ch := c.group.DoChan(
+		key, func() (any, error) {
+			return c.fetch(context.WithoutCancel(ctx), key, encode)
+		},
+	)
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+
+		var v T
+		if err := json.Unmarshal(res.Val.([]byte), &v); err != nil {
+			return zero, fmt.Errorf("cache: decode %s: %w", key, err)
+		}
+
+		return v, nil
+	}
+}
+
+// fetch returns the encoded value at key, from the backend or computed by load.
+func (c *Cache) fetch(ctx context.Context, key string, load func(context.Context) ([]byte, error)) ([]byte, error) {
+	generation, err := c.generation(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Cache bypassed, generation unavailable")
+		return load(ctx)
+	}
+
+	key = c.namespace + ":" + generation + ":" + key
+
+	b, err := c.backend.Get(ctx, key)
+	if err == nil {
+		return b, nil
+	}
+
+	if !errors.Is(err, ErrMiss) {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Cache bypassed, get failed")
+		return load(ctx)
+	}
+
+	lock := key + ":lock"
+
+	locked, err := c.backend.SetNX(ctx, lock, []byte("1"), c.lockTTL)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Cache bypassed, lock failed")
+		return load(ctx)
+	}
+
+	if !locked {
+		if b, ok := c.wait(ctx, key); ok {
+			return b, nil
+		}
+	} else {
+		defer func() {
+			if err := c.backend.Delete(ctx, lock); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Cache unlock failed")
+			}
+		}()
+	}
+
+	b, err = load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.backend.Set(ctx, key, b, c.ttl); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Cache set failed")
+	}
+
+	return b, nil
+}
+
+// wait polls the value computed by the process holding the lock of the key. It gives up as soon as the lock is
+// released without a value, when the computation or the write of the value failed, and when the lock expires.
+func (c *Cache) wait(ctx context.Context, key string) ([]byte, bool) {
+	ticker := time.NewTicker(c.lockPoll)
+	defer ticker.Stop()
+
+	deadline := time.After(c.lockTTL)
+
+	for {
+		select {
+		case <-deadline:
+			return nil, false
+		case <-ticker.C:
+			// The lock is read first: the value is written before the lock is released, so it is read below when
+			// the lock was already released.
+			_, lockErr := c.backend.Get(ctx, key+":lock")
+
+			if b, err := c.backend.Get(ctx, key); err == nil {
+				return b, true
+			}
+
+			if errors.Is(lockErr, ErrMiss) {
+				return nil, false
+			}
+		}
+	}
+}
+
+func (c *Cache) generation(ctx context.Context) (string, error) {
+	b, err := c.backend.Get(ctx, c.namespace+":generation")
+	if errors.Is(err, ErrMiss) {
+		return "0", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := strconv.ParseInt(string(b), 10, 64); err != nil {
+		return "", fmt.Errorf("invalid generation %q: %w", b, err)
+	}
+
+	return string(b), nil
+}
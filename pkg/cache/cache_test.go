@@ -0,0 +1,390 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend is a backend along with a function moving its clock forward.
+type testBackend struct {
+	backend Backend
+	advance func(time.Duration)
+}
+
+// testBackends returns a memory backend and a Redis backend served by an in-process Redis.
+func testBackends(t *testing.T) map[string]func(t *testing.T) testBackend {
+	t.Helper()
+
+	return map[string]func(t *testing.T) testBackend{
+		"memory": func(t *testing.T) testBackend {
+			var (
+				mu  sync.Mutex
+				now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			)
+
+			return testBackend{
+				backend: NewMemory(
+					func() time.Time {
+						mu.Lock()
+						defer mu.Unlock()
+
+						return now
+					},
+				),
+				advance: func(d time.Duration) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					now = now.Add(d)
+				},
+			}
+		},
+		"redis": func(t *testing.T) testBackend {
+			mr := miniredis.RunT(t)
+
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = client.Close() })
+
+			return testBackend{
+				backend: NewRedis(client),
+				advance: mr.FastForward,
+			}
+		},
+	}
+}
+
+func TestBackend(t *testing.T) {
+	t.Parallel()
+
+	for name, newBackend := range testBackends(t) {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					ctx = context.Background()
+					tb  = newBackend(t)
+					b   = tb.backend
+				)
+
+				_, err := b.Get(ctx, "k")
+				require.ErrorIs(t, err, ErrMiss)
+
+				require.NoError(t, b.Set(ctx, "k", []byte("v"), time.Minute))
+
+				got, err := b.Get(ctx, "k")
+				require.NoError(t, err)
+				assert.Equal(t, []byte("v"), got)
+
+				ok, err := b.SetNX(ctx, "k", []byte("other"), time.Minute)
+				require.NoError(t, err)
+				assert.False(t, ok)
+
+				tb.advance(time.Minute)
+
+				_, err = b.Get(ctx, "k")
+				require.ErrorIs(t, err, ErrMiss, "expired")
+
+				ok, err = b.SetNX(ctx, "k", []byte("other"), time.Minute)
+				require.NoError(t, err)
+				assert.True(t, ok)
+
+				require.NoError(t, b.Delete(ctx, "k"))
+
+				_, err = b.Get(ctx, "k")
+				require.ErrorIs(t, err, ErrMiss, "deleted")
+
+				for want := int64(1); want <= 2; want++ {
+					n, err := b.Incr(ctx, "n")
+					require.NoError(t, err)
+					assert.Equal(t, want, n)
+				}
+
+				tb.advance(24 * time.Hour)
+
+				got, err = b.Get(ctx, "n")
+				require.NoError(t, err)
+				assert.Equal(t, []byte("2"), got, "counters do not expire")
+			},
+		)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type value struct {
+		Count int64 `json:"count"`
+	}
+
+	errLoad := errors.New("load failed")
+
+	tests := []struct {
+		name      string
+		run       func(t *testing.T, c *Cache, tb testBackend, load func(context.Context) (value, error))
+		wantCalls int32
+	}{
+		{
+			name: "cached",
+			run: func(t *testing.T, c *Cache, _ testBackend, load func(context.Context) (value, error)) {
+				for range 3 {
+					got, err := Load(context.Background(), c, Key("count", 1), load)
+					require.NoError(t, err)
+					assert.Equal(t, value{Count: 1}, got)
+				}
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "keyed by arguments",
+			run: func(t *testing.T, c *Cache, _ testBackend, load func(context.Context) (value, error)) {
+				for _, arg := range []int{1, 2, 1} {
+					_, err := Load(context.Background(), c, Key("count", arg), load)
+					require.NoError(t, err)
+				}
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "invalidated",
+			run: func(t *testing.T, c *Cache, _ testBackend, load func(context.Context) (value, error)) {
+				ctx := context.Background()
+
+				_, err := Load(ctx, c, Key("count"), load)
+				require.NoError(t, err)
+
+				require.NoError(t, c.Invalidate(ctx))
+
+				got, err := Load(ctx, c, Key("count"), load)
+				require.NoError(t, err)
+				assert.Equal(t, value{Count: 2}, got)
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "expired",
+			run: func(t *testing.T, c *Cache, tb testBackend, load func(context.Context) (value, error)) {
+				ctx := context.Background()
+
+				_, err := Load(ctx, c, Key("count"), load)
+				require.NoError(t, err)
+
+				tb.advance(time.Hour)
+
+				_, err = Load(ctx, c, Key("count"), load)
+				require.NoError(t, err)
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "errors not cached",
+			run: func(t *testing.T, c *Cache, _ testBackend, _ func(context.Context) (value, error)) {
+				var calls int
+
+				for range 2 {
+					_, err := Load(
+						context.Background(), c, Key("count"), func(context.Context) (value, error) {
+							calls++
+							return value{}, errLoad
+						},
+					)
+					require.ErrorIs(t, err, errLoad)
+				}
+
+				assert.Equal(t, 2, calls)
+			},
+		},
+		{
+			name: "stampede in a process",
+			run: func(t *testing.T, c *Cache, _ testBackend, load func(context.Context) (value, error)) {
+				var (
+					release = make(chan struct{})
+					wg      sync.WaitGroup
+				)
+
+				slow := func(ctx context.Context) (value, error) {
+					<-release
+					return load(ctx)
+				}
+
+				for range 10 {
+					wg.Add(1)
+
+					go func() {
+						defer wg.Done()
+
+						got, err := Load(context.Background(), c, Key("count"), slow)
+						assert.NoError(t, err)
+						assert.Equal(t, value{Count: 1}, got)
+					}()
+				}
+
+				time.Sleep(20 * time.Millisecond)
+				close(release)
+				wg.Wait()
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "stampede across processes",
+			run: func(t *testing.T, c *Cache, tb testBackend, load func(context.Context) (value, error)) {
+				var (
+					other   = New(tb.backend, "test", time.Hour, WithLock(time.Minute, time.Millisecond))
+					started = make(chan struct{})
+					release = make(chan struct{})
+					done    = make(chan struct{})
+				)
+
+				go func() {
+					defer close(done)
+
+					_, err := Load(
+						context.Background(), c, Key("count"), func(ctx context.Context) (value, error) {
+							close(started)
+							<-release
+
+							return load(ctx)
+						},
+					)
+					assert.NoError(t, err)
+				}()
+
+				<-started
+				time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+				got, err := Load(context.Background(), other, Key("count"), load)
+				require.NoError(t, err)
+				assert.Equal(t, value{Count: 1}, got, "waited for the other process")
+
+				<-done
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "failure across processes",
+			run: func(t *testing.T, c *Cache, tb testBackend, load func(context.Context) (value, error)) {
+				var (
+					other   = New(tb.backend, "test", time.Hour, WithLock(time.Minute, time.Millisecond))
+					started = make(chan struct{})
+					release = make(chan struct{})
+					done    = make(chan struct{})
+				)
+
+				go func() {
+					defer close(done)
+
+					_, err := Load(
+						context.Background(), c, Key("count"), func(context.Context) (value, error) {
+							close(started)
+							<-release
+
+							return value{}, errLoad
+						},
+					)
+					assert.ErrorIs(t, err, errLoad)
+				}()
+
+				<-started
+				time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+				start := time.Now()
+
+				got, err := Load(context.Background(), other, Key("count"), load)
+				require.NoError(t, err)
+				assert.Equal(t, value{Count: 1}, got)
+				assert.Less(t, time.Since(start), time.Second, "waited for the lock to expire")
+
+				<-done
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "canceled caller",
+			run: func(t *testing.T, c *Cache, _ testBackend, load func(context.Context) (value, error)) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err := Load(ctx, c, Key("count"), load)
+				require.ErrorIs(t, err, context.Canceled)
+
+				// The computation is not canceled with its caller, and is cached for the next ones.
+				require.Eventually(
+					t, func() bool {
+						got, err := Load(context.Background(), c, Key("count"), load)
+						return err == nil && got == value{Count: 1}
+					}, time.Second, 10*time.Millisecond,
+				)
+			},
+			wantCalls: 1,
+		},
+	}
+
+	for name, newBackend := range testBackends(t) {
+		for _, tt := range tests {
+			t.Run(
+				name+"/"+tt.name, func(t *testing.T) {
+					t.Parallel()
+
+					var (
+						tb    = newBackend(t)
+						c     = New(tb.backend, "test", time.Hour, WithLock(time.Minute, time.Millisecond))
+						calls atomic.Int32
+					)
+
+					tt.run(
+						t, c, tb, func(context.Context) (value, error) {
+							return value{Count: int64(calls.Add(1))}, nil
+						},
+					)
+
+					assert.Equal(t, tt.wantCalls, calls.Load())
+				},
+			)
+		}
+	}
+}
+
+func TestLoad_NilCache(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	for range 2 {
+		got, err := Load(
+			context.Background(), nil, Key("count"), func(context.Context) (int, error) {
+				calls++
+				return 42, nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 42, got)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestLoad_BackendDown(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	mr.Close()
+
+	c := New(NewRedis(client), "test", time.Hour)
+
+	got, err := Load(context.Background(), c, Key("count"), func(context.Context) (int, error) { return 42, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 42, got)
+}
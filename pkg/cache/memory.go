@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of writes between two removals of the expired entries of a Memory backend.
+const sweepEvery = 1024
+
+// Memory is a Backend local to the process.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	writes  int
+	timeNow func() time.Time
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // Zero for entries without expiration.
+}
+
+func NewMemory(timeNow func() time.Time) *Memory {
+	return &Memory{
+		entries: make(map[string]memoryEntry),
+		timeNow: timeNow,
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entry(key)
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	return e.value, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, value, ttl)
+
+	return nil
+}
+
+func (m *Memory) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entry(key); ok {
+		return false, nil
+	}
+
+	m.set(key, value, ttl)
+
+	return true, nil
+}
+
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+
+	return nil
+}
+
+func (m *Memory) Incr(_ context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	if e, ok := m.entry(key); ok {
+		var err error
+		if n, err = strconv.ParseInt(string(e.value), 10, 64); err != nil {
+			return 0, err
+		}
+	}
+
+	n++
+	m.set(key, []byte(strconv.FormatInt(n, 10)), 0)
+
+	return n, nil
+}
+
+// entry returns the entry at key unless it expired. The lock must be held.
+func (m *Memory) entry(key string) (memoryEntry, bool) {
+	e, ok := m.entries[key]
+	if !ok || m.expired(e) {
+		return memoryEntry{}, false
+	}
+
+	return e, true
+}
+
+// set writes an entry, expiring after ttl unless it is zero. The lock must be held.
+func (m *Memory) set(key string, value []byte, ttl time.Duration) {
+	e := memoryEntry{value: value}
+	if ttl > 0 {
+		e.expiresAt = m.timeNow().Add(ttl)
+	}
+
+	m.entries[key] = e
+
+	if m.writes++; m.writes%sweepEvery == 0 {
+		for k, e := range m.entries {
+			if m.expired(e) {
+				delete(m.entries, k)
+			}
+		}
+	}
+}
+
+func (m *Memory) expired(e memoryEntry) bool {
+	return !e.expiresAt.IsZero() && !m.timeNow().Before(e.expiresAt)
+}
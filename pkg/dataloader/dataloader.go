@@ -0,0 +1,120 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches the values of a batch of keys. Keys without value are left out of the returned map.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader coalesces the loads of single keys into batches, so that resolving a list of items
+// does not query the database once per item. Loaded values are cached for the lifetime of the loader,
+// which should be scoped to a request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results map[K]*result[V]
+}
+
+// New returns a loader fetching a batch once wait has elapsed since its first key, or once it holds maxBatch keys.
+// A maxBatch of 0 means no limit.
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of a key, found being false when the batch function returned no value for it.
+// The batch is fetched with the context of its first load.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (value V, found bool, err error) {
+	l.mu.Lock()
+
+	r, ok := l.cache[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.cache[key] = r
+		l.enqueue(ctx, key, r)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.found, r.err
+	case <-ctx.Done():
+		return value, false, ctx.Err()
+	}
+}
+
+// enqueue adds a key to the pending batch, starting a new one if needed. It must be called with the lock held.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, r *result[V]) {
+	if l.pending == nil {
+		b := &batch[K, V]{results: make(map[K]*result[V])}
+		l.pending = b
+
+		time.AfterFunc(
+			l.wait, func() {
+				l.mu.Lock()
+				dispatch := l.pending == b // Otherwise already dispatched for being full.
+				if dispatch {
+					l.pending = nil
+				}
+				l.mu.Unlock()
+
+				if dispatch {
+					l.run(ctx, b)
+				}
+			},
+		)
+	}
+
+	l.pending.keys = append(l.pending.keys, key)
+	l.pending.results[key] = r
+
+	if l.maxBatch > 0 && len(l.pending.keys) >= l.maxBatch {
+		b := l.pending
+		l.pending = nil
+
+		go l.run(ctx, b)
+	}
+}
+
+func (l *Loader[K, V]) run(ctx context.Context, b *batch[K, V]) {
+	values, err := l.fetch(ctx, b.keys)
+
+	for key, r := range b.results {
+		if err != nil {
+			r.err = err
+
+			// Failures are not cached, so that a later load retries.
+			l.mu.Lock()
+			delete(l.cache, key)
+			l.mu.Unlock()
+		} else {
+			r.value, r.found = values[key]
+		}
+
+		close(r.done)
+	}
+}
@@ -0,0 +1,142 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a batch function doubling its keys and recording its batches. Negative keys have no value.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) fetch(_ context.Context, keys []int) (map[int]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, keys)
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	values := make(map[int]int, len(keys))
+	for _, k := range keys {
+		if k >= 0 {
+			values[k] = 2 * k
+		}
+	}
+
+	return values, nil
+}
+
+// loadAll loads the keys concurrently, like the resolvers of a list.
+func loadAll(l *Loader[int, int], keys ...int) (values []int, founds []bool, errs []error) {
+	values, founds, errs = make([]int, len(keys)), make([]bool, len(keys)), make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], founds[i], errs[i] = l.Load(context.Background(), k)
+		}()
+	}
+	wg.Wait()
+
+	return values, founds, errs
+}
+
+func TestLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxBatch    int
+		keys        []int
+		wantValues  []int
+		wantFounds  []bool
+		wantBatches int
+	}{
+		{
+			name:        "single batch",
+			keys:        []int{1, 2, 3},
+			wantValues:  []int{2, 4, 6},
+			wantFounds:  []bool{true, true, true},
+			wantBatches: 1,
+		},
+		{
+			name:        "duplicate keys",
+			keys:        []int{1, 1, 2},
+			wantValues:  []int{2, 2, 4},
+			wantFounds:  []bool{true, true, true},
+			wantBatches: 1,
+		},
+		{
+			name:        "missing key",
+			keys:        []int{1, -1},
+			wantValues:  []int{2, 0},
+			wantFounds:  []bool{true, false},
+			wantBatches: 1,
+		},
+		{
+			name:        "max batch",
+			maxBatch:    2,
+			keys:        []int{1, 2, 3, 4},
+			wantValues:  []int{2, 4, 6, 8},
+			wantFounds:  []bool{true, true, true, true},
+			wantBatches: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				r := &recorder{}
+				l := New(r.fetch, 10*time.Millisecond, tt.maxBatch)
+
+				values, founds, errs := loadAll(l, tt.keys...)
+				for _, err := range errs {
+					require.NoError(t, err)
+				}
+
+				assert.Equal(t, tt.wantValues, values)
+				assert.Equal(t, tt.wantFounds, founds)
+				assert.Len(t, r.batches, tt.wantBatches)
+
+				// Loaded keys are cached.
+				_, _, _ = loadAll(l, tt.keys...)
+				assert.Len(t, r.batches, tt.wantBatches)
+			},
+		)
+	}
+}
+
+func TestLoader_LoadError(t *testing.T) {
+	t.Parallel()
+
+	r := &recorder{err: errors.New("boom")}
+	l := New(r.fetch, time.Millisecond, 0)
+
+	_, _, err := l.Load(context.Background(), 1)
+	assert.ErrorIs(t, err, r.err)
+
+	// Failures are not cached.
+	r.err = nil
+
+	value, found, err := l.Load(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, value)
+	assert.Len(t, r.batches, 2)
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"kiln-exercice/pkg/api"
+)
+
+// APIKeyMetadata is the metadata key carrying the API key of a client, like the X-API-Key header over HTTP.
+const APIKeyMetadata = "x-api-key"
+
+// publicServices are the services open to every call, whatever its API key.
+var publicServices = []string{
+	reflectionv1.ServerReflection_ServiceDesc.ServiceName,
+	reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName,
+}
+
+// Authenticator authenticates the calls with their API key, for the scope of their method. Its interceptors must
+// be chained after the ones converting errors.
+type Authenticator struct {
+	Auth api.Authenticator
+	// Scopes are the scopes of the full method names. Other methods are denied, but the reflection ones, which are
+	// not authenticated.
+	Scopes map[string]string
+	// Anonymous are the scopes granted to calls without an API key.
+	Anonymous []string
+}
+
+// UnaryServerInterceptor authenticates unary calls, their context holding the client.
+func (a Authenticator) UnaryServerInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor authenticates streaming calls, their context holding the client.
+func (a Authenticator) StreamServerInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns a copy of ctx holding the client of a call, or the call itself for anonymous calls.
+func (a Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	scope, ok := a.Scopes[method]
+	if !ok {
+		if service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/"); slices.Contains(publicServices, service) {
+			return ctx, nil
+		}
+
+		return nil, api.NewError(api.PermissionDenied, "method "+method+" is not granted to any scope", nil)
+	}
+
+	key := apiKeyFromMetadata(ctx)
+	if key == "" {
+		if slices.Contains(a.Anonymous, scope) {
+			return ctx, nil
+		}
+
+		return nil, api.NewError(api.Unauthenticated, "API key required, in the "+APIKeyMetadata+" metadata", nil)
+	}
+
+	client, err := a.Auth(ctx, key, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ContextWithClient(ctx, client), nil
+}
+
+// apiKeyFromMetadata returns the API key of a call, from its x-api-key metadata or a bearer authorization.
+func apiKeyFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(APIKeyMetadata); len(keys) > 0 && keys[0] != "" {
+		return keys[0]
+	}
+
+	for _, authorization := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
+
+// authenticatedStream is a server stream whose context holds the client.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"kiln-exercice/pkg/api"
+)
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	a := Authenticator{
+		Auth: func(_ context.Context, key, scope string) (api.Client, error) {
+			switch {
+			case key != "s3cr3t":
+				return api.Client{}, api.NewError(api.Unauthenticated, "invalid API key", nil)
+			case scope != "read":
+				return api.Client{}, api.NewError(api.PermissionDenied, "API key is not granted the "+scope+" scope", nil)
+			default:
+				return api.Client{ID: 7, Name: "crm"}, nil
+			}
+		},
+		Scopes:    map[string]string{"/svc/Get": "read", "/svc/Export": "export"},
+		Anonymous: []string{"read"},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		md         metadata.MD
+		wantClient int // 0 for anonymous calls.
+		wantCode   api.Code
+	}{
+		{
+			name:       "api key metadata",
+			method:     "/svc/Get",
+			md:         metadata.Pairs(APIKeyMetadata, "s3cr3t"),
+			wantClient: 7,
+		},
+		{
+			name:       "bearer token",
+			method:     "/svc/Get",
+			md:         metadata.Pairs("authorization", "Bearer s3cr3t"),
+			wantClient: 7,
+		},
+		{
+			name:   "anonymous",
+			method: "/svc/Get",
+		},
+		{
+			name:   "reflection",
+			method: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+		},
+		{
+			name:     "method without scope",
+			method:   "/svc/Delete",
+			md:       metadata.Pairs(APIKeyMetadata, "s3cr3t"),
+			wantCode: api.PermissionDenied,
+		},
+		{
+			name:     "service named like reflection",
+			method:   "/grpc.reflection.v1.ServerReflectionX/ServerReflectionInfo",
+			wantCode: api.PermissionDenied,
+		},
+		{
+			name:     "missing key",
+			method:   "/svc/Export",
+			wantCode: api.Unauthenticated,
+		},
+		{
+			name:     "invalid key",
+			method:   "/svc/Get",
+			md:       metadata.Pairs(APIKeyMetadata, "guess"),
+			wantCode: api.Unauthenticated,
+		},
+		{
+			name:     "missing scope",
+			method:   "/svc/Export",
+			md:       metadata.Pairs(APIKeyMetadata, "s3cr3t"),
+			wantCode: api.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+				handler := func(ctx context.Context, _ any) (any, error) {
+					client, _ := api.ClientFromContext(ctx)
+					return client.ID, nil
+				}
+
+				got, err := a.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					require.True(t, errors.As(err, &apiErr), "error = %v", err)
+					assert.Equal(t, tt.wantCode, apiErr.Code)
+
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantClient, got)
+			},
+		)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kiln-exercice/pkg/api"
+)
+
+// Status converts an error returned by a use case into a gRPC status error.
+// API errors keep their message, other errors become Internal without exposing their details.
+func Status(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		log.Ctx(ctx).Info().Err(err).Msg("Internal error")
+		return status.Error(codes.Internal, "Internal error")
+	}
+
+	code := grpcCodeFromCode(apiErr.Code)
+	if code == codes.Internal || code == codes.Unknown {
+		log.Ctx(ctx).Info().Err(apiErr.Err).Msg(apiErr.Message)
+	}
+
+	return status.Error(code, apiErr.Message)
+}
+
+// grpcCodeFromCode returns the gRPC code for the given API code.
+func grpcCodeFromCode(code api.Code) codes.Code {
+	switch code {
+	case api.OK:
+		return codes.OK
+	case api.Unknown:
+		return codes.Unknown
+	case api.InvalidArgument:
+		return codes.InvalidArgument
+	case api.NotFound:
+		return codes.NotFound
+	case api.ResourceExhausted:
+		return codes.ResourceExhausted
+	case api.Unauthenticated:
+		return codes.Unauthenticated
+	case api.PermissionDenied:
+		return codes.PermissionDenied
+	default:
+		return codes.Unknown
+	}
+}
+
+// UnaryServerInterceptor converts the errors of unary handlers with Status.
+func UnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, Status(ctx, err)
+	}
+
+	return res, nil
+}
+
+// StreamServerInterceptor converts the errors of streaming handlers with Status.
+func StreamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return Status(ss.Context(), handler(srv, ss))
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kiln-exercice/pkg/api"
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:        "invalid argument",
+			err:         api.NewError(api.InvalidArgument, "invalid year", errors.New("year")),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid year",
+		},
+		{
+			name:        "not found",
+			err:         api.NewError(api.NotFound, "no delegation found", nil),
+			wantCode:    codes.NotFound,
+			wantMessage: "no delegation found",
+		},
+		{
+			name:        "resource exhausted",
+			err:         api.NewError(api.ResourceExhausted, "rate limit exceeded", nil),
+			wantCode:    codes.ResourceExhausted,
+			wantMessage: "rate limit exceeded",
+		},
+		{
+			name:        "unauthenticated",
+			err:         api.NewError(api.Unauthenticated, "invalid API key", nil),
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "invalid API key",
+		},
+		{
+			name:        "permission denied",
+			err:         api.NewError(api.PermissionDenied, "missing scope export", nil),
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "missing scope export",
+		},
+		{
+			name:        "unknown",
+			err:         api.NewError(api.Unknown, "error listing delegations", errors.New("connection refused")),
+			wantCode:    codes.Unknown,
+			wantMessage: "error listing delegations",
+		},
+		{
+			name:        "wrapped api error",
+			err:         fmt.Errorf("export: %w", api.NewError(api.InvalidArgument, "invalid sort", nil)),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid sort",
+		},
+		{
+			name:        "status error",
+			err:         status.Error(codes.Unavailable, "draining"),
+			wantCode:    codes.Unavailable,
+			wantMessage: "draining",
+		},
+		{
+			name:        "canceled",
+			err:         fmt.Errorf("send: %w", context.Canceled),
+			wantCode:    codes.Canceled,
+			wantMessage: "context canceled",
+		},
+		{
+			name:        "other error",
+			err:         errors.New("connection refused"),
+			wantCode:    codes.Internal,
+			wantMessage: "Internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				s := status.Convert(Status(context.Background(), tt.err))
+				assert.Equal(t, tt.wantCode, s.Code())
+				assert.Equal(t, tt.wantMessage, s.Message())
+			},
+		)
+	}
+
+	assert.NoError(t, Status(context.Background(), nil))
+}
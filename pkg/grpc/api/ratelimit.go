@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"kiln-exercice/pkg/api"
+)
+
+// Limiter takes tokens from the bucket of a client when it holds them, and reports whether it did, as the rate
+// limiters of the HTTP API do.
+type Limiter interface {
+	Take(key string, cost int) bool
+}
+
+// RateLimiter limits the calls of each client, identified by its API key or by its peer address when anonymous.
+// Its interceptors must be chained after the Authenticator ones.
+type RateLimiter struct {
+	// Limiters are the limiters of the full method names, each with buckets of its own. Other methods are not limited.
+	Limiters map[string]Limiter
+}
+
+// UnaryServerInterceptor rate limits unary calls, taking a token per page of the default size requested.
+func (l RateLimiter) UnaryServerInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if err := l.take(ctx, info.FullMethod, PageCost(req)); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rate limits streaming calls, taking a token per call.
+func (l RateLimiter) StreamServerInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	if err := l.take(ss.Context(), info.FullMethod, 1); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// take takes cost tokens from the bucket of the client of a call, or returns an Error coded ResourceExhausted.
+func (l RateLimiter) take(ctx context.Context, method string, cost int) error {
+	limiter, ok := l.Limiters[method]
+	if !ok {
+		return nil
+	}
+
+	if !limiter.Take(ClientKey(ctx), cost) {
+		return api.NewError(api.ResourceExhausted, "rate limit exceeded, retry later", nil)
+	}
+
+	return nil
+}
+
+// ClientKey identifies the client of a call by its API key, as authenticated by Authenticator, or by its peer
+// address for anonymous calls.
+func ClientKey(ctx context.Context) string {
+	if client, ok := api.ClientFromContext(ctx); ok {
+		return "key:" + strconv.Itoa(client.ID)
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "ip:"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return "ip:" + p.Addr.String()
+	}
+
+	return "ip:" + host
+}
+
+// PageCost takes a token per page of the default size asked for by a request, like its HTTP counterpart.
+func PageCost(req any) int {
+	paginated, ok := req.(interface{ GetPageSize() int32 })
+	if !ok {
+		return 1
+	}
+
+	return api.PageCost(int(paginated.GetPageSize()))
+}
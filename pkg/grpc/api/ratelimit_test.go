@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"kiln-exercice/pkg/api"
+)
+
+// pageRequest is a request of a page of size items.
+type pageRequest struct {
+	size int32
+}
+
+func (r pageRequest) GetPageSize() int32 {
+	return r.size
+}
+
+// bucketLimiter is a limiter holding a number of tokens per client, never refilled.
+type bucketLimiter map[string]int
+
+func (l bucketLimiter) Take(key string, cost int) bool {
+	if l[key] < cost {
+		return false
+	}
+
+	l[key] -= cost
+
+	return true
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		anonymous = peer.NewContext(
+			context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}},
+		)
+		authenticated = api.ContextWithClient(anonymous, api.Client{ID: 7})
+	)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		req      any
+		tokens   map[string]int
+		wantCode api.Code
+	}{
+		{
+			name:   "anonymous",
+			ctx:    anonymous,
+			method: "/svc/List",
+			req:    pageRequest{},
+			tokens: map[string]int{"ip:192.0.2.1": 1},
+		},
+		{
+			name:   "api key",
+			ctx:    authenticated,
+			method: "/svc/List",
+			req:    pageRequest{},
+			tokens: map[string]int{"key:7": 1},
+		},
+		{
+			name:   "cost by page size",
+			ctx:    anonymous,
+			method: "/svc/List",
+			req:    pageRequest{size: 250},
+			tokens: map[string]int{"ip:192.0.2.1": 3},
+		},
+		{
+			name:     "exhausted",
+			ctx:      anonymous,
+			method:   "/svc/List",
+			req:      pageRequest{size: 250},
+			tokens:   map[string]int{"ip:192.0.2.1": 2},
+			wantCode: api.ResourceExhausted,
+		},
+		{
+			name:   "not limited method",
+			ctx:    anonymous,
+			method: "/svc/Get",
+			req:    struct{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				limiter := bucketLimiter(tt.tokens)
+				l := RateLimiter{Limiters: map[string]Limiter{"/svc/List": limiter}}
+
+				handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+				got, err := l.UnaryServerInterceptor(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+				if tt.wantCode != api.OK {
+					var apiErr *api.Error
+					require.True(t, errors.As(err, &apiErr), "error = %v", err)
+					assert.Equal(t, tt.wantCode, apiErr.Code)
+
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, "ok", got)
+
+				for key, tokens := range limiter {
+					assert.Zero(t, tokens, "tokens left to %s", key)
+				}
+			},
+		)
+	}
+}
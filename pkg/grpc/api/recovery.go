@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryUnaryServerInterceptor turns the panics of unary handlers into Internal errors, and logs them with their
+// stack, so that a single RPC cannot bring the server down.
+func RecoveryUnaryServerInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (res any, err error) {
+	defer recoverPanic(info.FullMethod, &err)
+
+	return handler(ctx, req)
+}
+
+// RecoveryStreamServerInterceptor turns the panics of streaming handlers into Internal errors, as
+// RecoveryUnaryServerInterceptor does. A stream that panics mid-way ends with the error, so the client does not take
+// it as complete.
+func RecoveryStreamServerInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) (err error) {
+	defer recoverPanic(info.FullMethod, &err)
+
+	return handler(srv, ss)
+}
+
+// recoverPanic, deferred by an interceptor, sets err to an Internal error when the handler panics, and logs the panic
+// with its stack.
+func recoverPanic(method string, err *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+
+	log.Error().Str("method", method).Interface("panic", p).Bytes("stack", debug.Stack()).Msg("Handler panicked")
+
+	*err = status.Error(codes.Internal, "Internal error")
+}
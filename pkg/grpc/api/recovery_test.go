@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryInterceptors(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := status.Error(codes.NotFound, "no delegation found")
+
+	tests := []struct {
+		name     string
+		handle   func() error
+		wantCode codes.Code
+		wantErr  error
+	}{
+		{
+			name:   "success",
+			handle: func() error { return nil },
+		},
+		{
+			name:    "error",
+			handle:  func() error { return errNotFound },
+			wantErr: errNotFound,
+		},
+		{
+			name:     "panic",
+			handle:   func() error { panic("boom") },
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "panic with an error",
+			handle:   func() error { panic(errors.New("boom")) },
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, unaryErr := RecoveryUnaryServerInterceptor(
+				context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/xtz.v1.DelegationService/GetDelegations"},
+				func(context.Context, any) (any, error) { return nil, tt.handle() },
+			)
+
+			streamErr := RecoveryStreamServerInterceptor(
+				nil, nil, &grpc.StreamServerInfo{FullMethod: "/xtz.v1.DelegationService/ExportDelegations"},
+				func(any, grpc.ServerStream) error { return tt.handle() },
+			)
+
+			for _, err := range []error{unaryErr, streamErr} {
+				if tt.wantCode == codes.OK {
+					assert.Equal(t, tt.wantErr, err)
+					continue
+				}
+
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Equal(t, "Internal error", status.Convert(err).Message(), "the panic is not exposed")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"kiln-exercice/pkg/api"
+)
+
+// APIKeyHeader is the header carrying the API key of a client.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyFromRequest returns the API key of a request, from its X-API-Key header or a bearer Authorization header.
+func APIKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// Authenticated serves the requests whose API key is authenticated for the scope, their context holding the client.
+// Requests without a key are served anonymously when anonymous is true, and rejected otherwise.
+func Authenticated(auth api.Authenticator, scope string, anonymous bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			key := APIKeyFromRequest(r)
+			if key == "" && anonymous {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if key == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, r, api.NewError(api.Unauthenticated, "API key required, in the "+APIKeyHeader+" header", nil))
+
+				return
+			}
+
+			client, err := auth(r.Context(), key, scope)
+			if err != nil {
+				if apiErr, ok := err.(*api.Error); ok && apiErr.Code == api.Unauthenticated {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+				}
+
+				writeError(w, r, err)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(api.ContextWithClient(r.Context(), client)))
+		},
+	)
+}
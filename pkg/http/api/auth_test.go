@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kiln-exercice/pkg/api"
+)
+
+func TestAuthenticated(t *testing.T) {
+	t.Parallel()
+
+	auth := func(_ context.Context, key, scope string) (api.Client, error) {
+		switch {
+		case key != "s3cr3t":
+			return api.Client{}, api.NewError(api.Unauthenticated, "invalid API key", nil)
+		case scope != "read":
+			return api.Client{}, api.NewError(api.PermissionDenied, "API key is not granted the "+scope+" scope", nil)
+		default:
+			return api.Client{ID: 7, Name: "crm"}, nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		header        map[string]string
+		scope         string
+		anonymous     bool
+		wantCode      int
+		wantBody      string
+		wantChallenge bool
+	}{
+		{
+			name:     "api key header",
+			header:   map[string]string{APIKeyHeader: "s3cr3t"},
+			scope:    "read",
+			wantCode: http.StatusOK,
+			wantBody: "7",
+		},
+		{
+			name:     "bearer token",
+			header:   map[string]string{"Authorization": "Bearer s3cr3t"},
+			scope:    "read",
+			wantCode: http.StatusOK,
+			wantBody: "7",
+		},
+		{
+			name:      "anonymous",
+			scope:     "read",
+			anonymous: true,
+			wantCode:  http.StatusOK,
+			wantBody:  "anonymous",
+		},
+		{
+			name:          "missing key",
+			scope:         "export",
+			wantCode:      http.StatusUnauthorized,
+			wantBody:      `{"data":{"message":"API key required, in the X-API-Key header"}}`,
+			wantChallenge: true,
+		},
+		{
+			name:          "invalid key",
+			header:        map[string]string{APIKeyHeader: "guess"},
+			scope:         "read",
+			anonymous:     true,
+			wantCode:      http.StatusUnauthorized,
+			wantBody:      `{"data":{"message":"invalid API key"}}`,
+			wantChallenge: true,
+		},
+		{
+			name:     "missing scope",
+			header:   map[string]string{APIKeyHeader: "s3cr3t"},
+			scope:    "export",
+			wantCode: http.StatusForbidden,
+			wantBody: `{"data":{"message":"API key is not granted the export scope"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				next := http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						client, ok := api.ClientFromContext(r.Context())
+						if !ok {
+							_, _ = w.Write([]byte("anonymous"))
+							return
+						}
+
+						_, _ = w.Write([]byte(strconv.Itoa(client.ID)))
+					},
+				)
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				for name, value := range tt.header {
+					r.Header.Set(name, value)
+				}
+
+				rec := httptest.NewRecorder()
+				Authenticated(auth, tt.scope, tt.anonymous, next).ServeHTTP(rec, r)
+
+				assert.Equal(t, tt.wantCode, rec.Code)
+				assert.Equal(t, tt.wantBody, strings.TrimSpace(rec.Body.String()))
+				assert.Equal(t, tt.wantChallenge, rec.Header().Get("WWW-Authenticate") != "")
+			},
+		)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxBodySize bounds the size of the request bodies decoded by JSONFromBody.
+const MaxBodySize = 1 << 20
+
+// JSONFromBody decodes the JSON body of a request into dst, rejecting unknown fields and trailing data.
+func JSONFromBody(w http.ResponseWriter, r *http.Request, dst any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return BadRequestError(fmt.Sprintf("invalid body: %s", err.Error()), err)
+	}
+
+	if dec.More() {
+		return BadRequestError("invalid body: unexpected data after the JSON value", errors.New("trailing data"))
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CacheControl lets clients store the cached responses, as long as they revalidate them with their ETag.
+const CacheControl = "public, no-cache"
+
+// maxCachedBodySize bounds the body of a cached response, larger responses being served uncached.
+const maxCachedBodySize = 1 << 20
+
+// Watermark identifies the version of the data served: cached responses expire as soon as it changes.
+type Watermark func(ctx context.Context) (string, error)
+
+// ResponseCache is an in-memory LRU cache of responses, safe for concurrent use.
+type ResponseCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List // Of *cachedResponse, most recently used first.
+	index    map[string]*list.Element
+}
+
+type cachedResponse struct {
+	key    string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewResponseCache returns a cache of up to capacity responses. A zero capacity caches nothing.
+func NewResponseCache(capacity int) *ResponseCache {
+	return &ResponseCache{
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.entries.Len()
+}
+
+func (c *ResponseCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.index[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.entries.MoveToFront(e)
+
+	return e.Value.(*cachedResponse), true
+}
+
+func (c *ResponseCache) add(res *cachedResponse) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.index[res.key]; ok {
+		e.Value = res
+		c.entries.MoveToFront(e)
+
+		return
+	}
+
+	c.index[res.key] = c.entries.PushFront(res)
+
+	for c.entries.Len() > c.capacity {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.index, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// Cached serves GET requests from the cache, keyed by the watermark, the request URI and the Accept header, so that
+// entries expire when the data changes. Successful responses get an ETag, and a 304 when it matches If-None-Match.
+// Responses are buffered, so next must not stream. Requests are served uncached when the watermark is unavailable.
+func Cached(c *ResponseCache, watermark Watermark, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mark, err := watermark(r.Context())
+			if err != nil {
+				log.Ctx(r.Context()).Warn().Err(err).Msg("Response cache bypassed, watermark unavailable")
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			key := strings.Join([]string{mark, r.URL.RequestURI(), r.Header.Get("Accept")}, "\n")
+
+			if res, ok := c.get(key); ok {
+				writeCachedResponse(w, r, res, "HIT")
+				return
+			}
+
+			rec := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			res := &cachedResponse{key: key, status: rec.status, header: rec.header, body: rec.body.Bytes()}
+
+			if res.status == http.StatusOK {
+				res.header.Set("ETag", fmt.Sprintf(`"%x"`, sha256.Sum256(res.body)))
+				res.header.Set("Cache-Control", CacheControl)
+
+				if len(res.body) <= maxCachedBodySize {
+					c.add(res)
+				}
+			}
+
+			writeCachedResponse(w, r, res, "MISS")
+		},
+	)
+}
+
+// writeCachedResponse writes a response, or a 304 when it is successful and its ETag matches If-None-Match.
+// X-Cache tells whether the response was served from the cache.
+func writeCachedResponse(w http.ResponseWriter, r *http.Request, res *cachedResponse, xCache string) {
+	w.Header().Set("X-Cache", xCache)
+
+	if etag := res.header.Get("ETag"); etag != "" && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", res.header.Get("Cache-Control"))
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	for name, values := range res.header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+
+	w.WriteHeader(res.status)
+	_, _ = w.Write(res.body)
+}
+
+// etagMatches reports whether an If-None-Match header matches an ETag, with the weak comparison of RFC 9110.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseRecorder buffers a response, to be cached before being written.
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+
+	rec.status = status
+	rec.wroteHeader = true
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(b)
+}
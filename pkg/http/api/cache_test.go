@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCached(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"f478fe4ee3b9b1733e4593f059b836a20d379818a4a037de5ce4bee39b2b94b5"`
+
+	type request struct {
+		target      string
+		accept      string
+		ifNoneMatch string
+		watermark   string
+		wantCode    int
+		wantXCache  string
+		wantBody    string
+	}
+
+	tests := []struct {
+		name      string
+		capacity  int
+		requests  []request
+		wantCalls int32
+	}{
+		{
+			name:     "hit until the watermark changes",
+			capacity: 10,
+			requests: []request{
+				{target: "/items?page=1", watermark: "1", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/items?page=1"},
+				{target: "/items?page=1", watermark: "1", wantCode: http.StatusOK, wantXCache: "HIT", wantBody: "/items?page=1"},
+				{target: "/items?page=1", watermark: "2", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/items?page=1"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "keyed by query and accept",
+			capacity: 10,
+			requests: []request{
+				{target: "/items?page=1", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/items?page=1"},
+				{target: "/items?page=2", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/items?page=2"},
+				{target: "/items?page=1", accept: "text/csv", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/items?page=1"},
+				{target: "/items?page=1", accept: "text/csv", wantCode: http.StatusOK, wantXCache: "HIT", wantBody: "/items?page=1"},
+			},
+			wantCalls: 3,
+		},
+		{
+			name:     "not modified",
+			capacity: 10,
+			requests: []request{
+				{target: "/items", ifNoneMatch: `W/"other", ` + etag, wantCode: http.StatusNotModified, wantXCache: "MISS"},
+				{target: "/items", ifNoneMatch: etag, wantCode: http.StatusNotModified, wantXCache: "HIT"},
+				{target: "/items", ifNoneMatch: `"other"`, wantCode: http.StatusOK, wantXCache: "HIT", wantBody: "/items"},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:     "errors not cached",
+			capacity: 10,
+			requests: []request{
+				{target: "/missing", wantCode: http.StatusNotFound, wantXCache: "MISS", wantBody: "not found"},
+				{target: "/missing", wantCode: http.StatusNotFound, wantXCache: "MISS", wantBody: "not found"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "least recently used evicted",
+			capacity: 2,
+			requests: []request{
+				{target: "/a", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/a"},
+				{target: "/b", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/b"},
+				{target: "/a", wantCode: http.StatusOK, wantXCache: "HIT", wantBody: "/a"},
+				{target: "/c", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/c"},
+				{target: "/a", wantCode: http.StatusOK, wantXCache: "HIT", wantBody: "/a"},
+				{target: "/b", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/b"},
+			},
+			wantCalls: 4,
+		},
+		{
+			name:     "disabled",
+			capacity: 0,
+			requests: []request{
+				{target: "/a", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/a"},
+				{target: "/a", wantCode: http.StatusOK, wantXCache: "MISS", wantBody: "/a"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "watermark unavailable",
+			capacity: 10,
+			requests: []request{
+				{target: "/a", watermark: "error", wantCode: http.StatusOK, wantBody: "/a"},
+				{target: "/a", watermark: "error", wantCode: http.StatusOK, wantBody: "/a"},
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var calls atomic.Int32
+
+				next := http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						calls.Add(1)
+
+						if r.URL.Path == "/missing" {
+							http.Error(w, "not found", http.StatusNotFound)
+							return
+						}
+
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte(r.URL.RequestURI()))
+					},
+				)
+
+				watermark := func(ctx context.Context) (string, error) {
+					mark := ctx.Value(watermarkKey{}).(string)
+					if mark == "error" {
+						return "", errors.New("unavailable")
+					}
+
+					return mark, nil
+				}
+
+				h := Cached(NewResponseCache(tt.capacity), watermark, next)
+
+				for i, req := range tt.requests {
+					r := httptest.NewRequest(http.MethodGet, req.target, nil)
+					r = r.WithContext(context.WithValue(r.Context(), watermarkKey{}, req.watermark))
+
+					if req.accept != "" {
+						r.Header.Set("Accept", req.accept)
+					}
+
+					if req.ifNoneMatch != "" {
+						r.Header.Set("If-None-Match", req.ifNoneMatch)
+					}
+
+					rec := httptest.NewRecorder()
+					h.ServeHTTP(rec, r)
+
+					require.Equal(t, req.wantCode, rec.Code, "request %d", i)
+					assert.Equal(t, req.wantXCache, rec.Header().Get("X-Cache"), "request %d", i)
+					assert.Contains(t, rec.Body.String(), req.wantBody, "request %d", i)
+
+					if req.wantCode == http.StatusOK || req.wantCode == http.StatusNotModified {
+						if req.wantXCache != "" {
+							assert.Equal(t, CacheControl, rec.Header().Get("Cache-Control"), "request %d", i)
+							assert.NotEmpty(t, rec.Header().Get("ETag"), "request %d", i)
+						}
+					} else {
+						assert.Empty(t, rec.Header().Get("ETag"), "request %d", i)
+					}
+
+					if req.wantCode == http.StatusNotModified {
+						assert.Empty(t, rec.Body.String(), "request %d", i)
+					}
+				}
+
+				assert.Equal(t, tt.wantCalls, calls.Load())
+			},
+		)
+	}
+}
+
+type watermarkKey struct{}
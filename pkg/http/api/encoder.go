@@ -0,0 +1,305 @@
+package api
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FormatKey is the query parameter selecting the response format, taking precedence over the Accept header.
+const FormatKey = "format"
+
+// Encoder writes a page of records in a given media type.
+type Encoder interface {
+	ContentType() string
+	// Encode writes data, a slice of structs, along with its pagination metadata when the format can hold it.
+	Encode(w io.Writer, data any, page Page) error
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		"json":   JSONEncoder{},
+		"csv":    CSVEncoder{},
+		"ndjson": NDJSONEncoder{},
+	}
+)
+
+// RegisterEncoder makes an encoder available under the given format name, replacing any previous one.
+func RegisterEncoder(format string, e Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	encoders[format] = e
+}
+
+// EncoderFromRequest selects the encoder of a request, from the format query parameter or else the Accept header.
+// JSON is the default when the Accept header is absent or holds no supported media type.
+func EncoderFromRequest(req *http.Request) (Encoder, error) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	return negotiate(req, encoders, encoders["json"])
+}
+
+// StreamEncoderFromRequest selects the stream encoder of a request, like EncoderFromRequest.
+// CSV is the default when the Accept header is absent or holds no supported media type.
+func StreamEncoderFromRequest(req *http.Request) (StreamEncoder, error) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	streams := make(map[string]Encoder, len(encoders))
+	for format, e := range encoders {
+		if _, ok := e.(StreamEncoder); ok {
+			streams[format] = e
+		}
+	}
+
+	e, err := negotiate(req, streams, encoders["csv"])
+	if err != nil {
+		return nil, err
+	}
+
+	return e.(StreamEncoder), nil
+}
+
+// negotiate selects one of the candidate encoders, keyed by format name, falling back to the given encoder.
+func negotiate(req *http.Request, candidates map[string]Encoder, fallback Encoder) (Encoder, error) {
+	if format := req.URL.Query().Get(FormatKey); format != "" {
+		e, ok := candidates[format]
+		if !ok {
+			return nil, fmt.Errorf("%s must be one of %s", FormatKey, strings.Join(formats(candidates), ", "))
+		}
+
+		return e, nil
+	}
+
+	var (
+		best  Encoder
+		bestQ float64
+	)
+
+	for _, accepted := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+
+		for _, format := range formats(candidates) {
+			if e := candidates[format]; e.ContentType() == mediaType && q > bestQ {
+				best, bestQ = e, q
+			}
+		}
+	}
+
+	if best == nil {
+		return fallback, nil
+	}
+
+	return best, nil
+}
+
+// formats returns the format names of the encoders, sorted.
+func formats(encoders map[string]Encoder) []string {
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// PageResponse writes a page of data with the encoder negotiated by EncoderFromRequest.
+// Navigation links are added to the Link header, and to the body when the format can hold them.
+func PageResponse(res http.ResponseWriter, req *http.Request, e Encoder, statusCode int, data any, page Page) error {
+	page = page.withLinks(req)
+
+	if link := page.linkHeader(); link != "" {
+		res.Header().Add("Link", link)
+	}
+
+	if page.TotalCount != nil {
+		res.Header().Set("X-Total-Count", strconv.FormatInt(*page.TotalCount, 10))
+	}
+
+	res.Header().Add("Vary", "Accept")
+	res.Header().Set("Content-Type", e.ContentType())
+	res.WriteHeader(statusCode)
+
+	return e.Encode(res, data, page)
+}
+
+// JSONEncoder writes the records and their pagination as a single JSON document.
+type JSONEncoder struct{}
+
+func (JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (JSONEncoder) Encode(w io.Writer, data any, page Page) error {
+	return json.NewEncoder(w).Encode(pageResponse{Data: data, Pagination: page})
+}
+
+// NDJSONEncoder writes one JSON document per record. Pagination is only available in the headers.
+type NDJSONEncoder struct{}
+
+func (NDJSONEncoder) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (NDJSONEncoder) FileExtension() string {
+	return "ndjson"
+}
+
+func (e NDJSONEncoder) Encode(w io.Writer, data any, _ Page) error {
+	return encodeRecords(e, w, data)
+}
+
+func (NDJSONEncoder) NewRecordWriter(w io.Writer, _ reflect.Type) (RecordWriter, error) {
+	return ndjsonRecordWriter{enc: json.NewEncoder(w)}, nil
+}
+
+type ndjsonRecordWriter struct {
+	enc *json.Encoder
+}
+
+func (rw ndjsonRecordWriter) Write(record any) error {
+	return rw.enc.Encode(record)
+}
+
+func (ndjsonRecordWriter) Flush() error {
+	return nil // The JSON encoder does not buffer.
+}
+
+// CSVEncoder writes one row per record, after a header holding the JSON names of the fields.
+// Values are formatted as in JSON, decimals in fixed-point notation. Pagination is only available in the headers.
+type CSVEncoder struct{}
+
+func (CSVEncoder) ContentType() string {
+	return "text/csv"
+}
+
+func (CSVEncoder) FileExtension() string {
+	return "csv"
+}
+
+func (e CSVEncoder) Encode(w io.Writer, data any, _ Page) error {
+	return encodeRecords(e, w, data)
+}
+
+// NewRecordWriter writes the CSV header right away, so that an empty dataset still has its columns.
+func (CSVEncoder) NewRecordWriter(w io.Writer, recordType reflect.Type) (RecordWriter, error) {
+	if recordType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: cannot encode %s, expected a struct", recordType)
+	}
+
+	rw := &csvRecordWriter{
+		cw:      csv.NewWriter(w),
+		columns: csvColumns(recordType),
+	}
+
+	header := make([]string, len(rw.columns))
+	for i, c := range rw.columns {
+		header[i] = c.name
+	}
+
+	if err := rw.cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	rw.row = make([]string, len(rw.columns))
+
+	return rw, nil
+}
+
+type csvRecordWriter struct {
+	cw      *csv.Writer
+	columns []csvColumn
+	row     []string
+}
+
+func (rw *csvRecordWriter) Write(record any) error {
+	v := reflect.ValueOf(record)
+
+	for i, c := range rw.columns {
+		value, err := csvValue(v.Field(c.index))
+		if err != nil {
+			return fmt.Errorf("csv: column %s: %w", c.name, err)
+		}
+
+		rw.row[i] = value
+	}
+
+	return rw.cw.Write(rw.row)
+}
+
+func (rw *csvRecordWriter) Flush() error {
+	rw.cw.Flush()
+	return rw.cw.Error()
+}
+
+type csvColumn struct {
+	name  string
+	index int
+}
+
+// csvColumns returns the exported fields of a struct type, named after their JSON tag.
+func csvColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		columns = append(columns, csvColumn{name: name, index: i})
+	}
+
+	return columns
+}
+
+// csvValue formats a field value, nil pointers being empty.
+func csvValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+
+		v = v.Elem()
+	}
+
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	return fmt.Sprint(v.Interface()), nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderFromRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		target  string
+		accept  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", target: "/", want: "application/json"},
+		{name: "wildcard", target: "/", accept: "*/*", want: "application/json"},
+		{name: "accept csv", target: "/", accept: "text/csv", want: "text/csv"},
+		{name: "accept by quality", target: "/", accept: "text/csv;q=0.2, application/x-ndjson;q=0.8", want: "application/x-ndjson"},
+		{name: "unsupported accept", target: "/", accept: "application/xml", want: "application/json"},
+		{name: "format over accept", target: "/?format=csv", accept: "application/json", want: "text/csv"},
+		{name: "unknown format", target: "/?format=xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				r := httptest.NewRequest("GET", tt.target, nil)
+				if tt.accept != "" {
+					r.Header.Set("Accept", tt.accept)
+				}
+
+				got, err := EncoderFromRequest(r)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.ContentType())
+			},
+		)
+	}
+}
+
+func TestCSVEncoder_Encode(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Name      string          `json:"name"`
+		Amount    decimal.Decimal `json:"amount"`
+		Timestamp time.Time       `json:"timestamp"`
+		Baker     *string         `json:"baker,omitempty"`
+		Hidden    string          `json:"-"`
+	}
+
+	baker := "tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft"
+
+	var buf bytes.Buffer
+	err := CSVEncoder{}.Encode(
+		&buf, []record{
+			{Name: "a, b", Amount: decimal.RequireFromString("1.5E+3"), Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Baker: &baker},
+			{Name: "c", Amount: decimal.RequireFromString("0.000001"), Hidden: "hidden"},
+		}, Page{},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		"name,amount,timestamp,baker\n"+
+			"\"a, b\",1500,2024-01-02T03:04:05Z,tz1U2ufqFdVkN2RdYormwHtgm3ityYY1uqft\n"+
+			"c,0.000001,0001-01-01T00:00:00Z,\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	require.NoError(t, CSVEncoder{}.Encode(&buf, []record{}, Page{}))
+	assert.Equal(t, "name,amount,timestamp,baker\n", buf.String())
+
+	assert.Error(t, CSVEncoder{}.Encode(&buf, "not a slice", Page{}))
+}
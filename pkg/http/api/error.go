@@ -11,9 +11,16 @@ import (
 )
 
 type Error struct {
-	Status  int    `json:"-"`
-	Err     error  `json:"-"`
-	Message string `json:"message"`
+	Status  int           `json:"-"`
+	Err     error         `json:"-"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail describes why a parameter of a request is invalid.
+type ErrorDetail struct {
+	Parameter string `json:"parameter"`
+	Message   string `json:"message"`
 }
 
 func (e Error) Error() string {
@@ -71,6 +78,14 @@ func httpStatusFromCode(code api.Code) int {
 		return http.StatusInternalServerError
 	case api.InvalidArgument:
 		return http.StatusBadRequest
+	case api.NotFound:
+		return http.StatusNotFound
+	case api.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case api.Unauthenticated:
+		return http.StatusUnauthorized
+	case api.PermissionDenied:
+		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
 	}
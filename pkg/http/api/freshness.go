@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DataLagHeader is the number of seconds the data served lags behind the head of the chain.
+const DataLagHeader = "X-Data-Lag"
+
+// DataLag returns how far the data served lags behind the head of the chain, false when unknown.
+type DataLag func(ctx context.Context) (time.Duration, bool)
+
+// WithDataLag sets the X-Data-Lag header of the responses of next, unless the lag is unknown.
+func WithDataLag(lag DataLag, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if d, ok := lag(r.Context()); ok {
+				w.Header().Set(DataLagHeader, strconv.FormatInt(int64(d/time.Second), 10))
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
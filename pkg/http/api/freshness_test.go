@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDataLag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		lag  DataLag
+		want string
+	}{
+		{
+			name: "known lag",
+			lag:  func(context.Context) (time.Duration, bool) { return 72500 * time.Millisecond, true },
+			want: "72",
+		},
+		{
+			name: "unknown lag",
+			lag:  func(context.Context) (time.Duration, bool) { return 0, false },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				rec := httptest.NewRecorder()
+				h := WithDataLag(tt.lag, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+				h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/xtz/delegations", nil))
+
+				assert.Equal(t, tt.want, rec.Header().Get(DataLagHeader))
+			},
+		)
+	}
+}
@@ -6,11 +6,15 @@ import (
 
 type Handler func(w http.ResponseWriter, r *http.Request) error
 
-// Handle wraps a http.HandlerFunc with error handling.
+// Handle wraps a http.HandlerFunc with error handling. Panics are turned into internal errors, or abort the response
+// once it started.
 func Handle(h Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if err := h(w, r); err != nil {
-			writeError(w, r, err)
+		sw := &startedResponseWriter{ResponseWriter: w}
+		defer recoverPanic(sw, r)
+
+		if err := h(sw, r); err != nil {
+			writeError(sw, r, err)
 		}
 	}
 }
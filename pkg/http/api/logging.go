@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestIDHeader identifies a request across services. It is propagated when valid, and generated otherwise.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds the request ids propagated from clients, longer ones being replaced.
+const maxRequestIDLen = 128
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the id of the request, as set by Logged.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// Logged identifies each request with the X-Request-ID header, propagated from the client or generated, and sends it
+// back. It attaches to the request context a logger with the fields of the request, used by log.Ctx, and logs an
+// access log once the response is written.
+func Logged(logger zerolog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+
+			l := logger.With().
+				Str("request_id", id).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Str("remote_addr", r.RemoteAddr).
+				Logger()
+
+			ctx := context.WithValue(l.WithContext(r.Context()), requestIDKey{}, id)
+			lw := &loggedResponseWriter{ResponseWriter: w}
+
+			defer func() {
+				// An aborted response is logged before being aborted again.
+				p := recover()
+
+				if lw.status == 0 && p == nil {
+					lw.status = http.StatusOK // Nothing written.
+				}
+
+				l.Info().
+					Int("status", lw.status).
+					Int64("bytes", lw.bytes).
+					Dur("duration", time.Since(start)).
+					Str("user_agent", r.UserAgent()).
+					Bool("aborted", p != nil).
+					Msg("Request")
+
+				if p != nil {
+					panic(p)
+				}
+			}()
+
+			next.ServeHTTP(lw, r.WithContext(ctx))
+		},
+	)
+}
+
+// validRequestID reports whether a request id sent by a client is safe to propagate and log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID returns a random request id of 32 hex characters.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // Never fails.
+
+	return hex.EncodeToString(b)
+}
+
+// loggedResponseWriter records the status and size of a response. It unwraps to the original writer, so that
+// http.ResponseController can still flush streams.
+type loggedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *loggedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *loggedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+
+	return n, err
+}
+
+func (w *loggedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Recovered turns the panics of next into internal errors, as Handle does for the handlers it wraps.
+func Recovered(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			sw := &startedResponseWriter{ResponseWriter: w}
+			defer recoverPanic(sw, r)
+
+			next.ServeHTTP(sw, r)
+		},
+	)
+}
+
+// recoverPanic, deferred by a handler, writes an internal error when it panics, and logs the panic with its stack.
+// http.ErrAbortHandler is panicked again, since it aborts the response on purpose, and so is any panic once the
+// response started, such as mid-way through an export, since the client would otherwise take it as complete.
+func recoverPanic(w *startedResponseWriter, r *http.Request) {
+	p := recover()
+	if p == nil {
+		return
+	}
+
+	if p == http.ErrAbortHandler { // Panicked as is, never wrapped.
+		panic(p)
+	}
+
+	log.Ctx(r.Context()).Error().Interface("panic", p).Bytes("stack", debug.Stack()).Msg("Handler panicked")
+
+	if w.started {
+		panic(http.ErrAbortHandler)
+	}
+
+	writeError(w, r, InternalServerError(fmt.Errorf("panic: %v", p)))
+}
+
+// startedResponseWriter records whether a response started, after which an error can no longer be written. It
+// unwraps to the original writer, as loggedResponseWriter does.
+type startedResponseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *startedResponseWriter) WriteHeader(status int) {
+	if status >= http.StatusOK { // Informational responses precede the response.
+		w.started = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *startedResponseWriter) Write(b []byte) (int, error) {
+	w.started = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *startedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		handler       http.HandlerFunc
+		wantID        string // Empty for a generated id.
+		wantCode      int
+		wantStatusLog int
+		wantBytes     int
+		wantPanic     bool
+	}{
+		{
+			name:      "propagated id",
+			requestID: "abc-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				id, _ := RequestIDFromContext(r.Context())
+				log.Ctx(r.Context()).Info().Msg("Handled")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(id))
+			},
+			wantID:        "abc-123",
+			wantCode:      http.StatusCreated,
+			wantStatusLog: http.StatusCreated,
+			wantBytes:     len("abc-123"),
+		},
+		{
+			name:          "generated id",
+			handler:       func(http.ResponseWriter, *http.Request) {},
+			wantCode:      http.StatusOK,
+			wantStatusLog: http.StatusOK,
+		},
+		{
+			name:          "invalid id",
+			requestID:     "two words",
+			handler:       func(http.ResponseWriter, *http.Request) {},
+			wantCode:      http.StatusOK,
+			wantStatusLog: http.StatusOK,
+		},
+		{
+			name:          "too long id",
+			requestID:     strings.Repeat("a", maxRequestIDLen+1),
+			handler:       func(http.ResponseWriter, *http.Request) {},
+			wantCode:      http.StatusOK,
+			wantStatusLog: http.StatusOK,
+		},
+		{
+			name:      "aborted",
+			requestID: "abc-123",
+			handler: func(http.ResponseWriter, *http.Request) {
+				panic(http.ErrAbortHandler)
+			},
+			wantID:    "abc-123",
+			wantCode:  http.StatusOK,
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var logs bytes.Buffer
+
+				h := Logged(zerolog.New(&logs), tt.handler)
+
+				req := httptest.NewRequest(http.MethodGet, "/xtz/delegations", nil)
+				if tt.requestID != "" {
+					req.Header.Set(RequestIDHeader, tt.requestID)
+				}
+
+				rec := httptest.NewRecorder()
+
+				if tt.wantPanic {
+					assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.ServeHTTP(rec, req) })
+				} else {
+					h.ServeHTTP(rec, req)
+				}
+
+				assert.Equal(t, tt.wantCode, rec.Code)
+
+				id := rec.Header().Get(RequestIDHeader)
+				if tt.wantID != "" {
+					assert.Equal(t, tt.wantID, id)
+				} else {
+					assert.Len(t, id, 32)
+				}
+
+				lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+
+				// Every log line of the request carries its fields.
+				for _, line := range lines {
+					var entry map[string]any
+					require.NoError(t, json.Unmarshal([]byte(line), &entry))
+					assert.Equal(t, id, entry["request_id"])
+					assert.Equal(t, "/xtz/delegations", entry["path"])
+				}
+
+				var access struct {
+					Status  int    `json:"status"`
+					Bytes   int    `json:"bytes"`
+					Aborted bool   `json:"aborted"`
+					Message string `json:"message"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &access))
+				assert.Equal(t, "Request", access.Message)
+				assert.Equal(t, tt.wantStatusLog, access.Status)
+				assert.Equal(t, tt.wantBytes, access.Bytes)
+				assert.Equal(t, tt.wantPanic, access.Aborted)
+			},
+		)
+	}
+}
+
+func TestHandle_Panics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		written   string // Written before panicking.
+		panic     any
+		wantCode  int
+		wantPanic any
+	}{
+		{
+			name:     "value",
+			panic:    "boom",
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "error",
+			panic:    errors.New("boom"),
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "aborted",
+			panic:     http.ErrAbortHandler,
+			wantPanic: http.ErrAbortHandler,
+		},
+		{
+			name:      "response started",
+			written:   "hash,timestamp\n",
+			panic:     "boom",
+			wantPanic: http.ErrAbortHandler,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				h := Handle(
+					func(w http.ResponseWriter, _ *http.Request) error {
+						if tt.written != "" {
+							_, _ = w.Write([]byte(tt.written))
+						}
+
+						panic(tt.panic)
+					},
+				)
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				if tt.wantPanic != nil {
+					assert.PanicsWithValue(t, tt.wantPanic, func() { h.ServeHTTP(rec, req) })
+					assert.Equal(t, tt.written, rec.Body.String())
+					return
+				}
+
+				require.NotPanics(t, func() { h.ServeHTTP(rec, req) })
+				assert.Equal(t, tt.wantCode, rec.Code)
+				assert.JSONEq(t, `{"data":{"message":"Internal Server Error"}}`, rec.Body.String())
+			},
+		)
+	}
+}
+
+func TestRecovered(t *testing.T) {
+	t.Parallel()
+
+	h := Recovered(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") }))
+	rec := httptest.NewRecorder()
+
+	require.NotPanics(t, func() { h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil)) })
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	h = Recovered(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				panic("boom")
+			},
+		),
+	)
+	rec = httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil)) })
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPI is the subset of an OpenAPI 3 document needed to validate the query parameters of requests.
+type OpenAPI struct {
+	OpenAPI string              `json:"openapi"`
+	Paths   map[string]PathItem `json:"paths"`
+
+	routes []openAPIRoute
+}
+
+// PathItem holds the operations of a path, keyed by lowercase HTTP method.
+type PathItem map[string]Operation
+
+type Operation struct {
+	OperationID string      `json:"operationId"`
+	Parameters  []Parameter `json:"parameters"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"` // query, path or header.
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Type     string   `json:"type"`
+	Format   string   `json:"format"`
+	Enum     []string `json:"enum"`
+	Pattern  string   `json:"pattern"`
+	Minimum  *int     `json:"minimum"`
+	Maximum  *int     `json:"maximum"`
+	MaxItems *int     `json:"maxItems"`
+	Items    *Schema  `json:"items"`
+
+	pattern *regexp.Regexp
+}
+
+type openAPIRoute struct {
+	method   string
+	segments []string // Templated segments, like {hash}, match any segment.
+	literals int
+	op       *Operation
+}
+
+// ParseOpenAPI parses an OpenAPI 3 JSON document.
+func ParseOpenAPI(b []byte) (*OpenAPI, error) {
+	var spec OpenAPI
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("unmarshal openapi: %w", err)
+	}
+
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			for i := range op.Parameters {
+				if err := op.Parameters[i].Schema.compile(); err != nil {
+					return nil, fmt.Errorf("%s %s: parameter %s: %w", method, path, op.Parameters[i].Name, err)
+				}
+			}
+
+			route := openAPIRoute{
+				method:   strings.ToUpper(method),
+				segments: strings.Split(path, "/"),
+				op:       &op,
+			}
+
+			for _, s := range route.segments {
+				if !isTemplate(s) {
+					route.literals++
+				}
+			}
+
+			spec.routes = append(spec.routes, route)
+		}
+	}
+
+	return &spec, nil
+}
+
+func (s *Schema) compile() error {
+	if s == nil {
+		return errors.New("missing schema")
+	}
+
+	if s.Pattern != "" {
+		var err error
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return err
+		}
+	}
+
+	if s.Type == "array" {
+		return s.Items.compile()
+	}
+
+	return nil
+}
+
+func isTemplate(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// Operation returns the operation matching a request method and path. Like http.ServeMux, literal segments take
+// precedence over templated ones.
+func (s *OpenAPI) Operation(method, path string) (*Operation, bool) {
+	var (
+		segments = strings.Split(path, "/")
+		best     *openAPIRoute
+	)
+
+	for i, route := range s.routes {
+		if route.method != method || !route.match(segments) {
+			continue
+		}
+
+		if best == nil || route.literals > best.literals {
+			best = &s.routes[i]
+		}
+	}
+
+	if best == nil {
+		return nil, false
+	}
+
+	return best.op, true
+}
+
+func (r openAPIRoute) match(segments []string) bool {
+	if len(segments) != len(r.segments) {
+		return false
+	}
+
+	for i, s := range r.segments {
+		if isTemplate(s) {
+			if segments[i] == "" {
+				return false
+			}
+		} else if s != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateQuery rejects the requests whose query parameters do not match the spec, with a 400 listing every invalid
+// parameter. Query parameters absent from the spec are invalid. Requests matching no operation are passed through.
+func ValidateQuery(spec *OpenAPI, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			op, ok := spec.Operation(r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if details := op.validateQuery(r); len(details) > 0 {
+				writeError(
+					w, r, &Error{
+						Status:  http.StatusBadRequest,
+						Err:     errors.New("query validation failed"),
+						Message: "invalid query parameters",
+						Details: details,
+					},
+				)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
+func (op *Operation) validateQuery(r *http.Request) []ErrorDetail {
+	var (
+		query   = r.URL.Query()
+		known   = make(map[string]bool)
+		details []ErrorDetail
+	)
+
+	for _, p := range op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+
+		known[p.Name] = true
+
+		if err := p.validate(query[p.Name]); err != nil {
+			details = append(details, ErrorDetail{Parameter: p.Name, Message: err.Error()})
+		}
+	}
+
+	for name := range query {
+		if !known[name] {
+			details = append(details, ErrorDetail{Parameter: name, Message: "unknown parameter"})
+		}
+	}
+
+	// Query parameters are unordered.
+	slices.SortFunc(details, func(a, b ErrorDetail) int { return strings.Compare(a.Parameter, b.Parameter) })
+
+	return details
+}
+
+// validate checks the values of a parameter. Arrays may be given as repeated or comma separated values.
+func (p Parameter) validate(values []string) error {
+	if len(values) == 0 {
+		if p.Required {
+			return errors.New("required")
+		}
+
+		return nil
+	}
+
+	if p.Schema.Type != "array" {
+		if len(values) > 1 {
+			return errors.New("must not be repeated")
+		}
+
+		return p.Schema.validate(values[0])
+	}
+
+	var items []string
+	for _, value := range values {
+		items = append(items, strings.Split(value, ",")...)
+	}
+
+	if p.Schema.MaxItems != nil && len(items) > *p.Schema.MaxItems {
+		return fmt.Errorf("must have at most %d items", *p.Schema.MaxItems)
+	}
+
+	for _, item := range items {
+		if err := p.Schema.Items.validate(strings.TrimSpace(item)); err != nil {
+			return fmt.Errorf("item %q: %w", item, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validate(raw string) error {
+	switch s.Type {
+	case "integer":
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+
+		if s.Minimum != nil && value < *s.Minimum {
+			return fmt.Errorf("must be at least %d", *s.Minimum)
+		}
+
+		if s.Maximum != nil && value > *s.Maximum {
+			return fmt.Errorf("must be at most %d", *s.Maximum)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return errors.New("must be a boolean")
+		}
+	case "string":
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, raw); err != nil {
+				return errors.New("must be an RFC 3339 date time")
+			}
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, raw) {
+		return fmt.Errorf("must be one of %s", strings.Join(s.Enum, ", "))
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(raw) {
+		return fmt.Errorf("must match %s", s.Pattern)
+	}
+
+	return nil
+}
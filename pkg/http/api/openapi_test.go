@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOpenAPI = `{
+	"openapi": "3.0.3",
+	"paths": {
+		"/items": {
+			"get": {
+				"parameters": [
+					{"name": "year", "in": "query", "schema": {"type": "integer", "minimum": 2018, "maximum": 2030}},
+					{"name": "from", "in": "query", "schema": {"type": "string", "format": "date-time"}},
+					{"name": "order", "in": "query", "schema": {"type": "string", "enum": ["asc", "desc"]}},
+					{"name": "id", "in": "query", "schema": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^[a-z]+$"}}}
+				]
+			}
+		},
+		"/items/{id}": {
+			"get": {
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+					{"name": "key", "in": "query", "required": true, "schema": {"type": "string"}}
+				]
+			}
+		},
+		"/items/export": {
+			"get": {}
+		}
+	}
+}`
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+
+	spec, err := ParseOpenAPI([]byte(testOpenAPI))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		target      string
+		wantStatus  int
+		wantDetails []ErrorDetail
+	}{
+		{
+			name:       "valid",
+			target:     "/items?year=2024&from=2024-01-01T00:00:00Z&order=asc&id=a,b",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "repeated array",
+			target:     "/items?id=a&id=b",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown route",
+			target:     "/other?year=1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "literal segments first",
+			target:     "/items/export",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid values",
+			target:     "/items?year=2000&from=yesterday&order=up&id=a,B",
+			wantStatus: http.StatusBadRequest,
+			wantDetails: []ErrorDetail{
+				{Parameter: "from", Message: "must be an RFC 3339 date time"},
+				{Parameter: "id", Message: `item "B": must match ^[a-z]+$`},
+				{Parameter: "order", Message: "must be one of asc, desc"},
+				{Parameter: "year", Message: "must be at least 2018"},
+			},
+		},
+		{
+			name:       "not an integer",
+			target:     "/items?year=twenty",
+			wantStatus: http.StatusBadRequest,
+			wantDetails: []ErrorDetail{
+				{Parameter: "year", Message: "must be an integer"},
+			},
+		},
+		{
+			name:       "too many items and repeated",
+			target:     "/items?id=a,b,c&order=asc&order=desc",
+			wantStatus: http.StatusBadRequest,
+			wantDetails: []ErrorDetail{
+				{Parameter: "id", Message: "must have at most 2 items"},
+				{Parameter: "order", Message: "must not be repeated"},
+			},
+		},
+		{
+			name:       "unknown and missing parameters",
+			target:     "/items/abc?page=1",
+			wantStatus: http.StatusBadRequest,
+			wantDetails: []ErrorDetail{
+				{Parameter: "key", Message: "required"},
+				{Parameter: "page", Message: "unknown parameter"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				h := ValidateQuery(
+					spec, http.HandlerFunc(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusOK)
+						},
+					),
+				)
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+				require.Equal(t, tt.wantStatus, rec.Code)
+
+				if tt.wantStatus != http.StatusOK {
+					var res struct {
+						Data Error `json:"data"`
+					}
+					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+					assert.Equal(t, "invalid query parameters", res.Data.Message)
+					assert.Equal(t, tt.wantDetails, res.Data.Details)
+				}
+			},
+		)
+	}
+}
+
+func TestParseOpenAPI_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseOpenAPI(
+		[]byte(`{"paths": {"/": {"get": {"parameters": [{"name": "q", "in": "query", "schema": {"type": "string", "pattern": "("}}]}}}}`),
+	)
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"kiln-exercice/pkg/api"
+)
+
+// Page holds the pagination metadata of a list response.
+type Page struct {
+	PageSize            int    `json:"page_size"`
+	PageNumber          int    `json:"page_number,omitempty"` // Only set with offset pagination.
+	TotalCount          *int64 `json:"total_count,omitempty"` // Only set on the pages fetched without cursor.
+	TotalCountEstimated *bool  `json:"total_count_estimated,omitempty"`
+	HasMore             bool   `json:"has_more"`
+	NextCursor          string `json:"next_cursor,omitempty"`
+	Next                string `json:"next,omitempty"`
+	Prev                string `json:"prev,omitempty"`
+	First               string `json:"first,omitempty"`
+}
+
+// NewPage returns the pagination metadata of a page fetched with the given pagination.
+// nextCursor is empty on the last page. Pages fetched with a cursor are not counted, their clients having the count
+// of the first page.
+func NewPage(pagination api.Pagination, totalCount int64, estimated bool, nextCursor string) Page {
+	page := Page{
+		PageSize:   pagination.PageSize,
+		HasMore:    nextCursor != "",
+		NextCursor: nextCursor,
+	}
+
+	if pagination.Cursor == "" {
+		page.PageNumber = pagination.PageNumber
+		page.TotalCount = &totalCount
+		page.TotalCountEstimated = &estimated
+	}
+
+	return page
+}
+
+// withLinks fills the navigation links of the page, relative to the request URL.
+// Offset paginated requests get page number links, keyset paginated ones only get a next link.
+func (p Page) withLinks(req *http.Request) Page {
+	p.First = pageURL(req.URL, func(q url.Values) {
+		q.Del(api.CursorKey)
+		q.Del(api.PageNumberKey)
+	})
+
+	if p.HasMore {
+		p.Next = pageURL(req.URL, func(q url.Values) {
+			if p.PageNumber != 0 {
+				q.Set(api.PageNumberKey, strconv.Itoa(p.PageNumber+1))
+			} else {
+				q.Set(api.CursorKey, p.NextCursor)
+			}
+		})
+	}
+
+	if p.PageNumber > 1 {
+		p.Prev = pageURL(req.URL, func(q url.Values) {
+			q.Set(api.PageNumberKey, strconv.Itoa(p.PageNumber-1))
+		})
+	}
+
+	return p
+}
+
+// linkHeader formats the page links as an RFC 8288 Link header value.
+func (p Page) linkHeader() string {
+	var links []string
+
+	for _, l := range []struct{ rel, uri string }{{"first", p.First}, {"prev", p.Prev}, {"next", p.Next}} {
+		if l.uri != "" {
+			links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, l.uri, l.rel))
+		}
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func pageURL(u *url.URL, edit func(url.Values)) string {
+	q := u.Query()
+	edit(q)
+
+	if len(q) == 0 {
+		return u.Path
+	}
+
+	return u.Path + "?" + q.Encode()
+}
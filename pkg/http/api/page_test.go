@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/pkg/api"
+)
+
+func TestJSONPageResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		page     Page
+		wantLink string
+		wantBody string
+	}{
+		{
+			name:     "offset pagination",
+			r:        httptest.NewRequest("GET", "/xtz/delegations?page_number=2&page_size=10&year=2021", nil),
+			page:     NewPage(api.Pagination{PageNumber: 2, PageSize: 10}, 42, false, "next"),
+			wantLink: `</xtz/delegations?page_size=10&year=2021>; rel="first", </xtz/delegations?page_number=1&page_size=10&year=2021>; rel="prev", </xtz/delegations?page_number=3&page_size=10&year=2021>; rel="next"`,
+			wantBody: `{"data": [], "pagination": {
+				"page_size": 10,
+				"page_number": 2,
+				"total_count": 42,
+				"total_count_estimated": false,
+				"has_more": true,
+				"next_cursor": "next",
+				"first": "/xtz/delegations?page_size=10&year=2021",
+				"prev": "/xtz/delegations?page_number=1&page_size=10&year=2021",
+				"next": "/xtz/delegations?page_number=3&page_size=10&year=2021"
+			}}`,
+		},
+		{
+			name:     "keyset pagination",
+			r:        httptest.NewRequest("GET", "/xtz/delegations?cursor=current", nil),
+			page:     NewPage(api.Pagination{PageNumber: 1, PageSize: 100, Cursor: "current"}, 1000, true, "next"),
+			wantLink: `</xtz/delegations>; rel="first", </xtz/delegations?cursor=next>; rel="next"`,
+			wantBody: `{"data": [], "pagination": {
+				"page_size": 100,
+				"has_more": true,
+				"next_cursor": "next",
+				"first": "/xtz/delegations",
+				"next": "/xtz/delegations?cursor=next"
+			}}`,
+		},
+		{
+			name:     "last page",
+			r:        httptest.NewRequest("GET", "/xtz/delegations", nil),
+			page:     NewPage(api.Pagination{PageNumber: 1, PageSize: 100}, 2, false, ""),
+			wantLink: `</xtz/delegations>; rel="first"`,
+			wantBody: `{"data": [], "pagination": {
+				"page_size": 100,
+				"page_number": 1,
+				"total_count": 2,
+				"total_count_estimated": false,
+				"has_more": false,
+				"first": "/xtz/delegations"
+			}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				w := httptest.NewRecorder()
+				require.NoError(t, JSONPageResponse(w, tt.r, http.StatusOK, []any{}, tt.page))
+
+				assert.Equal(t, tt.wantLink, w.Header().Get("Link"))
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			},
+		)
+	}
+}
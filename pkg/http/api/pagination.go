@@ -3,7 +3,9 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"kiln-exercice/pkg/api"
 )
@@ -20,6 +22,15 @@ func PaginationFromRequest(req *http.Request) (pagination api.Pagination, err er
 		return api.Pagination{}, err
 	}
 
+	if pagination.PageSize > api.MaxPageSize {
+		return api.Pagination{}, fmt.Errorf("%s must be at most %d", api.PageSizeKey, api.MaxPageSize)
+	}
+
+	pagination.Cursor = req.URL.Query().Get(api.CursorKey)
+	if pagination.Cursor != "" && req.URL.Query().Has(api.PageNumberKey) {
+		return api.Pagination{}, fmt.Errorf("%s and %s are mutually exclusive", api.PageNumberKey, api.CursorKey)
+	}
+
 	return pagination, nil
 }
 
@@ -43,3 +54,30 @@ func getPaginationValueFromRequest(req *http.Request, name string, defaultValue
 
 	return value, nil
 }
+
+// SortFromRequest takes a request and returns a Sort.
+// The sort field must be one of fields, and defaults to the first one; the order defaults to descending.
+func SortFromRequest(req *http.Request, fields ...string) (api.Sort, error) {
+	sort := api.Sort{
+		Field: req.URL.Query().Get(api.SortKey),
+		Order: strings.ToLower(req.URL.Query().Get(api.OrderKey)),
+	}
+
+	if sort.Field == "" && len(fields) > 0 {
+		sort.Field = fields[0]
+	}
+
+	if !slices.Contains(fields, sort.Field) {
+		return api.Sort{}, fmt.Errorf("%s must be one of %s", api.SortKey, strings.Join(fields, ", "))
+	}
+
+	switch sort.Order {
+	case "":
+		sort.Order = api.OrderDesc
+	case api.OrderAsc, api.OrderDesc:
+	default:
+		return api.Sort{}, fmt.Errorf("%s must be one of %s, %s", api.OrderKey, api.OrderAsc, api.OrderDesc)
+	}
+
+	return sort, nil
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// IntFromQuery parses an optional integer query parameter, returning 0 when it is absent.
+func IntFromQuery(query url.Values, key string) (int, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, BadRequestError(fmt.Sprintf("invalid %s format: %s", key, raw), err)
+	}
+
+	return value, nil
+}
+
+// TimeFromQuery parses an optional RFC 3339 query parameter, returning the zero time when it is absent.
+func TimeFromQuery(query url.Values, key string) (time.Time, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, BadRequestError(fmt.Sprintf("invalid %s format: %s, expected RFC 3339", key, raw), err)
+	}
+
+	return value, nil
+}
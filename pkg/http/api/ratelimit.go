@@ -0,0 +1,163 @@
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"kiln-exercice/pkg/api"
+)
+
+// sweepEvery is the number of requests between two removals of the idle buckets of a RateLimiter.
+const sweepEvery = 1024
+
+// Limit is a token bucket holding up to Burst tokens, refilled with Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ClientKey identifies the client of a request, whose requests share a bucket.
+type ClientKey func(r *http.Request) string
+
+// RequestCost returns the number of tokens taken by a request.
+type RequestCost func(r *http.Request) int
+
+// RateLimiter limits the requests of each client with a token bucket, safe for concurrent use.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     Limit
+	buckets   map[string]*rate.Limiter
+	requests  int
+	clientKey ClientKey
+	cost      RequestCost
+	timeNow   func() time.Time
+}
+
+// NewRateLimiter returns a rate limiter giving each client a bucket of the limit. A nil cost takes a token per
+// request.
+func NewRateLimiter(limit Limit, clientKey ClientKey, cost RequestCost, timeNow func() time.Time) *RateLimiter {
+	if cost == nil {
+		cost = func(*http.Request) int { return 1 }
+	}
+
+	return &RateLimiter{
+		limit:     limit,
+		buckets:   make(map[string]*rate.Limiter),
+		clientKey: clientKey,
+		cost:      cost,
+		timeNow:   timeNow,
+	}
+}
+
+// take takes cost tokens from the bucket of a client when it holds them, and returns the tokens left.
+func (l *RateLimiter) take(key string, cost int) (bool, float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.timeNow()
+
+	// A full bucket is the same as a new one, so idle buckets are removed to bound the memory used by past clients.
+	if l.requests++; l.requests%sweepEvery == 0 {
+		for k, b := range l.buckets {
+			if b.TokensAt(now) >= float64(l.limit.Burst) {
+				delete(l.buckets, k)
+			}
+		}
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = rate.NewLimiter(rate.Limit(l.limit.Rate), l.limit.Burst)
+		l.buckets[key] = b
+	}
+
+	allowed := b.AllowN(now, cost)
+
+	return allowed, b.TokensAt(now)
+}
+
+// Take takes cost tokens from the bucket of a client when it holds them, and reports whether it did. A call never
+// costs more than the burst, as in RateLimited.
+func (l *RateLimiter) Take(key string, cost int) bool {
+	allowed, _ := l.take(key, l.clamp(cost))
+	return allowed
+}
+
+// clamp bounds a cost between a token and the burst.
+func (l *RateLimiter) clamp(cost int) int {
+	return min(max(cost, 1), l.limit.Burst)
+}
+
+// RateLimited serves the requests of a client while its bucket holds enough tokens, and rejects them with a 429
+// otherwise. A request never costs more than the burst, so that it can always be served eventually.
+//
+// Responses carry the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers, the latter being the number
+// of seconds until the bucket is full, and rejections a Retry-After header.
+func RateLimited(l *RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			cost := l.clamp(l.cost(r))
+
+			allowed, tokens := l.take(l.clientKey(r), cost)
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(l.limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(max(int(math.Floor(tokens)), 0)))
+			w.Header().Set("RateLimit-Reset", l.secondsUntil(float64(l.limit.Burst)-tokens))
+
+			if !allowed {
+				w.Header().Set("Retry-After", l.secondsUntil(float64(cost)-tokens))
+				writeError(w, r, api.NewError(api.ResourceExhausted, "rate limit exceeded, retry later", nil))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
+// secondsUntil returns the number of seconds for a bucket to gain tokens, rounded up.
+func (l *RateLimiter) secondsUntil(tokens float64) string {
+	if tokens <= 0 {
+		return "0"
+	}
+
+	return strconv.Itoa(int(math.Ceil(tokens / l.limit.Rate)))
+}
+
+// ClientIP identifies a client by the IP address the request comes from.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// APIKeyOrIP identifies a client by its API key, as authenticated by Authenticated, or by its IP address for
+// anonymous requests.
+func APIKeyOrIP(r *http.Request) string {
+	if client, ok := api.ClientFromContext(r.Context()); ok {
+		return "key:" + strconv.Itoa(client.ID)
+	}
+
+	return ClientIP(r)
+}
+
+// PageCost takes a token per page of the default size asked for by a request, so at most
+// api.MaxPageSize / api.DefaultPageSize.
+func PageCost(r *http.Request) int {
+	size, err := strconv.Atoi(r.URL.Query().Get(api.PageSizeKey))
+	if err != nil {
+		return 1
+	}
+
+	return api.PageCost(size)
+}
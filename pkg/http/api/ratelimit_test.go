@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kiln-exercice/pkg/api"
+)
+
+func TestRateLimited(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		target        string
+		remoteAddr    string
+		clientID      int           // Of the authenticated client, 0 for anonymous requests.
+		wait          time.Duration // Before the request.
+		wantCode      int
+		wantRemaining string
+		wantReset     string
+		wantRetry     string
+	}
+
+	tests := []struct {
+		name     string
+		limit    Limit
+		requests []request
+	}{
+		{
+			name:  "burst then refill",
+			limit: Limit{Rate: 1, Burst: 2},
+			requests: []request{
+				{wantCode: http.StatusOK, wantRemaining: "1", wantReset: "1"},
+				{wantCode: http.StatusOK, wantRemaining: "0", wantReset: "2"},
+				{wantCode: http.StatusTooManyRequests, wantRemaining: "0", wantReset: "2", wantRetry: "1"},
+				{wait: 500 * time.Millisecond, wantCode: http.StatusTooManyRequests, wantRemaining: "0", wantReset: "2", wantRetry: "1"},
+				{wait: 500 * time.Millisecond, wantCode: http.StatusOK, wantRemaining: "0", wantReset: "2"},
+				{wait: time.Hour, wantCode: http.StatusOK, wantRemaining: "1", wantReset: "1"},
+			},
+		},
+		{
+			name:  "per client",
+			limit: Limit{Rate: 1, Burst: 1},
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", wantCode: http.StatusOK, wantRemaining: "0", wantReset: "1"},
+				{remoteAddr: "192.0.2.1:5678", wantCode: http.StatusTooManyRequests, wantRemaining: "0", wantReset: "1", wantRetry: "1"},
+				{remoteAddr: "192.0.2.2:1234", wantCode: http.StatusOK, wantRemaining: "0", wantReset: "1"},
+				{remoteAddr: "192.0.2.1:1234", clientID: 1, wantCode: http.StatusOK, wantRemaining: "0", wantReset: "1"},
+				{remoteAddr: "192.0.2.2:1234", clientID: 1, wantCode: http.StatusTooManyRequests, wantRemaining: "0", wantReset: "1", wantRetry: "1"},
+			},
+		},
+		{
+			name:  "cost by page size",
+			limit: Limit{Rate: 1, Burst: 5},
+			requests: []request{
+				{target: "/?page_size=250", wantCode: http.StatusOK, wantRemaining: "2", wantReset: "3"},
+				{target: "/?page_size=300", wantCode: http.StatusTooManyRequests, wantRemaining: "2", wantReset: "3", wantRetry: "1"},
+				{target: "/?page_size=100", wantCode: http.StatusOK, wantRemaining: "1", wantReset: "4"},
+				{wait: time.Hour, target: "/?page_size=100000", wantCode: http.StatusOK, wantRemaining: "0", wantReset: "5"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					now  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+					next = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+					l    = NewRateLimiter(tt.limit, APIKeyOrIP, PageCost, func() time.Time { return now })
+					h    = RateLimited(l, next)
+				)
+
+				for i, req := range tt.requests {
+					now = now.Add(req.wait)
+
+					target := req.target
+					if target == "" {
+						target = "/"
+					}
+
+					r := httptest.NewRequest(http.MethodGet, target, nil)
+					if req.remoteAddr != "" {
+						r.RemoteAddr = req.remoteAddr
+					}
+
+					if req.clientID != 0 {
+						r = r.WithContext(api.ContextWithClient(r.Context(), api.Client{ID: req.clientID}))
+					}
+
+					rec := httptest.NewRecorder()
+					h.ServeHTTP(rec, r)
+
+					require.Equal(t, req.wantCode, rec.Code, "request %d", i)
+					assert.Equal(t, strconv.Itoa(tt.limit.Burst), rec.Header().Get("RateLimit-Limit"), "request %d", i)
+					assert.Equal(t, req.wantRemaining, rec.Header().Get("RateLimit-Remaining"), "request %d", i)
+					assert.Equal(t, req.wantReset, rec.Header().Get("RateLimit-Reset"), "request %d", i)
+					assert.Equal(t, req.wantRetry, rec.Header().Get("Retry-After"), "request %d", i)
+
+					if req.wantCode == http.StatusTooManyRequests {
+						assert.JSONEq(t, `{"data":{"message":"rate limit exceeded, retry later"}}`, rec.Body.String())
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(Limit{Rate: 1, Burst: 1}, ClientIP, nil, func() time.Time { return now })
+
+	for i := range sweepEvery - 1 {
+		allowed, _ := l.take(string(rune(i)), 1)
+		require.True(t, allowed)
+	}
+
+	now = now.Add(time.Second)
+
+	allowed, _ := l.take("last", 1)
+	require.True(t, allowed)
+	assert.Len(t, l.buckets, 1, "full buckets removed")
+}
+
+func TestRateLimiter_Take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(Limit{Rate: 1, Burst: 3}, nil, nil, func() time.Time { return now })
+
+	assert.True(t, l.Take("a", 10), "cost above the burst takes the burst")
+	assert.False(t, l.Take("a", 1))
+	assert.True(t, l.Take("b", 0), "cost below a token takes a token")
+	assert.True(t, l.Take("b", 2))
+	assert.False(t, l.Take("b", 1))
+}
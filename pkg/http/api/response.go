@@ -9,6 +9,11 @@ type response struct {
 	Data any `json:"data"`
 }
 
+type pageResponse struct {
+	Data       any  `json:"data"`
+	Pagination Page `json:"pagination"`
+}
+
 // JSONResponse writes a JSON response with the given status code and data.
 func JSONResponse(res http.ResponseWriter, statusCode int, data any) error {
 	res.Header().Set("Content-Type", "application/json")
@@ -16,3 +21,18 @@ func JSONResponse(res http.ResponseWriter, statusCode int, data any) error {
 
 	return json.NewEncoder(res).Encode(response{Data: data})
 }
+
+// JSONPageResponse writes a JSON response with the given status code, page of data and pagination metadata.
+// Navigation links are added to the metadata and to the Link header.
+func JSONPageResponse(res http.ResponseWriter, req *http.Request, statusCode int, data any, page Page) error {
+	page = page.withLinks(req)
+
+	if link := page.linkHeader(); link != "" {
+		res.Header().Add("Link", link)
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+
+	return json.NewEncoder(res).Encode(pageResponse{Data: data, Pagination: page})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LastEventIDKey is the header sent by Server-Sent Events clients to resume a stream after a reconnection.
+const LastEventIDKey = "Last-Event-ID"
+
+// SSE writes Server-Sent Events to an HTTP response. Every event is flushed to the client right away.
+type SSE struct {
+	res *http.ResponseController
+	w   http.ResponseWriter
+}
+
+// NewSSE sends the status and headers of an event stream.
+func NewSSE(w http.ResponseWriter) (*SSE, error) {
+	sse := &SSE{
+		res: http.NewResponseController(w),
+		w:   w,
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disables buffering in nginx-like proxies.
+	w.WriteHeader(http.StatusOK)
+
+	if err := sse.res.Flush(); err != nil {
+		return nil, fmt.Errorf("flush event stream: %w", err)
+	}
+
+	return sse, nil
+}
+
+// Event sends an event whose data is the JSON encoding of data.
+func (s *SSE) Event(id, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	if _, err = fmt.Fprintf(s.w, "id: %s\nevent: %s\ndata: %s\n\n", id, event, b); err != nil {
+		return err
+	}
+
+	return s.res.Flush()
+}
+
+// Comment sends a comment line, ignored by clients. It keeps idle connections open through proxies.
+func (s *SSE) Comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", strings.ReplaceAll(text, "\n", " ")); err != nil {
+		return err
+	}
+
+	return s.res.Flush()
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/rs/zerolog/log"
+)
+
+// streamFlushEvery is the number of records after which a stream is flushed to the client.
+const streamFlushEvery = 1000
+
+// StreamEncoder is an Encoder able to write records one at a time, so that a response never holds the whole dataset.
+type StreamEncoder interface {
+	Encoder
+	// FileExtension is the extension of the files holding the encoded records, without the dot.
+	FileExtension() string
+	// NewRecordWriter returns a writer of records of the given struct type.
+	NewRecordWriter(w io.Writer, recordType reflect.Type) (RecordWriter, error)
+}
+
+// RecordWriter writes records one at a time. Records may be buffered until Flush is called.
+type RecordWriter interface {
+	Write(record any) error
+	Flush() error
+}
+
+// encodeRecords writes data, a slice of structs, with a record writer of the encoder.
+func encodeRecords(e StreamEncoder, w io.Writer, data any) error {
+	records := reflect.ValueOf(data)
+	if records.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot encode %T, expected a slice", data)
+	}
+
+	rw, err := e.NewRecordWriter(w, records.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	for i := range records.Len() {
+		if err = rw.Write(records.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return rw.Flush()
+}
+
+// Stream writes an unbounded list of records to an HTTP response, flushing them regularly to the client.
+// The response status and headers are only sent with the first record, or on Close, so that errors occurring
+// before can still be reported with a regular error response.
+type Stream struct {
+	res        http.ResponseWriter
+	encoder    StreamEncoder
+	recordType reflect.Type
+	filename   string
+	records    RecordWriter
+	count      int
+}
+
+// NewStream returns a stream of records of type T, sent as an attachment named after basename and the encoder.
+func NewStream[T any](res http.ResponseWriter, e StreamEncoder, basename string) *Stream {
+	return &Stream{
+		res:        res,
+		encoder:    e,
+		recordType: reflect.TypeFor[T](),
+		filename:   basename + "." + e.FileExtension(),
+	}
+}
+
+// Started reports whether the response status and headers were sent.
+func (s *Stream) Started() bool {
+	return s.records != nil
+}
+
+// Write sends a record.
+func (s *Stream) Write(record any) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	if err := s.records.Write(record); err != nil {
+		return err
+	}
+
+	if s.count++; s.count%streamFlushEvery == 0 {
+		return s.flush()
+	}
+
+	return nil
+}
+
+// Close sends the remaining buffered records.
+func (s *Stream) Close() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	return s.flush()
+}
+
+// Abort interrupts a started stream after a failure, by aborting the connection,
+// so that the client cannot mistake the truncated response for a complete one.
+func (s *Stream) Abort(ctx context.Context, err error) {
+	log.Ctx(ctx).Error().Err(err).Int("records", s.count).Msg("Stream aborted")
+
+	panic(http.ErrAbortHandler)
+}
+
+func (s *Stream) start() error {
+	if s.Started() {
+		return nil
+	}
+
+	s.res.Header().Set("Content-Type", s.encoder.ContentType())
+	s.res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.filename))
+	s.res.WriteHeader(http.StatusOK)
+
+	records, err := s.encoder.NewRecordWriter(s.res, s.recordType)
+	if err != nil {
+		return err
+	}
+
+	s.records = records
+
+	return nil
+}
+
+func (s *Stream) flush() error {
+	if err := s.records.Flush(); err != nil {
+		return err
+	}
+
+	if err := http.NewResponseController(s.res).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
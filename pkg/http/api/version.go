@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionParam is the media type parameter of the Accept header selecting an API version,
+// as in "application/json; version=2".
+const VersionParam = "version"
+
+// Deprecation dates an API version, announced by the Deprecation (RFC 9745) and Sunset (RFC 8594) headers.
+// Zero dates are not announced.
+type Deprecation struct {
+	Since  time.Time
+	Sunset time.Time
+}
+
+// VersionFromAccept returns the API version requested by the Accept header, 0 when none is.
+func VersionFromAccept(req *http.Request) (int, error) {
+	for _, accepted := range strings.Split(req.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		if raw, ok := params[VersionParam]; ok {
+			version, err := strconv.Atoi(raw)
+			if err != nil || version < 1 {
+				return 0, BadRequestError(fmt.Sprintf("invalid %s: %s", VersionParam, raw), err)
+			}
+
+			return version, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// Versioned serves the requests to the unversioned routes which have a successor, the same route prefixed by
+// "/v<version>", with their successor when the Accept header requests that version. Otherwise, their responses
+// announce the deprecation of the unversioned route. Successors are looked up in routes, and requests are served by
+// next. Versioned routes are served as they are.
+func Versioned(routes *http.ServeMux, version int, deprecation Deprecation, next http.Handler) http.Handler {
+	prefix := "/v" + strconv.Itoa(version)
+
+	return Handle(
+		func(w http.ResponseWriter, r *http.Request) error {
+			successor := r.Clone(r.Context())
+			successor.URL.Path = prefix + r.URL.Path
+			successor.URL.RawPath = ""
+
+			if _, pattern := routes.Handler(successor); pattern == "" || isVersioned(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return nil
+			}
+
+			requested, err := VersionFromAccept(r)
+			if err != nil {
+				return err
+			}
+
+			w.Header().Add("Vary", "Accept")
+
+			switch requested {
+			case version:
+				next.ServeHTTP(w, successor)
+			case 0, 1:
+				if !deprecation.Since.IsZero() {
+					w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecation.Since.Unix(), 10))
+				}
+
+				if !deprecation.Sunset.IsZero() {
+					w.Header().Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+				}
+
+				w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor.URL.Path))
+
+				next.ServeHTTP(w, r)
+			default:
+				return &Error{
+					Status:  http.StatusNotAcceptable,
+					Message: fmt.Sprintf("unsupported %s: %d, must be 1 or %d", VersionParam, requested, version),
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+// isVersioned reports whether a path starts with a version segment, like "/v2/".
+func isVersioned(path string) bool {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+
+	_, err := strconv.Atoi(segment[1:])
+
+	return err == nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersioned(t *testing.T) {
+	t.Parallel()
+
+	deprecation := Deprecation{
+		Since:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name            string
+		target          string
+		accept          string
+		wantCode        int
+		wantServedBy    string
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{
+			name:            "deprecated without version",
+			target:          "/items?id=a",
+			wantCode:        http.StatusOK,
+			wantServedBy:    "/items",
+			wantDeprecation: "@1792368000",
+			wantSunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+			wantLink:        `</v2/items>; rel="successor-version"`,
+		},
+		{
+			name:            "deprecated with version 1",
+			target:          "/items",
+			accept:          "application/json; version=1",
+			wantCode:        http.StatusOK,
+			wantServedBy:    "/items",
+			wantDeprecation: "@1792368000",
+			wantSunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+			wantLink:        `</v2/items>; rel="successor-version"`,
+		},
+		{
+			name:         "successor with version 2",
+			target:       "/items/abc",
+			accept:       "text/csv, application/json; version=2",
+			wantCode:     http.StatusOK,
+			wantServedBy: "/v2/items/abc",
+		},
+		{
+			name:         "versioned path",
+			target:       "/v2/items",
+			wantCode:     http.StatusOK,
+			wantServedBy: "/v2/items",
+		},
+		{
+			name:         "no successor",
+			target:       "/others",
+			accept:       "application/json; version=2",
+			wantCode:     http.StatusOK,
+			wantServedBy: "/others",
+		},
+		{
+			name:     "unsupported version",
+			target:   "/items",
+			accept:   "application/json; version=3",
+			wantCode: http.StatusNotAcceptable,
+		},
+		{
+			name:     "invalid version",
+			target:   "/items",
+			accept:   "application/json; version=two",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				serve := func(w http.ResponseWriter, r *http.Request) { w.Header().Set("Served-By", r.URL.Path) }
+
+				mux := http.NewServeMux()
+				mux.HandleFunc("GET /items", serve)
+				mux.HandleFunc("GET /others", serve)
+				mux.HandleFunc("GET /items/{id}", serve)
+				mux.HandleFunc("GET /v2/items", serve)
+				mux.HandleFunc("GET /v2/items/{id}", serve)
+
+				req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+				if tt.accept != "" {
+					req.Header.Set("Accept", tt.accept)
+				}
+
+				rec := httptest.NewRecorder()
+				Versioned(mux, 2, deprecation, mux).ServeHTTP(rec, req)
+
+				assert.Equal(t, tt.wantCode, rec.Code)
+				assert.Equal(t, tt.wantServedBy, rec.Header().Get("Served-By"))
+				assert.Equal(t, tt.wantDeprecation, rec.Header().Get("Deprecation"))
+				assert.Equal(t, tt.wantSunset, rec.Header().Get("Sunset"))
+				assert.Equal(t, tt.wantLink, rec.Header().Get("Link"))
+			},
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultCursorFetchSize is the number of rows fetched at once by Cursor when no fetch size is given.
+const DefaultCursorFetchSize = 1000
+
+// Cursor runs a query through a server-side cursor and calls fn on every row.
+// Rows are fetched fetchSize at a time, so that memory does not grow with the size of the result.
+// Iteration stops at the first error returned by fn, or when ctx is done.
+func Cursor(
+	ctx context.Context, tb TxxBeginner, fetchSize int, query string, args []any, fn func(*sqlx.Rows) error,
+) error {
+	if fetchSize <= 0 {
+		fetchSize = DefaultCursorFetchSize
+	}
+
+	// A cursor only lives inside the transaction declaring it.
+	return Tx(
+		ctx, tb, func(tx *sqlx.Tx) error {
+			if _, err := tx.ExecContext(ctx, "DECLARE rows_cursor NO SCROLL CURSOR FOR "+query, args...); err != nil {
+				return fmt.Errorf("declare cursor: %w", err)
+			}
+
+			fetch := fmt.Sprintf("FETCH FORWARD %d FROM rows_cursor", fetchSize)
+
+			for {
+				n, err := fetchRows(ctx, tx, fetch, fn)
+				if err != nil {
+					return err
+				}
+
+				if n < fetchSize {
+					return nil
+				}
+			}
+		},
+	)
+}
+
+// fetchRows runs a FETCH statement and returns the number of rows it returned.
+func fetchRows(ctx context.Context, tx *sqlx.Tx, fetch string, fn func(*sqlx.Rows) error) (n int, err error) {
+	rows, err := tx.QueryxContext(ctx, fetch)
+	if err != nil {
+		return 0, fmt.Errorf("fetch: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		n++
+
+		if err = fn(rows); err != nil {
+			return n, err
+		}
+	}
+
+	return n, rows.Err()
+}
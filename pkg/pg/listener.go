@@ -0,0 +1,113 @@
+package pg
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// Listener listens to a PostgreSQL notification channel and wakes up its subscribers on every notification.
+// Notifications are coalesced: a subscriber that has not consumed the previous wake-up is not woken up twice.
+// Subscribers are also woken up after a reconnection, since notifications may have been missed in between.
+type Listener struct {
+	listener *pq.Listener
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	closed      bool
+}
+
+// NewListener starts listening to the given channel.
+func NewListener(params Parameters, channel string) (*Listener, error) {
+	l := &Listener{
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+
+	l.listener = pq.NewListener(
+		params.DSN(), listenerMinReconnect, listenerMaxReconnect, func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Warn().Err(err).Str("channel", channel).Msg("postgres listener event")
+			}
+		},
+	)
+
+	if err := l.listener.Listen(channel); err != nil {
+		return nil, fmt.Errorf("listen %s: %w", channel, errors.Join(err, l.listener.Close()))
+	}
+
+	go l.run()
+
+	return l, nil
+}
+
+// Subscribe returns a channel receiving a value after every notification, closed when the listener is closed.
+// unsubscribe must be called once the channel is not read anymore.
+func (l *Listener) Subscribe() (notifications <-chan struct{}, unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if l.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	l.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.subscribers[ch]; ok {
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close stops listening and closes the channels of every subscriber.
+func (l *Listener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+
+	l.closed = true
+
+	for ch := range l.subscribers {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+
+	return l.listener.Close()
+}
+
+func (l *Listener) run() {
+	// A nil notification is sent after a reconnection. The channel is closed by Close.
+	for range l.listener.Notify {
+		l.broadcast()
+	}
+}
+
+func (l *Listener) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // The subscriber has a pending wake-up already.
+		}
+	}
+}
@@ -1,31 +1,98 @@
 package pg
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 const (
 	LimitNone              = 0
 	DefaultPaginationOrder = "DESC"
 )
 
+var ErrInvalidSort = errors.New("invalid sort")
+
+// SortColumns whitelists the columns a query can be sorted by, keyed by their public name.
+// Only whitelisted columns end up in the ORDER BY clause, since it cannot be parameterized.
+type SortColumns map[string]string
+
+// Column returns the column whitelisted under name.
+func (c SortColumns) Column(name string) (string, error) {
+	column, ok := c[name]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown column %q", ErrInvalidSort, name)
+	}
+
+	return column, nil
+}
+
+// ParseOrder returns the SQL sort order matching order (asc or desc, case-insensitive).
+// An empty order falls back to DefaultPaginationOrder.
+func ParseOrder(order string) (string, error) {
+	switch strings.ToUpper(order) {
+	case "":
+		return DefaultPaginationOrder, nil
+	case "ASC":
+		return "ASC", nil
+	case "DESC":
+		return "DESC", nil
+	default:
+		return "", fmt.Errorf("%w: unknown order %q", ErrInvalidSort, order)
+	}
+}
+
 type Pagination struct {
-	limit   int
-	offset  int
-	orderBy string
-	order   string
+	limit      int
+	offset     int
+	orderBy    string
+	order      string
+	tieBreaker string
+	keyset     []any // Sort key and tie breaker values of the last row of the previous page.
 }
 
 func (p Pagination) Embed(query string) string {
 	if p.OrderBy() != "" {
 		query += fmt.Sprintf(" ORDER BY %s %s", p.OrderBy(), p.Order())
+
+		// Rows sharing the same sort key would come back in any order, making pages overlap.
+		if p.TieBreaker() != "" && p.TieBreaker() != p.OrderBy() {
+			query += fmt.Sprintf(", %s %s", p.TieBreaker(), p.Order())
+		}
 	}
 
 	if p.Limit() != LimitNone {
-		query += fmt.Sprintf(" LIMIT %d OFFSET %d", p.Limit(), p.Offset())
+		query += fmt.Sprintf(" LIMIT %d", p.Limit())
+
+		if p.keyset == nil {
+			query += fmt.Sprintf(" OFFSET %d", p.Offset())
+		}
 	}
 
 	return query
 }
 
+// KeysetPredicate returns the WHERE predicate selecting the rows located after the keyset in the sort order,
+// with placeholders numbered from argIndex, along with its arguments.
+// The predicate is empty when no keyset is set.
+func (p Pagination) KeysetPredicate(argIndex int) (string, []any) {
+	if p.keyset == nil {
+		return "", nil
+	}
+
+	op := "<"
+	if p.Order() == "ASC" {
+		op = ">"
+	}
+
+	if p.TieBreaker() == "" || p.TieBreaker() == p.OrderBy() {
+		return fmt.Sprintf("%s %s $%d", p.OrderBy(), op, argIndex), p.keyset[:1]
+	}
+
+	// The row comparison keeps the predicate sargable on a (orderBy, tieBreaker) index.
+	return fmt.Sprintf("(%s, %s) %s ($%d, $%d)", p.OrderBy(), p.TieBreaker(), op, argIndex, argIndex+1), p.keyset
+}
+
 func NewPagination(limit, offset int, orderBy string) Pagination {
 	return Pagination{
 		limit:   limit,
@@ -34,6 +101,25 @@ func NewPagination(limit, offset int, orderBy string) Pagination {
 	}
 }
 
+// WithOrder sets the sort order, as returned by ParseOrder.
+func (p Pagination) WithOrder(order string) Pagination {
+	p.order = order
+	return p
+}
+
+// WithTieBreaker sets a unique column appended to the ORDER BY clause to make the ordering stable.
+func (p Pagination) WithTieBreaker(column string) Pagination {
+	p.tieBreaker = column
+	return p
+}
+
+// WithKeyset switches to keyset pagination, starting after the row holding the given sort key
+// and tie breaker values. The offset is then ignored.
+func (p Pagination) WithKeyset(value, tieBreakerValue any) Pagination {
+	p.keyset = []any{value, tieBreakerValue}
+	return p
+}
+
 func (p Pagination) Limit() int {
 	return p.limit
 }
@@ -53,3 +139,7 @@ func (p Pagination) Order() string {
 
 	return p.order
 }
+
+func (p Pagination) TieBreaker() string {
+	return p.tieBreaker
+}
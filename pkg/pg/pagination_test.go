@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination_Embed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		pagination Pagination
+		want       string
+	}{
+		{
+			name:       "default order",
+			pagination: NewPagination(10, 20, "datetime"),
+			want:       "SELECT * FROM t ORDER BY datetime DESC LIMIT 10 OFFSET 20",
+		},
+		{
+			name:       "order with tie breaker",
+			pagination: NewPagination(10, 0, "amount").WithOrder("ASC").WithTieBreaker("id"),
+			want:       "SELECT * FROM t ORDER BY amount ASC, id ASC LIMIT 10 OFFSET 0",
+		},
+		{
+			name:       "no limit",
+			pagination: NewPagination(LimitNone, 0, "id").WithTieBreaker("id"),
+			want:       "SELECT * FROM t ORDER BY id DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				assert.Equal(t, tt.want, tt.pagination.Embed("SELECT * FROM t"))
+			},
+		)
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{"": "DESC", "asc": "ASC", "DESC": "DESC"} {
+		got, err := ParseOrder(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseOrder("up; DROP TABLE delegation")
+	assert.ErrorIs(t, err, ErrInvalidSort)
+}
+
+func TestPagination_KeysetPredicate(t *testing.T) {
+	t.Parallel()
+
+	p := NewPagination(10, 20, "amount").WithOrder("ASC").WithTieBreaker("id").WithKeyset("100", 42)
+
+	predicate, args := p.KeysetPredicate(3)
+	assert.Equal(t, "(amount, id) > ($3, $4)", predicate)
+	assert.Equal(t, []any{"100", 42}, args)
+	assert.Equal(t, "SELECT * FROM t ORDER BY amount ASC, id ASC LIMIT 10", p.Embed("SELECT * FROM t"))
+
+	predicate, args = NewPagination(10, 0, "id").WithKeyset(42, 42).KeysetPredicate(1)
+	assert.Equal(t, "id < $1", predicate)
+	assert.Equal(t, []any{42}, args)
+
+	predicate, args = NewPagination(10, 0, "id").KeysetPredicate(1)
+	assert.Empty(t, predicate)
+	assert.Nil(t, args)
+}
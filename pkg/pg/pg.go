@@ -22,17 +22,20 @@ type TxxBeginner interface {
 	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
 }
 
-func New(params Parameters) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf(
+// DSN returns the connection string of the database.
+func (p Parameters) DSN() string {
+	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable", // sslmode=disable is used for simplicity of dev.
-		params.Username,
-		params.Password,
-		params.Host,
-		params.Port,
-		params.DBName,
+		p.Username,
+		p.Password,
+		p.Host,
+		p.Port,
+		p.DBName,
 	)
+}
 
-	db, err := sqlx.Connect("postgres", dsn)
+func New(params Parameters) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("postgres", params.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to database: %w", err)
 	}
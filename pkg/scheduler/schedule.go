@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule returns the next activation time strictly after t.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every returns a schedule activating every interval.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// Cron parses a standard 5 fields cron spec (or a descriptor such as @hourly or @every 10s).
+func Cron(spec string) (Schedule, error) {
+	s, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse cron spec %q: %w", spec, err)
+	}
+
+	return s, nil
+}
+
+type jitterSchedule struct {
+	schedule Schedule
+	jitter   time.Duration
+}
+
+// WithJitter delays every activation of a schedule by a random duration in [0, jitter).
+func WithJitter(schedule Schedule, jitter time.Duration) Schedule {
+	if jitter <= 0 {
+		return schedule
+	}
+
+	return jitterSchedule{schedule: schedule, jitter: jitter}
+}
+
+func (s jitterSchedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t).Add(rand.N(s.jitter))
+}
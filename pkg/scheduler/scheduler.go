@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Job func(ctx context.Context) error
+
+// Backoff configures the delay added after consecutive failures.
+// The delay starts at Initial and doubles on every failure, up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay returns the delay to wait after the given number of consecutive failures, 0 without failure.
+func (b Backoff) Delay(failures int) time.Duration {
+	if b.Initial <= 0 || failures == 0 {
+		return 0
+	}
+
+	d := b.Initial
+	for i := 1; i < failures && (b.Max <= 0 || d < b.Max); i++ {
+		d *= 2
+	}
+
+	if b.Max > 0 && d > b.Max {
+		return b.Max
+	}
+
+	return d
+}
+
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// Fatal marks an error as unrecoverable: the scheduler stops and returns it instead of retrying.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fatalError{err: err}
+}
+
+// Status is the state of a scheduler, telling a wedged job from a healthy one.
+type Status struct {
+	Running             bool      `json:"running"`
+	LastStartedAt       time.Time `json:"last_started_at"` // Zero before the first run.
+	LastSucceededAt     time.Time `json:"last_succeeded_at"`
+	LastError           string    `json:"last_error,omitempty"` // Of the last run, empty when it succeeded.
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	NextRunAt           time.Time `json:"next_run_at"` // Zero while running.
+}
+
+// Scheduler runs a job according to a schedule.
+// Runs never overlap: the next activation is computed once the current run is over, and a run
+// that overruns its slot is followed immediately by the next one instead of waiting for a later slot.
+type Scheduler struct {
+	schedule Schedule
+	job      Job
+	backoff  Backoff
+
+	mu     sync.Mutex
+	status Status
+}
+
+func New(schedule Schedule, job Job, backoff Backoff) *Scheduler {
+	return &Scheduler{
+		schedule: schedule,
+		job:      job,
+		backoff:  backoff,
+	}
+}
+
+// Status returns the state of the scheduler, safe to call while it runs.
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+func (s *Scheduler) setStatus(update func(*Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.status)
+}
+
+// Run runs the job immediately, then on every activation of the schedule until the context is done.
+// It only returns an error if the job returned an error marked with Fatal.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var (
+		next     = time.Now()
+		failures int
+	)
+
+	for {
+		s.setStatus(func(st *Status) { st.NextRunAt = next })
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		start := time.Now()
+
+		s.setStatus(
+			func(st *Status) {
+				st.Running = true
+				st.LastStartedAt = start
+				st.NextRunAt = time.Time{}
+			},
+		)
+
+		err := s.job(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		next = s.schedule.Next(start)
+
+		if err == nil {
+			failures = 0
+
+			s.setStatus(
+				func(st *Status) {
+					st.Running = false
+					st.LastSucceededAt = time.Now()
+					st.LastError = ""
+					st.ConsecutiveFailures = 0
+				},
+			)
+
+			continue
+		}
+
+		var fatal *fatalError
+		if errors.As(err, &fatal) {
+			return fatal.err
+		}
+
+		failures++
+
+		s.setStatus(
+			func(st *Status) {
+				st.Running = false
+				st.LastError = err.Error()
+				st.ConsecutiveFailures = failures
+			},
+		)
+
+		if retryAt := time.Now().Add(s.backoff.Delay(failures)); retryAt.After(next) {
+			next = retryAt
+		}
+
+		log.Warn().Err(err).Int("consecutive_failures", failures).Time("next_run", next).Msg("scheduled job failed")
+	}
+}
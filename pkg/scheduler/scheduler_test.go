@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff_delay(t *testing.T) {
+	t.Parallel()
+
+	b := Backoff{Initial: time.Second, Max: 5 * time.Second}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 1, want: time.Second},
+		{failures: 2, want: 2 * time.Second},
+		{failures: 3, want: 4 * time.Second},
+		{failures: 4, want: 5 * time.Second},
+		{failures: 100, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, b.Delay(tt.failures), "Delay(%d)", tt.failures)
+	}
+}
+
+func TestCron(t *testing.T) {
+	t.Parallel()
+
+	s, err := Cron("*/5 * * * *")
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC),
+		s.Next(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)),
+	)
+
+	_, err = Cron("not a cron")
+	assert.Error(t, err)
+}
+
+func TestWithJitter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s   = WithJitter(Every(time.Minute), time.Second)
+	)
+
+	for range 100 {
+		next := s.Next(now)
+		assert.False(t, next.Before(now.Add(time.Minute)))
+		assert.True(t, next.Before(now.Add(time.Minute+time.Second)))
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run(
+		"keeps running after failures", func(t *testing.T) {
+			t.Parallel()
+
+			var runs atomic.Int32
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := New(
+				Every(time.Millisecond), func(context.Context) error {
+					if runs.Add(1) == 5 {
+						cancel()
+					}
+					return errors.New("transient")
+				}, Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond},
+			)
+
+			assert.NoError(t, s.Run(ctx))
+			assert.Equal(t, int32(5), runs.Load())
+		},
+	)
+
+	t.Run(
+		"stops on fatal error", func(t *testing.T) {
+			t.Parallel()
+
+			errDB := errors.New("db is gone")
+
+			s := New(
+				Every(time.Millisecond), func(context.Context) error {
+					return Fatal(errDB)
+				}, Backoff{},
+			)
+
+			assert.ErrorIs(t, s.Run(context.Background()), errDB)
+		},
+	)
+
+	t.Run(
+		"runs never overlap", func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				running atomic.Int32
+				runs    atomic.Int32
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := New(
+				Every(time.Millisecond), func(context.Context) error {
+					assert.Equal(t, int32(1), running.Add(1))
+					defer running.Add(-1)
+
+					time.Sleep(5 * time.Millisecond) // Slower than the interval.
+					if runs.Add(1) == 3 {
+						cancel()
+					}
+					return nil
+				}, Backoff{},
+			)
+
+			assert.NoError(t, s.Run(ctx))
+			assert.Equal(t, int32(3), runs.Load())
+		},
+	)
+
+	t.Run(
+		"reports its status", func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				runs     atomic.Int32
+				statuses = make(chan Status, 3)
+				s        *Scheduler
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s = New(
+				Every(time.Millisecond), func(context.Context) error {
+					statuses <- s.Status()
+
+					switch runs.Add(1) {
+					case 1:
+						return errors.New("transient")
+					case 2:
+						return nil
+					default:
+						cancel()
+						return nil
+					}
+				}, Backoff{},
+			)
+
+			assert.NoError(t, s.Run(ctx))
+
+			first, second, third := <-statuses, <-statuses, <-statuses
+
+			assert.True(t, first.Running)
+			assert.False(t, first.LastStartedAt.IsZero())
+			assert.True(t, first.LastSucceededAt.IsZero())
+			assert.True(t, first.NextRunAt.IsZero())
+
+			assert.Equal(t, "transient", second.LastError)
+			assert.Equal(t, 1, second.ConsecutiveFailures)
+			assert.True(t, second.LastSucceededAt.IsZero())
+
+			assert.Empty(t, third.LastError)
+			assert.Zero(t, third.ConsecutiveFailures)
+			assert.False(t, third.LastSucceededAt.IsZero())
+		},
+	)
+}
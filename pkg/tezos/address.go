@@ -0,0 +1,44 @@
+package tezos
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	addressLength = 36
+	hashLength    = 20
+)
+
+var (
+	ErrInvalidAddress       = errors.New("invalid tezos address")
+	ErrInvalidOperationHash = errors.New("invalid tezos operation hash")
+)
+
+// addressPrefixes maps every supported address prefix to its base58check version bytes.
+var addressPrefixes = map[string][]byte{
+	"tz1": {6, 161, 159},
+	"tz2": {6, 161, 161},
+	"tz3": {6, 161, 164},
+	"tz4": {6, 161, 166},
+	"KT1": {2, 90, 121},
+}
+
+// ValidateAddress checks that addr is a well-formed tz1, tz2, tz3, tz4 or KT1 address,
+// including its base58check checksum.
+func ValidateAddress(addr string) error {
+	if len(addr) != addressLength {
+		return fmt.Errorf("%w: %q must be %d characters long", ErrInvalidAddress, addr, addressLength)
+	}
+
+	version, ok := addressPrefixes[addr[:3]]
+	if !ok {
+		return fmt.Errorf("%w: %q has an unknown prefix", ErrInvalidAddress, addr)
+	}
+
+	if err := checkBase58(addr, version, hashLength); err != nil {
+		return fmt.Errorf("%w: %q %w", ErrInvalidAddress, addr, err)
+	}
+
+	return nil
+}
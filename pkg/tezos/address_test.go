@@ -0,0 +1,43 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr bool
+	}{
+		{name: "tz1", addr: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"},
+		{name: "tz2", addr: "tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq"},
+		{name: "tz3", addr: "tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5"},
+		{name: "tz4", addr: "tz4HVR6aty9KwsQFHh81C1G7gBdhxT8kuytm"},
+		{name: "KT1", addr: "KT1JejNYjmQYh8yw95u5kfQDRuxJcaUPjUnf"},
+		{name: "empty", addr: "", wantErr: true},
+		{name: "too short", addr: "tz1a1SAaXRt9yoGMx29rh9FsBF4Uzmvojd", wantErr: true},
+		{name: "unknown prefix", addr: "tz5a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", wantErr: true},
+		{name: "invalid character", addr: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdT0", wantErr: true},
+		{name: "invalid checksum", addr: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTM", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := ValidateAddress(tt.addr)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, ErrInvalidAddress)
+				} else {
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
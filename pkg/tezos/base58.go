@@ -0,0 +1,63 @@
+package tezos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	checksumLength = 4
+)
+
+// checkBase58 checks that s is the base58check encoding of version followed by a payload of payloadLength bytes.
+func checkBase58(s string, version []byte, payloadLength int) error {
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return err
+	}
+
+	if len(decoded) != len(version)+payloadLength+checksumLength || !bytes.HasPrefix(decoded, version) {
+		return errors.New("has an invalid payload")
+	}
+
+	payload, checksum := decoded[:len(decoded)-checksumLength], decoded[len(decoded)-checksumLength:]
+
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+
+	if !bytes.Equal(second[:checksumLength], checksum) {
+		return errors.New("has an invalid checksum")
+	}
+
+	return nil
+}
+
+func base58Decode(s string) ([]byte, error) {
+	var (
+		n     = new(big.Int)
+		radix = big.NewInt(58)
+	)
+
+	for _, c := range []byte(s) {
+		idx := bytes.IndexByte([]byte(base58Alphabet), c)
+		if idx < 0 {
+			return nil, fmt.Errorf("has an invalid base58 character %q", c)
+		}
+
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	decoded := n.Bytes()
+
+	// Leading '1' characters encode leading zero bytes.
+	for i := 0; i < len(s) && s[i] == base58Alphabet[0]; i++ {
+		decoded = append([]byte{0}, decoded...)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,23 @@
+package tezos
+
+import "fmt"
+
+const (
+	operationHashLength      = 51
+	operationHashBytesLength = 32
+)
+
+var operationHashVersion = []byte{5, 116}
+
+// ValidateOperationHash checks that hash is a well-formed operation (group) hash, including its base58check checksum.
+func ValidateOperationHash(hash string) error {
+	if len(hash) != operationHashLength || hash[0] != 'o' {
+		return fmt.Errorf("%w: %q must start with o and be %d characters long", ErrInvalidOperationHash, hash, operationHashLength)
+	}
+
+	if err := checkBase58(hash, operationHashVersion, operationHashBytesLength); err != nil {
+		return fmt.Errorf("%w: %q %w", ErrInvalidOperationHash, hash, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOperationHash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr bool
+	}{
+		{name: "valid", hash: "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3b"},
+		{name: "valid bis", hash: "ooedoJWn6fFXaiCkNDftRVCvEbJ855M7fD7gzHryL6x6FXdejP4"},
+		{name: "too short", hash: "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3", wantErr: true},
+		{name: "not an operation", hash: "BLwRUPupdhP8TyWp9J6TbjLSCxPPW6tyhVPF2KmNAbLPt7thjPw", wantErr: true},
+		{name: "invalid checksum", hash: "ooP37LNma6DiWjVxDbS2XZu4PiNKy7fbHZWSn8Vj8FX1hWfkC3c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := ValidateOperationHash(tt.hash)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, ErrInvalidOperationHash)
+				} else {
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
@@ -48,6 +48,7 @@ type Error struct {
 	Type string `json:"type"`
 }
 
+// Quote holds the price of a tez in each currency at the time of an operation.
 type Quote struct {
 	Btc decimal.Decimal `json:"btc"`
 	Eur decimal.Decimal `json:"eur"`
@@ -58,3 +59,9 @@ type Quote struct {
 	Eth decimal.Decimal `json:"eth"`
 	Gbp decimal.Decimal `json:"gbp"`
 }
+
+// Head is the latest block of the chain indexed by TzKT.
+type Head struct {
+	Level     int       `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+}
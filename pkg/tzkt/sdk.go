@@ -10,32 +10,68 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"golang.org/x/time/rate"
+
+	"kiln-exercice/pkg/breaker"
 )
 
 const rateLimit = 10
 
+// quotes lists the currencies of the Quote of every delegation.
+const quotes = "btc,eur,usd,cny,jpy,krw,eth,gbp"
+
 type SDK struct {
-	url    *url.URL
-	client *resty.Client
+	url     *url.URL
+	client  *resty.Client
+	breaker *breaker.Breaker
 }
 
-func NewSDK(rawURL string) (*SDK, error) {
+type Option func(*SDK)
+
+// WithCircuitBreaker guards every call to the TzKT API with the given circuit breaker.
+func WithCircuitBreaker(b *breaker.Breaker) Option {
+	return func(s *SDK) {
+		s.breaker = b
+	}
+}
+
+func NewSDK(rawURL string, opts ...Option) (*SDK, error) {
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, err
 	}
 
-	return &SDK{
+	s := &SDK{
 		url: u,
 		client: resty.New().SetRateLimiter(
 			rate.NewLimiter(
 				rate.Limit(rateLimit), rateLimit,
 			),
 		), // 10 requests per second with the free plan
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
 func (s *SDK) GetDelegations(ctx context.Context, from, to time.Time) (delegations []Delegation, err error) {
+	if s.breaker == nil {
+		return s.getDelegations(ctx, from, to)
+	}
+
+	err = s.breaker.Execute(
+		func() error {
+			delegations, err = s.getDelegations(ctx, from, to)
+			return err
+		},
+	)
+
+	return delegations, err
+}
+
+func (s *SDK) getDelegations(ctx context.Context, from, to time.Time) (delegations []Delegation, err error) {
 	const path = "/v1/operations/delegations"
 	var (
 		result      []Delegation
@@ -58,6 +94,7 @@ func (s *SDK) GetDelegations(ctx context.Context, from, to time.Time) (delegatio
 					"timestamp.lt": to.Format(time.RFC3339),
 					"offset":       strconv.Itoa(offset),
 					"limit":        strconv.Itoa(limit),
+					"quote":        quotes,
 				},
 			).
 			Get(s.url.String() + path)
@@ -65,7 +102,7 @@ func (s *SDK) GetDelegations(ctx context.Context, from, to time.Time) (delegatio
 		if err != nil {
 			if errors.Is(err, resty.ErrRateLimitExceeded) {
 				time.Sleep(rateLimit * time.Second)
-				return s.GetDelegations(ctx, from, to)
+				return s.getDelegations(ctx, from, to)
 			}
 
 			return result, err
@@ -85,3 +122,39 @@ func (s *SDK) GetDelegations(ctx context.Context, from, to time.Time) (delegatio
 
 	return delegations, nil
 }
+
+// GetHead returns the latest block indexed by TzKT.
+func (s *SDK) GetHead(ctx context.Context) (head Head, err error) {
+	if s.breaker == nil {
+		return s.getHead(ctx)
+	}
+
+	err = s.breaker.Execute(
+		func() error {
+			head, err = s.getHead(ctx)
+			return err
+		},
+	)
+
+	return head, err
+}
+
+func (s *SDK) getHead(ctx context.Context) (Head, error) {
+	const path = "/v1/head"
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var head Head
+
+	resp, err := s.client.R().SetContext(ctx).SetResult(&head).Get(s.url.String() + path)
+	if err != nil {
+		return Head{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return Head{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+	}
+
+	return head, nil
+}
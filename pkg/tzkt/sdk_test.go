@@ -59,6 +59,7 @@ func TestSDK_GetDelegations(t *testing.T) {
 					assert.Equal(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("timestamp.ge"))
 					assert.Equal(t, "2021-01-11T00:00:00Z", r.URL.Query().Get("timestamp.lt"))
 					assert.Equal(t, "10000", r.URL.Query().Get("limit"))
+					assert.Equal(t, "btc,eur,usd,cny,jpy,krw,eth,gbp", r.URL.Query().Get("quote"))
 
 					var body []byte
 					switch r.URL.Query().Get("offset") {
@@ -85,7 +86,8 @@ func TestSDK_GetDelegations(t *testing.T) {
 						"newDelegate": {
 							"address": "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd"
 						},
-						"status": "applied"
+						"status": "applied",
+						"quote": {"eur": 1.53, "usd": 1.79}
 					},
 					{
 						"type": "delegation",
@@ -150,6 +152,10 @@ func TestSDK_GetDelegations(t *testing.T) {
 						Address: "tz1Wit2PqodvPeuRRhdQXmkrtU8e8bRYZecd",
 					},
 					Status: "applied",
+					Quote: Quote{
+						Eur: decimal.RequireFromString("1.53"),
+						Usd: decimal.RequireFromString("1.79"),
+					},
 				},
 				{
 					Type:      "delegation",
@@ -207,3 +213,53 @@ func TestSDK_GetDelegations(t *testing.T) {
 		)
 	}
 }
+
+func TestSDK_GetHead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    Head
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "happy path",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/head", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"chain": "mainnet", "level": 5000000, "timestamp": "2024-01-01T00:00:00Z", "synced": true}`))
+			},
+			want:    Head{Level: 5000000, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unexpected status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				server := httptest.NewServer(tt.handler)
+				defer server.Close()
+
+				s, err := NewSDK(server.URL)
+				require.NoError(t, err)
+
+				got, err := s.GetHead(context.Background())
+				if !tt.wantErr(t, err) {
+					return
+				}
+
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
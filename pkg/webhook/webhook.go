@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the signature of a payload, as "t=<unix timestamp>,v1=<hex HMAC-SHA256>".
+	SignatureHeader = "X-Webhook-Signature"
+	// IDHeader holds the id of a delivery, identical across its retries so that receivers can deduplicate them.
+	IDHeader = "X-Webhook-Id"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrForbiddenAddress is returned when a webhook URL resolves to an address of the internal network.
+	ErrForbiddenAddress = errors.New("forbidden webhook address")
+)
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed by the secret.
+// Signing the timestamp lets receivers reject replayed payloads.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signatureHeader returns the value of the SignatureHeader of a payload.
+func signatureHeader(secret string, timestamp time.Time, payload []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp.Unix(), Sign(secret, timestamp, payload))
+}
+
+// Verify checks the value of the SignatureHeader of a payload, which must have been signed less than tolerance ago.
+// A zero tolerance disables the timestamp check.
+func Verify(secret, header string, payload []byte, tolerance time.Duration, now time.Time) error {
+	var (
+		timestamp  time.Time
+		signatures []string
+	)
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: timestamp: %w", ErrInvalidSignature, err)
+			}
+
+			timestamp = time.Unix(unix, 0)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp.IsZero() || len(signatures) == 0 {
+		return fmt.Errorf("%w: missing timestamp or signature", ErrInvalidSignature)
+	}
+
+	if tolerance > 0 && now.Sub(timestamp).Abs() > tolerance {
+		return fmt.Errorf("%w: timestamp out of tolerance", ErrInvalidSignature)
+	}
+
+	expected := []byte(Sign(secret, timestamp, payload))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// nonPublicPrefixes are the special-purpose ranges that netip.Addr has no predicate for, yet do not reach the public
+// internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"), // Shared address space, for carrier-grade NAT.
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments.
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking.
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which translates to any IPv4 address, private ones included.
+}
+
+// PublicAddr reports whether webhooks can be delivered to an address. Loopback, private, link-local, multicast,
+// unspecified and other special-purpose addresses are refused, so that clients cannot probe the network of the
+// dispatcher.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// checkDialAddress refuses connections to addresses that are not public. As the Control hook of the dialer, it runs
+// on the resolved address of every connection, so that a host name cannot point to the internal network.
+func checkDialAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !PublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	return nil
+}
+
+// Sender posts signed JSON payloads to webhook URLs.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender returns a sender whose requests time out after the given duration.
+// It only connects to public addresses, and does not follow redirects.
+func NewSender(timeout time.Duration, now func() time.Time) *Sender {
+	return newSender(timeout, checkDialAddress, now)
+}
+
+// newSender returns a sender whose connections are checked by control, nil to allow every address.
+func newSender(timeout time.Duration, control func(network, address string, c syscall.RawConn) error, now func() time.Time) *Sender {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil // A proxy would connect to the webhook URL itself, past the check.
+
+	return &Sender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect is answered as is, and fails the delivery as any response other than 2xx.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: now,
+	}
+}
+
+// Send posts a payload to a URL and returns the response status code, 0 when no response was received.
+// Responses other than 2xx are errors.
+func (s *Sender) Send(ctx context.Context, url, secret string, id int64, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, strconv.FormatInt(id, 10))
+	req.Header.Set(SignatureHeader, signatureHeader(secret, s.now(), payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// Drain the body, so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
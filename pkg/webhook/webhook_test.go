@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		payload = []byte(`{"id":1}`)
+		header  = signatureHeader("secret", now, payload)
+	)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		payload []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			secret:  "secret",
+			header:  header,
+			payload: payload,
+			now:     now.Add(time.Minute),
+		},
+		{
+			name:    "several signatures",
+			secret:  "secret",
+			header:  "t=1704067200,v1=deadbeef," + header[len("t=1704067200,"):],
+			payload: payload,
+			now:     now,
+		},
+		{
+			name:    "wrong secret",
+			secret:  "other",
+			header:  header,
+			payload: payload,
+			now:     now,
+			wantErr: true,
+		},
+		{
+			name:    "tampered payload",
+			secret:  "secret",
+			header:  header,
+			payload: []byte(`{"id":2}`),
+			now:     now,
+			wantErr: true,
+		},
+		{
+			name:    "replayed",
+			secret:  "secret",
+			header:  header,
+			payload: payload,
+			now:     now.Add(time.Hour),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			secret:  "secret",
+			header:  "v1=abc",
+			payload: payload,
+			now:     now,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := Verify(tt.secret, tt.header, tt.payload, 5*time.Minute, tt.now)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, ErrInvalidSignature)
+				} else {
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		payload = []byte(`{"id":42}`)
+	)
+
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "delivered",
+			status:     http.StatusNoContent,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "rejected",
+			status:     http.StatusServiceUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+			wantErr:    true,
+		},
+		{
+			name:       "redirected",
+			status:     http.StatusFound,
+			wantStatus: http.StatusFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				server := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							body, err := io.ReadAll(r.Body)
+							assert.NoError(t, err)
+							assert.Equal(t, "42", r.Header.Get(IDHeader))
+							assert.NoError(t, Verify("secret", r.Header.Get(SignatureHeader), body, time.Minute, now))
+
+							if tt.status == http.StatusFound {
+								assert.Equal(t, "/", r.URL.Path, "redirect followed")
+								w.Header().Set("Location", "/redirected")
+							}
+
+							w.WriteHeader(tt.status)
+						},
+					),
+				)
+				defer server.Close()
+
+				// The test server listens on a loopback address.
+				sender := newSender(time.Second, nil, func() time.Time { return now })
+
+				status, err := sender.Send(context.Background(), server.URL, "secret", 42, payload)
+				require.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+				assert.Equal(t, tt.wantStatus, status)
+			},
+		)
+	}
+}
+
+func TestSender_Send_ForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(http.ResponseWriter, *http.Request) {
+				t.Error("request sent to a loopback address")
+			},
+		),
+	)
+	defer server.Close()
+
+	status, err := NewSender(time.Second, time.Now).Send(context.Background(), server.URL, "secret", 42, []byte(`{}`))
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.Zero(t, status)
+}
+
+func TestPublicAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14", want: true},
+		{addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "0.0.0.0"},
+		{addr: "224.0.0.1"},
+		{addr: "::ffff:127.0.0.1"},
+		{addr: "100.64.0.1"},
+		{addr: "100.127.255.254"},
+		{addr: "192.0.0.8"},
+		{addr: "198.18.0.1"},
+		{addr: "198.19.255.254"},
+		{addr: "64:ff9b::7f00:1"},
+		{addr: "64:ff9b::5db8:d70e"},
+		{addr: "::ffff:100.64.0.1"},
+		{addr: "100.128.0.1", want: true},
+		{addr: "198.20.0.1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.addr, func(t *testing.T) {
+				t.Parallel()
+
+				assert.Equal(t, tt.want, PublicAddr(netip.MustParseAddr(tt.addr)))
+			},
+		)
+	}
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: xtz/v1/delegation.proto
+
+package xtzv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DelegationService_ListDelegations_FullMethodName   = "/xtz.v1.DelegationService/ListDelegations"
+	DelegationService_GetDelegations_FullMethodName    = "/xtz.v1.DelegationService/GetDelegations"
+	DelegationService_ExportDelegations_FullMethodName = "/xtz.v1.DelegationService/ExportDelegations"
+)
+
+// DelegationServiceClient is the client API for DelegationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DelegationServiceClient interface {
+	ListDelegations(ctx context.Context, in *ListDelegationsRequest, opts ...grpc.CallOption) (*ListDelegationsResponse, error)
+	GetDelegations(ctx context.Context, in *GetDelegationsRequest, opts ...grpc.CallOption) (*GetDelegationsResponse, error)
+	ExportDelegations(ctx context.Context, in *ExportDelegationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Delegation], error)
+}
+
+type delegationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDelegationServiceClient(cc grpc.ClientConnInterface) DelegationServiceClient {
+	return &delegationServiceClient{cc}
+}
+
+func (c *delegationServiceClient) ListDelegations(ctx context.Context, in *ListDelegationsRequest, opts ...grpc.CallOption) (*ListDelegationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDelegationsResponse)
+	err := c.cc.Invoke(ctx, DelegationService_ListDelegations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *delegationServiceClient) GetDelegations(ctx context.Context, in *GetDelegationsRequest, opts ...grpc.CallOption) (*GetDelegationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDelegationsResponse)
+	err := c.cc.Invoke(ctx, DelegationService_GetDelegations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *delegationServiceClient) ExportDelegations(ctx context.Context, in *ExportDelegationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Delegation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DelegationService_ServiceDesc.Streams[0], DelegationService_ExportDelegations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportDelegationsRequest, Delegation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DelegationService_ExportDelegationsClient = grpc.ServerStreamingClient[Delegation]
+
+// DelegationServiceServer is the server API for DelegationService service.
+// All implementations must embed UnimplementedDelegationServiceServer
+// for forward compatibility.
+type DelegationServiceServer interface {
+	ListDelegations(context.Context, *ListDelegationsRequest) (*ListDelegationsResponse, error)
+	GetDelegations(context.Context, *GetDelegationsRequest) (*GetDelegationsResponse, error)
+	ExportDelegations(*ExportDelegationsRequest, grpc.ServerStreamingServer[Delegation]) error
+	mustEmbedUnimplementedDelegationServiceServer()
+}
+
+// UnimplementedDelegationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDelegationServiceServer struct{}
+
+func (UnimplementedDelegationServiceServer) ListDelegations(context.Context, *ListDelegationsRequest) (*ListDelegationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDelegations not implemented")
+}
+func (UnimplementedDelegationServiceServer) GetDelegations(context.Context, *GetDelegationsRequest) (*GetDelegationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDelegations not implemented")
+}
+func (UnimplementedDelegationServiceServer) ExportDelegations(*ExportDelegationsRequest, grpc.ServerStreamingServer[Delegation]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportDelegations not implemented")
+}
+func (UnimplementedDelegationServiceServer) mustEmbedUnimplementedDelegationServiceServer() {}
+func (UnimplementedDelegationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDelegationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DelegationServiceServer will
+// result in compilation errors.
+type UnsafeDelegationServiceServer interface {
+	mustEmbedUnimplementedDelegationServiceServer()
+}
+
+func RegisterDelegationServiceServer(s grpc.ServiceRegistrar, srv DelegationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDelegationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DelegationService_ServiceDesc, srv)
+}
+
+func _DelegationService_ListDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DelegationServiceServer).ListDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DelegationService_ListDelegations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DelegationServiceServer).ListDelegations(ctx, req.(*ListDelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DelegationService_GetDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DelegationServiceServer).GetDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DelegationService_GetDelegations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DelegationServiceServer).GetDelegations(ctx, req.(*GetDelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DelegationService_ExportDelegations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDelegationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DelegationServiceServer).ExportDelegations(m, &grpc.GenericServerStream[ExportDelegationsRequest, Delegation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DelegationService_ExportDelegationsServer = grpc.ServerStreamingServer[Delegation]
+
+// DelegationService_ServiceDesc is the grpc.ServiceDesc for DelegationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DelegationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xtz.v1.DelegationService",
+	HandlerType: (*DelegationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDelegations",
+			Handler:    _DelegationService_ListDelegations_Handler,
+		},
+		{
+			MethodName: "GetDelegations",
+			Handler:    _DelegationService_GetDelegations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportDelegations",
+			Handler:       _DelegationService_ExportDelegations_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "xtz/v1/delegation.proto",
+}